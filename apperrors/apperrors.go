@@ -0,0 +1,46 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// AppError 사용자에게 보여줄 메시지와 개발자가 확인할 내부 오류를 구분하여 보관하는 에러
+type AppError struct {
+	// 오류의 종류를 나타내는 코드(예: "SNAPSHOT_LOAD_FAILED")
+	Code string
+
+	// 알림메시지로 사용자에게 보여줄 메시지
+	UserMessage string
+
+	// 오류의 원인이 된 내부 오류, 로그에만 기록된다.
+	InternalError error
+}
+
+func New(code, userMessage string, internalErr error) *AppError {
+	return &AppError{
+		Code:          code,
+		UserMessage:   userMessage,
+		InternalError: internalErr,
+	}
+}
+
+func (e *AppError) Error() string {
+	if e.InternalError == nil {
+		return e.UserMessage
+	}
+	return fmt.Sprintf("%s(error:%s)", e.UserMessage, e.InternalError)
+}
+
+func (e *AppError) Unwrap() error {
+	return e.InternalError
+}
+
+// As err의 체인에서 AppError를 찾아 반환한다.
+func As(err error) (*AppError, bool) {
+	var appErr *AppError
+	if errors.As(err, &appErr) == true {
+		return appErr, true
+	}
+	return nil, false
+}
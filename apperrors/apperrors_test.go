@@ -0,0 +1,31 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func TestAppError(t *testing.T) {
+	assert := assert.New(t)
+
+	err := New("PAGE_FETCH_FAILED", "페이지 접근이 실패하였습니다.", io.ErrUnexpectedEOF)
+	assert.Equal("페이지 접근이 실패하였습니다.(error:unexpected EOF)", err.Error())
+	assert.True(errors.Is(err, io.ErrUnexpectedEOF))
+
+	wrapped := fmt.Errorf("작업 실패: %w", err)
+	var appErr *AppError
+	assert.True(errors.As(wrapped, &appErr))
+	assert.Equal("PAGE_FETCH_FAILED", appErr.Code)
+
+	appErr, ok := As(wrapped)
+	assert.True(ok)
+	assert.Equal("페이지 접근이 실패하였습니다.", appErr.UserMessage)
+
+	_, ok = As(io.EOF)
+	assert.False(ok)
+
+	assert.Equal("페이지 접근이 실패하였습니다.", New("PAGE_FETCH_FAILED", "페이지 접근이 실패하였습니다.", nil).Error())
+}
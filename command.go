@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/darkkaiser/notify-server/utils"
+	"os"
+)
+
+const (
+	commandHashKey = "hash-key"
+)
+
+// runCommand 서버를 실행하지 않고 처리하는 서브 커맨드를 실행한다.
+// 서브 커맨드가 아닌 경우 false를 반환한다.
+func runCommand(args []string) (handled bool, exitCode int) {
+	if len(args) == 0 {
+		return false, 0
+	}
+
+	switch args[0] {
+	case commandHashKey:
+		return true, runHashKeyCommand(args[1:])
+	}
+
+	return false, 0
+}
+
+// runHashKeyCommand 환경설정 파일의 hashed_app_key 항목에 입력할 APP_KEY의 해시값을 출력한다.
+func runHashKeyCommand(args []string) int {
+	fs := flag.NewFlagSet(commandHashKey, flag.ContinueOnError)
+	key := fs.String("key", "", "해싱할 APP_KEY")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *key == "" {
+		fmt.Fprintf(os.Stderr, "사용법: notify-server %s --key=<APP_KEY>\n", commandHashKey)
+		return 2
+	}
+
+	fmt.Println(utils.HashKey(*key))
+
+	return 0
+}
@@ -1,9 +1,13 @@
 package g
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"github.com/darkkaiser/notify-server/utils"
 	"log"
+	"net"
 	"os"
 	"strings"
 )
@@ -15,6 +19,12 @@ const (
 	AppConfigFileName = AppName + ".json"
 )
 
+// 빌드할 때 -ldflags "-X github.com/darkkaiser/notify-server/g.AppBuildDate=..." 옵션으로 설정된다.
+var (
+	AppBuildDate   = ""
+	AppBuildNumber = ""
+)
+
 // Convert JSON to Go struct : https://mholt.github.io/json-to-go/
 type AppConfig struct {
 	Debug     bool `json:"debug"`
@@ -25,11 +35,32 @@ type AppConfig struct {
 			BotToken string `json:"bot_token"`
 			ChatID   int64  `json:"chat_id"`
 		} `json:"telegrams"`
+		Discords []struct {
+			ID         string `json:"id"`
+			WebhookURL string `json:"webhook_url"`
+		} `json:"discords"`
+		Emails []struct {
+			ID            string   `json:"id"`
+			Host          string   `json:"host"`
+			Port          int      `json:"port"`
+			Username      string   `json:"username"`
+			Password      string   `json:"password"`
+			From          string   `json:"from"`
+			To            []string `json:"to"`
+			SubjectPrefix string   `json:"subject_prefix"`
+		} `json:"emails"`
+		Deduplication struct {
+			TTLSeconds int `json:"ttl_seconds"`
+		} `json:"deduplication"`
+		FallbackChain []string `json:"fallback_chain"`
 	} `json:"notifiers"`
 	Tasks []struct {
-		ID       string `json:"id"`
-		Title    string `json:"title"`
-		Commands []struct {
+		ID             string            `json:"id"`
+		Title          string            `json:"title"`
+		Headers        map[string]string `json:"headers"`
+		TimeoutSeconds int               `json:"timeout_seconds"`
+		ProxyURL       string            `json:"proxy_url"`
+		Commands       []struct {
 			ID          string `json:"id"`
 			Title       string `json:"title"`
 			Description string `json:"description"`
@@ -45,6 +76,11 @@ type AppConfig struct {
 		} `json:"commands"`
 		Data map[string]interface{} `json:"data"`
 	} `json:"tasks"`
+	TaskService struct {
+		MaxConcurrentTasks    int `json:"max_concurrent_tasks"`
+		QueueSize             int `json:"queue_size"`
+		DefaultTimeoutSeconds int `json:"default_timeout_seconds"`
+	} `json:"task_service"`
 	NotifyAPI struct {
 		WS struct {
 			TLSServer   bool   `json:"tls_server"`
@@ -52,82 +88,384 @@ type AppConfig struct {
 			TLSKeyFile  string `json:"tls_key_file"`
 			ListenPort  int    `json:"listen_port"`
 		} `json:"ws"`
+		RateLimit struct {
+			Rate          float64 `json:"rate"`
+			Burst         int     `json:"burst"`
+			SlidingWindow struct {
+				WindowSeconds int `json:"window_seconds"`
+				MaxRequests   int `json:"max_requests"`
+			} `json:"sliding_window"`
+		} `json:"rate_limit"`
 		Applications []struct {
 			ID                string `json:"id"`
 			Title             string `json:"title"`
 			Description       string `json:"description"`
 			DefaultNotifierID string `json:"default_notifier_id"`
 			AppKey            string `json:"app_key"`
+			HashedAppKey      string `json:"hashed_app_key"`
+			Admin             bool   `json:"admin"`
 		} `json:"applications"`
+		AdminIPAllowlist struct {
+			CIDRs      []string `json:"cidrs"`
+			TrustProxy bool     `json:"trust_proxy"`
+		} `json:"admin_ip_allowlist"`
+		ShutdownTimeoutSeconds int   `json:"shutdown_timeout_seconds"`
+		MaxRequestBodyBytes    int64 `json:"max_request_body_bytes"`
+		RequestTimeoutSeconds  int   `json:"request_timeout_seconds"`
+		HTTPLog                struct {
+			LogRequestBody bool `json:"log_request_body"`
+			MaxBodyBytes   int  `json:"max_body_bytes"`
+		} `json:"http_log"`
+		Webhooks []struct {
+			ID        string `json:"id"`
+			TaskID    string `json:"task_id"`
+			CommandID string `json:"command_id"`
+		} `json:"webhooks"`
 	} `json:"notify_api"`
+	Fetcher struct {
+		HTTPClient struct {
+			MaxIdleConns           int               `json:"max_idle_conns"`
+			MaxIdleConnsPerHost    int               `json:"max_idle_conns_per_host"`
+			IdleConnTimeoutSeconds int               `json:"idle_conn_timeout_seconds"`
+			RequestTimeoutSeconds  int               `json:"request_timeout_seconds"`
+			DialTimeoutSeconds     int               `json:"dial_timeout_seconds"`
+			UserAgents             []string          `json:"user_agents"`
+			Headers                map[string]string `json:"headers"`
+			ProxyURL               string            `json:"proxy_url"`
+			CACertFile             string            `json:"ca_cert_file"`
+			InsecureSkipVerify     bool              `json:"insecure_skip_verify"`
+		} `json:"http_client"`
+		CircuitBreaker struct {
+			FailureThreshold       int `json:"failure_threshold"`
+			RecoveryTimeoutSeconds int `json:"recovery_timeout_seconds"`
+		} `json:"circuit_breaker"`
+		Retry struct {
+			MaxRetries           *int  `json:"max_retries"`
+			RetryDelaySeconds    int   `json:"retry_delay_seconds"`
+			MaxRetryDelaySeconds int   `json:"max_retry_delay_seconds"`
+			RetryOnStatusCodes   []int `json:"retry_on_status_codes"`
+		} `json:"retry"`
+	} `json:"fetcher"`
+	Telemetry struct {
+		OTLPEndpoint string `json:"otlp_endpoint"`
+	} `json:"telemetry"`
+	Storage struct {
+		Type   string `json:"type"`
+		SQLite struct {
+			Path string `json:"path"`
+		} `json:"sqlite"`
+		Compression struct {
+			Enabled        bool `json:"enabled"`
+			ThresholdBytes int  `json:"threshold_bytes"`
+		} `json:"compression"`
+	} `json:"storage"`
 }
 
 func InitAppConfig() *AppConfig {
 	data, err := os.ReadFile(AppConfigFileName)
 	utils.CheckErr(err)
 
+	// 필드 타입 오류, 필수 항목 누락 등을 모두 찾아서 알려주기 위해 JSON 변환 전에 스키마 검사를 먼저 한다.
+	if err := validateAppConfigSchema(data); err != nil {
+		log.Panic(err)
+	}
+
 	var config AppConfig
 	err = json.Unmarshal(data, &config)
 	utils.CheckErr(err)
 
-	//
 	// 파일 내용에 대해 유효성 검사를 한다.
-	//
+	if err := config.validate(); err != nil {
+		log.Panic(err)
+	}
+
+	return &config
+}
+
+// LoadAppConfig 환경설정 파일을 읽어들인다.
+// InitAppConfig()와는 달리 오류가 발생하더라도 프로그램을 종료하지 않고 에러를 반환한다.
+func LoadAppConfig() (*AppConfig, error) {
+	data, err := os.ReadFile(AppConfigFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateAppConfigSchema(data); err != nil {
+		return nil, err
+	}
+
+	var config AppConfig
+	if err = json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("%s 파일의 JSON 변환이 실패하였습니다.(error:%s)", AppConfigFileName, err)
+	}
+
+	if err = config.validate(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+func (config *AppConfig) validate() error {
 	var notifierIDs []string
 	for _, telegram := range config.Notifiers.Telegrams {
 		if utils.Contains(notifierIDs, telegram.ID) == true {
-			log.Panicf("%s 파일의 내용이 유효하지 않습니다. NotifierID(%s)가 중복되었습니다.", AppConfigFileName, telegram.ID)
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. NotifierID(%s)가 중복되었습니다.", AppConfigFileName, telegram.ID)
 		}
 		notifierIDs = append(notifierIDs, telegram.ID)
 	}
+	for _, discord := range config.Notifiers.Discords {
+		if utils.Contains(notifierIDs, discord.ID) == true {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. NotifierID(%s)가 중복되었습니다.", AppConfigFileName, discord.ID)
+		}
+		notifierIDs = append(notifierIDs, discord.ID)
+
+		if strings.TrimSpace(discord.WebhookURL) == "" {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. %s Discord Notifier의 Webhook URL이 입력되지 않았습니다.", AppConfigFileName, discord.ID)
+		}
+		if err := utils.ValidateWebhookURL(discord.WebhookURL); err != nil {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. %s Discord Notifier의 %s", AppConfigFileName, discord.ID, err)
+		}
+	}
+	for _, email := range config.Notifiers.Emails {
+		if utils.Contains(notifierIDs, email.ID) == true {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. NotifierID(%s)가 중복되었습니다.", AppConfigFileName, email.ID)
+		}
+		notifierIDs = append(notifierIDs, email.ID)
+
+		if strings.TrimSpace(email.Host) == "" || email.Port <= 0 {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. %s Email Notifier의 SMTP 서버 정보(host, port)가 유효하지 않습니다.", AppConfigFileName, email.ID)
+		}
+		if strings.TrimSpace(email.From) == "" || len(email.To) == 0 {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. %s Email Notifier의 발신자(from) 또는 수신자(to)가 입력되지 않았습니다.", AppConfigFileName, email.ID)
+		}
+	}
+	if config.Notifiers.Deduplication.TTLSeconds < 0 {
+		return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 알림메시지 중복 발송 방지 시간(ttl_seconds)에 음수가 입력되었습니다.", AppConfigFileName)
+	}
+
 	if utils.Contains(notifierIDs, config.Notifiers.DefaultNotifierID) == false {
-		log.Panicf("%s 파일의 내용이 유효하지 않습니다. 전체 NotifierID 목록에서 기본 NotifierID(%s)가 존재하지 않습니다.", AppConfigFileName, config.Notifiers.DefaultNotifierID)
+		return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 전체 NotifierID 목록에서 기본 NotifierID(%s)가 존재하지 않습니다.", AppConfigFileName, config.Notifiers.DefaultNotifierID)
 	}
 
-	var taskIDs []string
+	var fallbackNotifierIDs []string
+	for _, id := range config.Notifiers.FallbackChain {
+		if utils.Contains(notifierIDs, id) == false {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 전체 NotifierID 목록에서 대체 NotifierID(%s)가 존재하지 않습니다.", AppConfigFileName, id)
+		}
+		if utils.Contains(fallbackNotifierIDs, id) == true {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 대체 NotifierID(%s)가 중복되었습니다.", AppConfigFileName, id)
+		}
+		fallbackNotifierIDs = append(fallbackNotifierIDs, id)
+	}
+
+	var taskIDs, taskCommandIDs []string
 	for _, t := range config.Tasks {
 		if utils.Contains(taskIDs, t.ID) == true {
-			log.Panicf("%s 파일의 내용이 유효하지 않습니다. TaskID(%s)가 중복되었습니다.", AppConfigFileName, t.ID)
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. TaskID(%s)가 중복되었습니다.", AppConfigFileName, t.ID)
 		}
 		taskIDs = append(taskIDs, t.ID)
 
+		if t.TimeoutSeconds < 0 {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. %s Task의 실행 제한 시간(timeout_seconds)에 음수가 입력되었습니다.", AppConfigFileName, t.ID)
+		}
+		if t.ProxyURL != "" {
+			if _, err := utils.ParseProxyURL(t.ProxyURL); err != nil {
+				return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. %s Task의 %s", AppConfigFileName, t.ID, err)
+			}
+		}
+
 		var commandIDs []string
 		for _, c := range t.Commands {
 			if utils.Contains(commandIDs, c.ID) == true {
-				log.Panicf("%s 파일의 내용이 유효하지 않습니다. CommandID(%s)가 중복되었습니다.", AppConfigFileName, c.ID)
+				return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. CommandID(%s)가 중복되었습니다.", AppConfigFileName, c.ID)
 			}
 			commandIDs = append(commandIDs, c.ID)
+			taskCommandIDs = append(taskCommandIDs, fmt.Sprintf("%s::%s", t.ID, c.ID))
 
 			if utils.Contains(notifierIDs, c.DefaultNotifierID) == false {
-				log.Panicf("%s 파일의 내용이 유효하지 않습니다. 전체 NotifierID 목록에서 %s::%s Task의 기본 NotifierID(%s)가 존재하지 않습니다.", AppConfigFileName, t.ID, c.ID, c.DefaultNotifierID)
+				return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 전체 NotifierID 목록에서 %s::%s Task의 기본 NotifierID(%s)가 존재하지 않습니다.", AppConfigFileName, t.ID, c.ID, c.DefaultNotifierID)
+			}
+
+			if c.Scheduler.Runnable == true {
+				if err := utils.ValidateCronExpressionWithTZ(c.Scheduler.TimeSpec); err != nil {
+					return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. %s::%s Task의 %s", AppConfigFileName, t.ID, c.ID, err)
+				}
 			}
 		}
 	}
 
+	if config.TaskService.DefaultTimeoutSeconds < 0 {
+		return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 작업의 기본 실행 제한 시간(default_timeout_seconds)에 음수가 입력되었습니다.", AppConfigFileName)
+	}
+	if config.TaskService.MaxConcurrentTasks < 0 || config.TaskService.QueueSize < 0 {
+		return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 작업 동시 실행 제한 값(max_concurrent_tasks, queue_size)에 음수가 입력되었습니다.", AppConfigFileName)
+	}
+
 	if config.NotifyAPI.WS.TLSServer == true {
 		if strings.TrimSpace(config.NotifyAPI.WS.TLSCertFile) == "" {
-			log.Panicf("%s 파일의 내용이 유효하지 않습니다. 웹서버의 Cert 파일 경로가 입력되지 않았습니다.", AppConfigFileName)
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 웹서버의 Cert 파일 경로가 입력되지 않았습니다.", AppConfigFileName)
 		}
 		if strings.TrimSpace(config.NotifyAPI.WS.TLSKeyFile) == "" {
-			log.Panicf("%s 파일의 내용이 유효하지 않습니다. 웹서버의 Key 파일 경로가 입력되지 않았습니다.", AppConfigFileName)
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 웹서버의 Key 파일 경로가 입력되지 않았습니다.", AppConfigFileName)
+		}
+	}
+
+	if config.NotifyAPI.ShutdownTimeoutSeconds < 0 {
+		return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 웹서버의 종료 대기 시간(shutdown_timeout_seconds)에 음수가 입력되었습니다.", AppConfigFileName)
+	}
+	if config.NotifyAPI.ShutdownTimeoutSeconds == 0 {
+		config.NotifyAPI.ShutdownTimeoutSeconds = 30
+	}
+
+	if config.NotifyAPI.MaxRequestBodyBytes < 0 {
+		return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 요청 본문의 최대 크기(max_request_body_bytes)에 음수가 입력되었습니다.", AppConfigFileName)
+	}
+	if config.NotifyAPI.MaxRequestBodyBytes == 0 {
+		config.NotifyAPI.MaxRequestBodyBytes = 64 * 1024
+	}
+
+	if config.NotifyAPI.RequestTimeoutSeconds < 0 {
+		return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 요청 처리 제한 시간(request_timeout_seconds)에 음수가 입력되었습니다.", AppConfigFileName)
+	}
+	if config.NotifyAPI.RequestTimeoutSeconds == 0 {
+		config.NotifyAPI.RequestTimeoutSeconds = 60
+	}
+
+	if config.NotifyAPI.HTTPLog.MaxBodyBytes < 0 {
+		return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 요청 본문 로그의 최대 크기(max_body_bytes)에 음수가 입력되었습니다.", AppConfigFileName)
+	}
+
+	for _, cidr := range config.NotifyAPI.AdminIPAllowlist.CIDRs {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 관리용 API의 접근 허용 IP 대역(%s)이 유효하지 않습니다.(error:%s)", AppConfigFileName, cidr, err)
 		}
 	}
 
+	if config.NotifyAPI.RateLimit.Rate < 0 || config.NotifyAPI.RateLimit.Burst < 0 {
+		return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 요청 횟수 제한 값(rate, burst)에 음수가 입력되었습니다.", AppConfigFileName)
+	}
+	if config.NotifyAPI.RateLimit.Rate > 0 && config.NotifyAPI.RateLimit.Burst == 0 {
+		config.NotifyAPI.RateLimit.Burst = 1
+	}
+	if config.NotifyAPI.RateLimit.SlidingWindow.WindowSeconds < 0 || config.NotifyAPI.RateLimit.SlidingWindow.MaxRequests < 0 {
+		return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 요청 횟수 제한 값(window_seconds, max_requests)에 음수가 입력되었습니다.", AppConfigFileName)
+	}
+	if (config.NotifyAPI.RateLimit.SlidingWindow.WindowSeconds > 0) != (config.NotifyAPI.RateLimit.SlidingWindow.MaxRequests > 0) {
+		return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 요청 횟수 제한 값(window_seconds, max_requests)은 함께 입력되어야 합니다.", AppConfigFileName)
+	}
+
+	httpClient := &config.Fetcher.HTTPClient
+	if httpClient.MaxIdleConns < 0 || httpClient.MaxIdleConnsPerHost < 0 || httpClient.IdleConnTimeoutSeconds < 0 || httpClient.RequestTimeoutSeconds < 0 || httpClient.DialTimeoutSeconds < 0 {
+		return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. HTTP 클라이언트 설정 값에 음수가 입력되었습니다.", AppConfigFileName)
+	}
+	if httpClient.MaxIdleConns == 0 {
+		httpClient.MaxIdleConns = 100
+	}
+	if httpClient.MaxIdleConnsPerHost == 0 {
+		httpClient.MaxIdleConnsPerHost = 10
+	}
+	if httpClient.IdleConnTimeoutSeconds == 0 {
+		httpClient.IdleConnTimeoutSeconds = 90
+	}
+	if httpClient.RequestTimeoutSeconds == 0 {
+		httpClient.RequestTimeoutSeconds = 60
+	}
+	if httpClient.DialTimeoutSeconds == 0 {
+		httpClient.DialTimeoutSeconds = 30
+	}
+	for _, userAgent := range httpClient.UserAgents {
+		if strings.TrimSpace(userAgent) == "" {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. HTTP 클라이언트 설정의 user_agents에 빈 문자열이 입력되었습니다.", AppConfigFileName)
+		}
+	}
+	if httpClient.ProxyURL != "" {
+		if _, err := utils.ParseProxyURL(httpClient.ProxyURL); err != nil {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. HTTP 클라이언트 설정의 %s", AppConfigFileName, err)
+		}
+	}
+	if httpClient.CACertFile != "" {
+		if _, err := utils.LoadCACertPool(httpClient.CACertFile); err != nil {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. HTTP 클라이언트 설정의 %s", AppConfigFileName, err)
+		}
+	}
+
+	if config.Fetcher.CircuitBreaker.FailureThreshold < 0 || config.Fetcher.CircuitBreaker.RecoveryTimeoutSeconds < 0 {
+		return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 서킷 브레이커 설정 값(failure_threshold, recovery_timeout_seconds)에 음수가 입력되었습니다.", AppConfigFileName)
+	}
+	if config.Fetcher.CircuitBreaker.FailureThreshold == 0 {
+		config.Fetcher.CircuitBreaker.FailureThreshold = 5
+	}
+	if config.Fetcher.CircuitBreaker.RecoveryTimeoutSeconds == 0 {
+		config.Fetcher.CircuitBreaker.RecoveryTimeoutSeconds = 60
+	}
+
+	retry := &config.Fetcher.Retry
+	if (retry.MaxRetries != nil && *retry.MaxRetries < 0) || retry.RetryDelaySeconds < 0 || retry.MaxRetryDelaySeconds < 0 {
+		return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 재시도 설정 값(max_retries, retry_delay_seconds, max_retry_delay_seconds)에 음수가 입력되었습니다.", AppConfigFileName)
+	}
+	// 재시도 횟수가 입력되지 않은 경우 2회 재시도하며, 0을 입력하면 재시도하지 않는다.
+	if retry.MaxRetries == nil {
+		maxRetries := 2
+		retry.MaxRetries = &maxRetries
+	}
+	if retry.RetryDelaySeconds == 0 {
+		retry.RetryDelaySeconds = 1
+	}
+	if retry.MaxRetryDelaySeconds == 0 {
+		retry.MaxRetryDelaySeconds = 30
+	}
+	if retry.RetryOnStatusCodes == nil {
+		retry.RetryOnStatusCodes = []int{429, 503, 504}
+	}
+	for _, statusCode := range retry.RetryOnStatusCodes {
+		if statusCode < 100 || statusCode > 599 {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 재시도할 HTTP 상태 코드(%d)가 유효하지 않습니다.", AppConfigFileName, statusCode)
+		}
+	}
+
+	if config.Storage.Type != "" && config.Storage.Type != "file" && config.Storage.Type != "sqlite" {
+		return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 지원하지 않는 저장소 타입(%s)입니다.", AppConfigFileName, config.Storage.Type)
+	}
+	if config.Storage.Compression.ThresholdBytes < 0 {
+		return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 작업결과데이터 압축 기준 크기(threshold_bytes)에 음수가 입력되었습니다.", AppConfigFileName)
+	}
+
 	var applicationIDs []string
 	for _, app := range config.NotifyAPI.Applications {
 		if utils.Contains(applicationIDs, app.ID) == true {
-			log.Panicf("%s 파일의 내용이 유효하지 않습니다. ApplicationID(%s)가 중복되었습니다.", AppConfigFileName, app.ID)
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. ApplicationID(%s)가 중복되었습니다.", AppConfigFileName, app.ID)
 		}
 		applicationIDs = append(applicationIDs, app.ID)
 
 		if utils.Contains(notifierIDs, app.DefaultNotifierID) == false {
-			log.Panicf("%s 파일의 내용이 유효하지 않습니다. 전체 NotifierID 목록에서 %s Application의 기본 NotifierID(%s)가 존재하지 않습니다.", AppConfigFileName, app.ID, app.DefaultNotifierID)
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 전체 NotifierID 목록에서 %s Application의 기본 NotifierID(%s)가 존재하지 않습니다.", AppConfigFileName, app.ID, app.DefaultNotifierID)
 		}
 
-		if len(app.AppKey) == 0 {
-			log.Panicf("%s 파일의 내용이 유효하지 않습니다. %s Application의 APP_KEY가 입력되지 않았습니다.", AppConfigFileName, app.ID)
+		if len(app.AppKey) == 0 && len(app.HashedAppKey) == 0 {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. %s Application의 APP_KEY가 입력되지 않았습니다.", AppConfigFileName, app.ID)
+		}
+		if len(app.HashedAppKey) > 0 {
+			if _, err := hex.DecodeString(app.HashedAppKey); err != nil || len(app.HashedAppKey) != sha256.Size*2 {
+				return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. %s Application의 해싱된 APP_KEY는 SHA-256 해시값(16진수 64자리)이어야 합니다.", AppConfigFileName, app.ID)
+			}
 		}
 	}
 
-	return &config
+	var webhookIDs []string
+	for _, webhook := range config.NotifyAPI.Webhooks {
+		if strings.TrimSpace(webhook.ID) == "" {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. WebhookID가 입력되지 않았습니다.", AppConfigFileName)
+		}
+		if utils.Contains(webhookIDs, webhook.ID) == true {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. WebhookID(%s)가 중복되었습니다.", AppConfigFileName, webhook.ID)
+		}
+		webhookIDs = append(webhookIDs, webhook.ID)
+
+		if utils.Contains(taskCommandIDs, fmt.Sprintf("%s::%s", webhook.TaskID, webhook.CommandID)) == false {
+			return fmt.Errorf("%s 파일의 내용이 유효하지 않습니다. 전체 Task 목록에서 %s Webhook의 작업(%s::%s)이 존재하지 않습니다.", AppConfigFileName, webhook.ID, webhook.TaskID, webhook.CommandID)
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,41 @@
+package g
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAppConfig_ValidateWebhooks(t *testing.T) {
+	cases := []struct {
+		name          string
+		webhooks      string
+		expectedError string
+	}{
+		{name: "Webhook 없음", webhooks: `[]`},
+		{name: "유효한 Webhook", webhooks: `[{"id":"release","task_id":"GITHUB","command_id":"WatchNewReleases"}]`},
+		{name: "WebhookID 누락", webhooks: `[{"id":" ","task_id":"GITHUB","command_id":"WatchNewReleases"}]`, expectedError: "WebhookID가 입력되지 않았습니다"},
+		{name: "WebhookID 중복", webhooks: `[{"id":"release","task_id":"GITHUB","command_id":"WatchNewReleases"},{"id":"release","task_id":"GITHUB","command_id":"WatchNewReleases"}]`, expectedError: "WebhookID(release)가 중복되었습니다"},
+		{name: "존재하지 않는 작업", webhooks: `[{"id":"release","task_id":"GITHUB","command_id":"Unknown"}]`, expectedError: "release Webhook의 작업(GITHUB::Unknown)이 존재하지 않습니다"},
+	}
+
+	for _, c := range cases {
+		data := `{
+	"notifiers": {"default_notifier_id": "telegram", "telegrams": [{"id": "telegram"}]},
+	"tasks": [{"id": "GITHUB", "commands": [{"id": "WatchNewReleases", "default_notifier_id": "telegram"}]}],
+	"notify_api": {"webhooks": ` + c.webhooks + `}
+}`
+
+		var config AppConfig
+		if assert.NoError(t, json.Unmarshal([]byte(data), &config), c.name) == false {
+			continue
+		}
+
+		err := config.validate()
+		if c.expectedError == "" {
+			assert.NoError(t, err, c.name)
+		} else if assert.Error(t, err, c.name) == true {
+			assert.Contains(t, err.Error(), c.expectedError, c.name)
+		}
+	}
+}
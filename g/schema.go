@@ -0,0 +1,36 @@
+package g
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"github.com/xeipuuv/gojsonschema"
+	"strings"
+)
+
+// appConfigSchema 환경설정 파일의 JSON 스키마
+// AppConfig 구조체에 새로운 설정 항목이 추가되면 스키마에도 함께 추가하여야 한다.
+//
+//go:embed notify-server.schema.json
+var appConfigSchema []byte
+
+// validateAppConfigSchema 환경설정 파일의 내용을 JSON 스키마로 검사한다.
+// 첫번째 오류에서 멈추지 않고 모든 오류를 수집하여 항목 경로와 사유를 한 줄씩 출력한 에러를 반환한다.
+func validateAppConfigSchema(data []byte) error {
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(appConfigSchema), gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return fmt.Errorf("%s 파일의 JSON 스키마 검사가 실패하였습니다.(error:%s)", AppConfigFileName, err)
+	}
+
+	if result.Valid() == true {
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s 파일의 내용이 유효하지 않습니다. 아래 %d개의 항목을 확인하세요:", AppConfigFileName, len(result.Errors())))
+	for _, e := range result.Errors() {
+		sb.WriteString(fmt.Sprintf("\n  - %s: %s", e.Field(), e.Description()))
+	}
+
+	return errors.New(sb.String())
+}
@@ -0,0 +1,35 @@
+package log
+
+import (
+	log "github.com/sirupsen/logrus"
+	"io"
+)
+
+// criticalHook Error, Fatal, Panic 레벨의 로그를 별도의 오류 로그 파일에 함께 기록한다.
+// 운영자가 전체 로그를 분석하지 않고도 오류 로그 파일만 모니터링하여 장애를 감지할 수 있도록 한다.
+type criticalHook struct {
+	writer    io.Writer
+	formatter log.Formatter
+}
+
+func newCriticalHook(writer io.Writer, formatter log.Formatter) *criticalHook {
+	return &criticalHook{
+		writer:    writer,
+		formatter: formatter,
+	}
+}
+
+func (h *criticalHook) Levels() []log.Level {
+	return []log.Level{log.ErrorLevel, log.FatalLevel, log.PanicLevel}
+}
+
+func (h *criticalHook) Fire(entry *log.Entry) error {
+	b, err := h.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.writer.Write(b)
+
+	return err
+}
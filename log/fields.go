@@ -0,0 +1,108 @@
+package log
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+)
+
+// 로그 검색 및 집계가 가능하도록 모든 컴포넌트에서 공통으로 사용하는 로그 필드명
+const (
+	FieldError      = "error"
+	FieldComponent  = "component"
+	FieldTaskID     = "task_id"
+	FieldCommandID  = "command_id"
+	FieldInstanceID = "instance_id"
+	FieldRunBy      = "run_by"
+	FieldNotifierID = "notifier_id"
+	FieldRequestID  = "request_id"
+
+	FieldRetryAttempt      = "retry_attempt"
+	FieldRetryAfterSeconds = "retry_after_seconds"
+)
+
+func WithError(err error) *log.Entry {
+	return log.WithField(FieldError, err)
+}
+
+func WithComponent(name string) *log.Entry {
+	return log.WithField(FieldComponent, name)
+}
+
+func WithTaskID(id string) *log.Entry {
+	return log.WithField(FieldTaskID, id)
+}
+
+// WithTask 작업의 TaskID, CommandID, InstanceID 필드가 포함된 로그 Entry를 반환한다.
+func WithTask(taskID, commandID, instanceID string) *log.Entry {
+	return log.WithFields(log.Fields{
+		FieldTaskID:     taskID,
+		FieldCommandID:  commandID,
+		FieldInstanceID: instanceID,
+	})
+}
+
+// TaskContext 작업 로그에 공통으로 포함되는 작업 정보
+type TaskContext struct {
+	TaskID     string
+	CommandID  string
+	InstanceID string
+	RunBy      string
+
+	// 작업 실행을 요청한 API 요청의 요청ID
+	RequestID string
+}
+
+// WithTaskContext 작업의 TaskID, CommandID, InstanceID, RunBy, RequestID 필드가 포함된 로그 Entry를 반환한다.
+// 입력되지 않은 항목은 필드에 포함되지 않는다.
+func WithTaskContext(ctx TaskContext) *log.Entry {
+	fields := log.Fields{}
+	if ctx.TaskID != "" {
+		fields[FieldTaskID] = ctx.TaskID
+	}
+	if ctx.CommandID != "" {
+		fields[FieldCommandID] = ctx.CommandID
+	}
+	if ctx.InstanceID != "" {
+		fields[FieldInstanceID] = ctx.InstanceID
+	}
+	if ctx.RunBy != "" {
+		fields[FieldRunBy] = ctx.RunBy
+	}
+	if ctx.RequestID != "" {
+		fields[FieldRequestID] = ctx.RequestID
+	}
+
+	return log.WithFields(fields)
+}
+
+func WithNotifierID(id string) *log.Entry {
+	return log.WithField(FieldNotifierID, id)
+}
+
+// requestIDContextKey 요청ID가 저장되는 context.Context의 키
+type requestIDContextKey struct{}
+
+// ContextWithRequestID 요청을 처리하는 동안 출력되는 로그에 요청ID가 함께 출력될 수 있도록 요청ID를 ctx에 저장한다.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext ctx에 저장된 요청ID를 반환한다. 저장된 요청ID가 없는 경우 빈 문자열을 반환한다.
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+
+	return requestID
+}
+
+// WithContext ctx에 요청ID가 저장된 경우 요청ID 필드가 포함된 로그 Entry를 반환한다.
+func WithContext(ctx context.Context) *log.Entry {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		return log.WithField(FieldRequestID, requestID)
+	}
+
+	return log.NewEntry(log.StandardLogger())
+}
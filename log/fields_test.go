@@ -0,0 +1,31 @@
+package log
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWithTaskContext(t *testing.T) {
+	entry := WithTaskContext(TaskContext{TaskID: "NS", CommandID: "WatchPrice_Galaxy", InstanceID: "1a", RunBy: "user"})
+	assert.Equal(t, "NS", entry.Data[FieldTaskID])
+	assert.Equal(t, "WatchPrice_Galaxy", entry.Data[FieldCommandID])
+	assert.Equal(t, "1a", entry.Data[FieldInstanceID])
+	assert.Equal(t, "user", entry.Data[FieldRunBy])
+
+	// 입력되지 않은 항목은 필드에 포함되지 않는다.
+	entry = WithTaskContext(TaskContext{TaskID: "NS"})
+	assert.Equal(t, 1, len(entry.Data))
+}
+
+func TestWithContext(t *testing.T) {
+	// 요청ID가 저장되지 않은 경우 필드가 포함되지 않는다.
+	assert.Equal(t, "", RequestIDFromContext(context.Background()))
+	assert.Equal(t, 0, len(WithContext(context.Background()).Data))
+
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	assert.Equal(t, "req-1", RequestIDFromContext(ctx))
+	assert.Equal(t, "req-1", WithContext(ctx).Data[FieldRequestID])
+
+	assert.Equal(t, "req-1", WithTaskContext(TaskContext{TaskID: "NS", RequestID: "req-1"}).Data[FieldRequestID])
+}
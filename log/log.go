@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"github.com/darkkaiser/notify-server/utils"
 	log "github.com/sirupsen/logrus"
+	"gopkg.in/natefinch/lumberjack.v2"
 	"io"
 	"io/fs"
 	"math"
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -39,7 +41,48 @@ func init() {
 	})
 }
 
+// Options 로그 파일의 교체(rotation) 조건
+// 두 조건이 모두 설정된 경우 먼저 만족되는 조건에 따라 로그 파일이 교체되며, 0인 조건은 사용하지 않는다.
+type Options struct {
+	// 로그 파일을 교체하고, 일정 시간이 지난 로그 파일을 삭제하는 기한(단위 : 일)
+	MaxAge float64
+
+	// 로그 파일을 교체하는 최대 크기(단위 : MB)
+	MaxSizeMB int
+
+	// 크기 제한으로 교체된 로그 파일을 보관하는 최대 갯수(0인 경우 모두 보관한다)
+	MaxBackups int
+
+	// Error, Fatal, Panic 레벨의 로그를 별도의 '{AppName}-critical.log' 파일에도 기록할지의 여부
+	EnableCriticalLog bool
+
+	// 오류 로그 파일에서 교체된 로그 파일을 삭제하는 기한(단위 : 일, 0인 경우 기본값을 사용한다)
+	CriticalMaxAge int
+
+	// 오류 로그 파일을 교체하는 최대 크기(단위 : MB, 0인 경우 기본값을 사용한다)
+	CriticalMaxSizeMB int
+}
+
+const (
+	defaultMaxSizeMB  = 100
+	defaultMaxBackups = 5
+
+	// 오류 로그 파일은 알림 파이프라인 등에서 모니터링하는 용도이므로 일반 로그 파일보다 짧은 기간, 작은 크기로 교체한다.
+	defaultCriticalMaxAge    = 7
+	defaultCriticalMaxSizeMB = 10
+
+	criticalLogFileSuffix string = "-critical"
+)
+
 func Init(debug bool, appName string, checkDaysAgo float64) io.Closer {
+	return Setup(debug, appName, Options{
+		MaxAge:     checkDaysAgo,
+		MaxSizeMB:  defaultMaxSizeMB,
+		MaxBackups: defaultMaxBackups,
+	})
+}
+
+func Setup(debug bool, appName string, options Options) io.Closer {
 	if debug == true {
 		return nil
 	}
@@ -53,17 +96,126 @@ func Init(debug bool, appName string, checkDaysAgo float64) io.Closer {
 	}
 
 	// 로그 파일을 생성한다.
+	// 크기 제한으로 교체된 로그 파일은 '{로그 파일명}-{교체시각}.log' 형식의 이름으로 같은 폴더에 보관된다.
 	t := time.Now()
 	logFilePath := fmt.Sprintf("%s%s%s-%d%02d%02d%02d%02d%02d.%s", logDirPath, string(os.PathSeparator), appName, t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), logFileExtension)
-	logFile, err := os.OpenFile(logFilePath, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+	logFile := &lumberjack.Logger{
+		Filename:   logFilePath,
+		MaxSize:    options.MaxSizeMB,
+		MaxBackups: options.MaxBackups,
+		LocalTime:  true,
+	}
+	if options.MaxSizeMB <= 0 {
+		// lumberjack은 MaxSize가 0인 경우 기본값(100MB)을 사용하므로, 크기 제한으로 교체되지 않도록 최대값을 설정한다.
+		logFile.MaxSize = math.MaxInt32
+	}
+
+	// 로그 파일을 미리 생성하여 로그 파일을 생성할 수 없는 경우 바로 알 수 있도록 한다.
+	_, err = logFile.Write(nil)
 	utils.CheckErr(err)
 
 	log.SetOutput(logFile)
 
 	// 일정 시간이 지난 로그 파일을 모두 삭제한다.
-	cleanOutOfLogFiles(appName, checkDaysAgo)
+	if options.MaxAge > 0 {
+		cleanOutOfLogFiles(appName, options.MaxAge)
+	}
+
+	closer := &logFileCloser{
+		logFile: logFile,
+		stopC:   make(chan struct{}),
+	}
+
+	// 오류 로그 파일을 생성하고, Error 레벨 이상의 로그가 함께 기록되도록 한다.
+	if options.EnableCriticalLog == true {
+		criticalLogFile := &lumberjack.Logger{
+			Filename:   fmt.Sprintf("%s%s%s%s.%s", logDirPath, string(os.PathSeparator), appName, criticalLogFileSuffix, logFileExtension),
+			MaxSize:    options.CriticalMaxSizeMB,
+			MaxAge:     options.CriticalMaxAge,
+			MaxBackups: options.MaxBackups,
+			LocalTime:  true,
+		}
+		if criticalLogFile.MaxSize <= 0 {
+			criticalLogFile.MaxSize = defaultCriticalMaxSizeMB
+		}
+		if criticalLogFile.MaxAge <= 0 {
+			criticalLogFile.MaxAge = defaultCriticalMaxAge
+		}
+
+		_, err = criticalLogFile.Write(nil)
+		utils.CheckErr(err)
+
+		closer.criticalLogFile = criticalLogFile
+		closer.criticalHook = newCriticalHook(criticalLogFile, log.StandardLogger().Formatter)
+
+		log.AddHook(closer.criticalHook)
+	}
+
+	// 일정 시간마다 로그 파일을 교체한다.
+	if options.MaxAge > 0 {
+		go closer.rotateEvery(time.Duration(options.MaxAge*24*float64(time.Hour)), func() {
+			cleanOutOfLogFiles(appName, options.MaxAge)
+		})
+	}
+
+	return closer
+}
+
+// logFileCloser 로그 파일을 닫을 때 로그 파일의 교체 작업도 함께 중지한다.
+type logFileCloser struct {
+	logFile *lumberjack.Logger
+
+	// 오류 로그 파일과 오류 로그 파일에 기록하는 Hook(사용하지 않는 경우 nil이다)
+	criticalLogFile *lumberjack.Logger
+	criticalHook    *criticalHook
+
+	stopC    chan struct{}
+	stopOnce sync.Once
+}
+
+func (c *logFileCloser) rotateEvery(d time.Duration, rotatedFn func()) {
+	ticker := time.NewTicker(d)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.logFile.Rotate(); err != nil {
+				log.Errorf("로그파일 교체 실패, %s", err)
+				continue
+			}
+
+			rotatedFn()
+
+		case <-c.stopC:
+			return
+		}
+	}
+}
+
+func (c *logFileCloser) Close() error {
+	c.stopOnce.Do(func() {
+		close(c.stopC)
+	})
+
+	if c.criticalLogFile != nil {
+		// 닫힌 오류 로그 파일이 다시 열리지 않도록 오류 로그 파일에 기록하는 Hook을 제거한다.
+		hooks := make(log.LevelHooks)
+		for level, levelHooks := range log.StandardLogger().ReplaceHooks(make(log.LevelHooks)) {
+			for _, h := range levelHooks {
+				if h != c.criticalHook {
+					hooks[level] = append(hooks[level], h)
+				}
+			}
+		}
+		log.StandardLogger().ReplaceHooks(hooks)
 
-	return logFile
+		if err := c.criticalLogFile.Close(); err != nil {
+			return err
+		}
+	}
+
+	return c.logFile.Close()
 }
 
 func cleanOutOfLogFiles(appName string, checkDaysAgo float64) {
@@ -88,6 +240,10 @@ func cleanOutOfLogFiles(appName string, checkDaysAgo float64) {
 		if strings.HasPrefix(fileName, appName) == false || strings.HasSuffix(fileName, logFileExtension) == false {
 			continue
 		}
+		// 오류 로그 파일은 lumberjack에서 별도의 기한으로 삭제한다.
+		if strings.HasPrefix(fileName, appName+criticalLogFileSuffix) == true {
+			continue
+		}
 
 		daysAgo := math.Abs(t.Sub(fi.ModTime()).Hours()) / 24
 		if daysAgo >= checkDaysAgo {
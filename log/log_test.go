@@ -71,3 +71,79 @@ func TestLog(t *testing.T) {
 	fiList, _ = ioutil.ReadDir(logDirPath)
 	assert.Equal(0, len(fiList))
 }
+
+func TestSetupWithMaxSizeMB(t *testing.T) {
+	// 로그가 생성되는 폴더를 임시폴더로 설정한다.
+	logDirParentPath = fmt.Sprintf("%s%s", t.TempDir(), string(os.PathSeparator))
+
+	var logDirPath = fmt.Sprintf("%s%s", logDirParentPath, logDirName)
+	var appName = "log-package-testing"
+
+	assert := assert.New(t)
+
+	//
+	// 최대 크기를 초과하면, 로그파일이 교체되어 2개의 파일이 존재하여야 한다.
+	//
+	lf := Setup(false, appName, Options{MaxSizeMB: 1})
+	assert.NotNil(lf)
+
+	line := strings.Repeat("0123456789", 100)
+	for i := 0; i < 1100; i++ {
+		log.Info(line)
+	}
+
+	_ = lf.Close()
+	log.SetOutput(os.Stderr)
+
+	fiList, _ := ioutil.ReadDir(logDirPath)
+	assert.Equal(2, len(fiList))
+	for _, fi := range fiList {
+		assert.True(strings.HasPrefix(fi.Name(), appName))
+		assert.True(strings.HasSuffix(fi.Name(), logFileExtension))
+	}
+}
+
+func TestSetupWithCriticalLog(t *testing.T) {
+	// 로그가 생성되는 폴더를 임시폴더로 설정한다.
+	logDirParentPath = fmt.Sprintf("%s%s", t.TempDir(), string(os.PathSeparator))
+
+	var logDirPath = fmt.Sprintf("%s%s", logDirParentPath, logDirName)
+	var appName = "log-package-testing"
+
+	assert := assert.New(t)
+
+	//
+	// 오류 로그 파일을 사용하면, 로그파일과 함께 오류 로그 파일이 생성되어져야 한다.
+	//
+	lf := Setup(false, appName, Options{EnableCriticalLog: true})
+	assert.NotNil(lf)
+
+	log.Info("info message")
+	log.Error("error message")
+
+	_ = lf.Close()
+	log.SetOutput(os.Stderr)
+
+	fiList, _ := ioutil.ReadDir(logDirPath)
+	assert.Equal(2, len(fiList))
+
+	//
+	// 오류 로그 파일에는 Error 레벨 이상의 로그만 기록되어야 한다.
+	//
+	b, err := ioutil.ReadFile(fmt.Sprintf("%s%s%s%s.%s", logDirPath, string(os.PathSeparator), appName, criticalLogFileSuffix, logFileExtension))
+	assert.Nil(err)
+	assert.True(strings.Contains(string(b), "error message"))
+	assert.False(strings.Contains(string(b), "info message"))
+
+	// 로그파일을 닫으면 오류 로그 파일에 기록하는 Hook도 제거되어야 한다.
+	assert.Equal(0, len(log.StandardLogger().Hooks[log.ErrorLevel]))
+
+	//
+	// 오류 로그 파일은 기한이 지난 로그파일을 삭제할 때 삭제되지 않아야 한다.
+	//
+	cleanOutOfLogFiles(appName, 0)
+
+	fiList, _ = ioutil.ReadDir(logDirPath)
+	assert.Equal(1, len(fiList))
+	assert.Equal(appName+criticalLogFileSuffix+"."+logFileExtension, fiList[0].Name())
+}
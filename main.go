@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/darkkaiser/notify-server/g"
 	_log_ "github.com/darkkaiser/notify-server/log"
+	"github.com/darkkaiser/notify-server/metrics"
 	"github.com/darkkaiser/notify-server/service"
 	"github.com/darkkaiser/notify-server/service/api"
 	"github.com/darkkaiser/notify-server/service/notification"
@@ -33,31 +34,50 @@ const (
 func main() {
 	runtime.GOMAXPROCS(runtime.NumCPU()) // 모든 CPU 사용
 
+	// 서브 커맨드가 입력된 경우, 서브 커맨드만 실행하고 종료한다.
+	if handled, exitCode := runCommand(os.Args[1:]); handled == true {
+		os.Exit(exitCode)
+	}
+
 	// 환경설정 정보를 읽어들인다.
 	config := g.InitAppConfig()
 
 	// 로그를 초기화하고, 일정 시간이 지난 로그 파일을 모두 삭제한다.
-	_log_.Init(config.Debug, g.AppName, 30.)
+	// Error 레벨 이상의 로그는 알림 파이프라인에서 모니터링할 수 있도록 별도의 오류 로그 파일에도 기록한다.
+	_log_.Setup(config.Debug, g.AppName, _log_.Options{
+		MaxAge:     30.,
+		MaxSizeMB:  100,
+		MaxBackups: 5,
+
+		EnableCriticalLog: true,
+	})
 
 	// 아스키아트 출력(https://ko.rakko.tools/tools/68/, 폰트:standard)
 	fmt.Printf(banner, g.AppVersion)
 
 	// 서비스를 생성하고 초기화한다.
-	taskService := task.NewService(config)
+	taskService := task.NewService(config, task.NewUUIDGenerator())
 	notificationService := notification.NewService(config, taskService)
-	notifyAPIService := api.NewNotifyAPIService(config, notificationService)
+	notifyAPIService := api.NewNotifyAPIService(config, notificationService, taskService, taskService)
 
 	taskService.SetTaskNotificationSender(notificationService)
+	taskService.SetMetricsCollector(metrics.NewPrometheusCollector())
 
 	// Set up cancellation context and waitgroup
 	serviceStopCtx, cancel := context.WithCancel(context.Background())
 	serviceStopWaiter := &sync.WaitGroup{}
 
+	// API 서비스로 요청된 작업이 모두 실행 요청된 후에 다른 서비스가 중지되도록 API 서비스는 별도로 중지한다.
+	apiServiceStopCtx, apiCancel := context.WithCancel(context.Background())
+	apiServiceStopWaiter := &sync.WaitGroup{}
+
 	// 서비스를 시작한다.
-	for _, s := range []service.Service{taskService, notificationService, notifyAPIService} {
+	for _, s := range []service.Service{taskService, notificationService} {
 		serviceStopWaiter.Add(1)
 		s.Run(serviceStopCtx, serviceStopWaiter)
 	}
+	apiServiceStopWaiter.Add(1)
+	notifyAPIService.Run(apiServiceStopCtx, apiServiceStopWaiter)
 
 	// Handle sigterm and await termC signal
 	termC := make(chan os.Signal)
@@ -67,6 +87,8 @@ func main() {
 
 	// Handle shutdown
 	log.Info("Shutdown signal received")
-	cancel()                 // Signal cancellation to context.Context
-	serviceStopWaiter.Wait() // Block here until are workers are done
+	apiCancel()                 // 처리중인 API 요청이 완료될 때까지 기다린 후
+	apiServiceStopWaiter.Wait() // 나머지 서비스를 중지한다.
+	cancel()                    // Signal cancellation to context.Context
+	serviceStopWaiter.Wait()    // Block here until are workers are done
 }
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+)
+
+// PrometheusCollector Task 서비스의 상태를 Prometheus 메트릭으로 기록한다.
+type PrometheusCollector struct{}
+
+func NewPrometheusCollector() *PrometheusCollector {
+	return &PrometheusCollector{}
+}
+
+func (c *PrometheusCollector) TaskSubmitted(taskID, commandID string) {
+	TasksSubmittedTotal.WithLabelValues(taskID, commandID).Inc()
+}
+
+func (c *PrometheusCollector) TaskCompleted(taskID, commandID string, duration time.Duration, success bool) {
+	TasksCompletedTotal.WithLabelValues(taskID, commandID, strconv.FormatBool(success)).Inc()
+	TaskDurationSeconds.WithLabelValues(taskID).Observe(duration.Seconds())
+}
+
+func (c *PrometheusCollector) TaskCancelled(taskID, commandID string) {
+	TasksCancelledTotal.WithLabelValues(taskID, commandID).Inc()
+}
+
+func (c *PrometheusCollector) QueueDepth(n int) {
+	TaskQueueDepth.Set(float64(n))
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "notify"
+
+var (
+	TasksSubmittedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_submitted_total",
+		Help:      "실행 요청된 Task의 수",
+	}, []string{"task_id", "command_id"})
+
+	TasksCompletedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_completed_total",
+		Help:      "실행이 끝난 Task의 수",
+	}, []string{"task_id", "command_id", "success"})
+
+	TasksCancelledTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_cancelled_total",
+		Help:      "취소된 Task의 수",
+	}, []string{"task_id", "command_id"})
+
+	TaskQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "task_queue_depth",
+		Help:      "작업 대기열에서 실행을 대기중인 Task의 수",
+	})
+
+	TasksRunning = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "tasks_running",
+		Help:      "현재 실행중인 Task의 수",
+	})
+
+	TaskDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "task_duration_seconds",
+		Help:      "Task의 실행 시간(초)",
+		Buckets:   []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"task_id"})
+
+	NotificationsSentTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notifications_sent_total",
+		Help:      "발송 요청된 알림메시지의 수",
+	}, []string{"notifier_id", "error_occurred"})
+
+	HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "NotifyAPI 서비스로 수신된 HTTP 요청의 수",
+	}, []string{"method", "path", "status"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		TasksSubmittedTotal,
+		TasksCompletedTotal,
+		TasksCancelledTotal,
+		TaskQueueDepth,
+		TasksRunning,
+		TaskDurationSeconds,
+		NotificationsSentTotal,
+		HTTPRequestsTotal,
+	)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/darkkaiser/notify-server/service/api/model"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strings"
+)
+
+// authenticateRealm 인증이 실패한 경우 WWW-Authenticate 헤더로 전달되는 영역 이름
+const authenticateRealm = "notify-server"
+
+// RequireAuthentication 요청된 APP_KEY와 일치하는 Application이 존재하는지 확인한다.
+// APP_KEY는 'Authorization: Bearer <APP_KEY>' 헤더를 먼저 확인하고, 헤더가 없는 경우 'app_key' 쿼리 파라미터에서 읽는다.
+func (h *Handler) RequireAuthentication(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		appKey := requestAppKey(c)
+		if appKey == "" {
+			c.Response().Header().Set(echo.HeaderWWWAuthenticate, fmt.Sprintf("Bearer realm=\"%s\"", authenticateRealm))
+			return echo.NewHTTPError(http.StatusUnauthorized, "APP_KEY가 입력되지 않았습니다.")
+		}
+
+		for _, application := range h.allowedApplications {
+			if application.MatchAppKey(appKey) == true {
+				c.Set(model.ContextKeyAllowedApplication, application)
+
+				return next(c)
+			}
+		}
+
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, fmt.Sprintf("Bearer realm=\"%s\", error=\"invalid_token\"", authenticateRealm))
+		return echo.NewHTTPError(http.StatusUnauthorized, "APP_KEY가 유효하지 않습니다.")
+	}
+}
+
+// requestAppKey 요청의 Authorization 헤더 또는 'app_key' 쿼리 파라미터에 입력된 APP_KEY를 반환한다.
+// 쿼리 파라미터로 전달된 APP_KEY는 접근 로그 등에 노출될 수 있으므로 Authorization 헤더를 우선한다.
+func requestAppKey(c echo.Context) string {
+	const bearerPrefix = "Bearer "
+
+	if authorization := c.Request().Header.Get(echo.HeaderAuthorization); len(authorization) > len(bearerPrefix) && strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) == true {
+		if appKey := strings.TrimSpace(authorization[len(bearerPrefix):]); appKey != "" {
+			return appKey
+		}
+	}
+
+	return c.QueryParam("app_key")
+}
+
+// RequireAdmin 인증된 Application이 관리용 API의 사용 권한을 가지고 있는지 확인한다.
+// 반드시 RequireAuthentication 미들웨어의 뒤에 위치하여야 한다.
+func (h *Handler) RequireAdmin(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		application := AuthenticatedApplication(c)
+		if application == nil || application.Admin == false {
+			return echo.NewHTTPError(http.StatusForbidden, "관리자 권한이 없는 APP_KEY입니다.")
+		}
+
+		return next(c)
+	}
+}
+
+// AuthenticatedApplication RequireAuthentication에 의해 인증된 Application 정보를 반환한다.
+func AuthenticatedApplication(c echo.Context) *model.AllowedApplication {
+	application, _ := c.Get(model.ContextKeyAllowedApplication).(*model.AllowedApplication)
+	return application
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"github.com/darkkaiser/notify-server/service/api/model"
+	"github.com/darkkaiser/notify-server/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_RequireAuthentication(t *testing.T) {
+	h := &Handler{
+		allowedApplications: []*model.AllowedApplication{
+			{ID: "legacy", AppKey: "legacy-key"},
+			{ID: "hashed", HashedAppKey: utils.HashKey("hashed-key")},
+		},
+	}
+
+	cases := []struct {
+		appKey        string
+		expectedCode  int
+		expectedAppID string
+	}{
+		{appKey: "", expectedCode: http.StatusUnauthorized},
+		{appKey: "unknown-key", expectedCode: http.StatusUnauthorized},
+		{appKey: "legacy-key", expectedCode: http.StatusOK, expectedAppID: "legacy"},
+		{appKey: "hashed-key", expectedCode: http.StatusOK, expectedAppID: "hashed"},
+		{appKey: utils.HashKey("hashed-key"), expectedCode: http.StatusUnauthorized},
+	}
+
+	e := echo.New()
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/?app_key="+c.appKey, nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		var authenticatedAppID string
+		err := h.RequireAuthentication(func(c echo.Context) error {
+			authenticatedAppID = AuthenticatedApplication(c).ID
+			return c.NoContent(http.StatusOK)
+		})(ctx)
+
+		if c.expectedCode == http.StatusOK {
+			assert.Nil(t, err, c.appKey)
+			assert.Equal(t, c.expectedAppID, authenticatedAppID, c.appKey)
+		} else {
+			httpErr, ok := err.(*echo.HTTPError)
+			assert.True(t, ok, c.appKey)
+			if ok == true {
+				assert.Equal(t, c.expectedCode, httpErr.Code, c.appKey)
+			}
+		}
+	}
+}
+
+func TestHandler_RequireAuthenticationWithBearerToken(t *testing.T) {
+	h := &Handler{
+		allowedApplications: []*model.AllowedApplication{
+			{ID: "legacy", AppKey: "legacy-key"},
+		},
+	}
+
+	cases := []struct {
+		authorization        string
+		appKey               string
+		expectedCode         int
+		expectedAuthenticate string
+	}{
+		{authorization: "Bearer legacy-key", expectedCode: http.StatusOK},
+		{authorization: "bearer legacy-key", expectedCode: http.StatusOK},
+		{authorization: "Bearer unknown-key", appKey: "legacy-key", expectedCode: http.StatusUnauthorized, expectedAuthenticate: `Bearer realm="notify-server", error="invalid_token"`},
+		{authorization: "Basic legacy-key", appKey: "legacy-key", expectedCode: http.StatusOK},
+		{authorization: "Bearer ", appKey: "legacy-key", expectedCode: http.StatusOK},
+		{authorization: "", appKey: "", expectedCode: http.StatusUnauthorized, expectedAuthenticate: `Bearer realm="notify-server"`},
+	}
+
+	e := echo.New()
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/?app_key="+c.appKey, nil)
+		if c.authorization != "" {
+			req.Header.Set(echo.HeaderAuthorization, c.authorization)
+		}
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		err := h.RequireAuthentication(func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})(ctx)
+
+		if c.expectedCode == http.StatusOK {
+			assert.Nil(t, err, c.authorization)
+		} else {
+			httpErr, ok := err.(*echo.HTTPError)
+			assert.True(t, ok, c.authorization)
+			if ok == true {
+				assert.Equal(t, c.expectedCode, httpErr.Code, c.authorization)
+			}
+			assert.Equal(t, c.expectedAuthenticate, rec.Header().Get(echo.HeaderWWWAuthenticate), c.authorization)
+		}
+	}
+}
+
+func TestHandler_RequireAdmin(t *testing.T) {
+	h := &Handler{}
+
+	cases := []struct {
+		application  *model.AllowedApplication
+		expectedCode int
+	}{
+		{application: nil, expectedCode: http.StatusForbidden},
+		{application: &model.AllowedApplication{ID: "user", Admin: false}, expectedCode: http.StatusForbidden},
+		{application: &model.AllowedApplication{ID: "admin", Admin: true}, expectedCode: http.StatusNoContent},
+	}
+
+	e := echo.New()
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodDelete, "/", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		if c.application != nil {
+			ctx.Set(model.ContextKeyAllowedApplication, c.application)
+		}
+
+		err := h.RequireAdmin(func(c echo.Context) error {
+			return c.NoContent(http.StatusNoContent)
+		})(ctx)
+
+		if c.expectedCode == http.StatusNoContent {
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+		} else {
+			httpErr, ok := err.(*echo.HTTPError)
+			assert.True(t, ok)
+			if ok == true {
+				assert.Equal(t, c.expectedCode, httpErr.Code)
+			}
+		}
+	}
+}
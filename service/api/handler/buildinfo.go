@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"github.com/darkkaiser/notify-server/g"
+	"github.com/darkkaiser/notify-server/service/api/model"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"runtime"
+)
+
+func (h *Handler) BuildInfoHandler(c echo.Context) error {
+	return c.JSON(http.StatusOK, &model.BuildInfo{
+		Version:     g.AppVersion,
+		BuildDate:   g.AppBuildDate,
+		BuildNumber: g.AppBuildNumber,
+		GoVersion:   runtime.Version(),
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+	})
+}
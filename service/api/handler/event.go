@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/darkkaiser/notify-server/service/api/model"
+	"github.com/darkkaiser/notify-server/service/notification"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// 연결이 유휴 상태로 끊어지지 않도록 주석 메시지를 전송하는 주기
+const notificationEventKeepAliveInterval = 30 * time.Second
+
+// NotificationEventStreamHandler 발송된 알림메시지를 Server-Sent Events 형식으로 클라이언트가 연결을 종료할 때까지 전송한다.
+// 클라이언트가 재연결하면서 Last-Event-ID 헤더를 전달한 경우, 최근 5분 이내에 발생된 이벤트 중에서 해당 이벤트 이후의 이벤트를 먼저 전송한다.
+// 인증된 Application의 기본 Notifier로 발송된 알림메시지의 이벤트만 전송한다.
+func (h *Handler) NotificationEventStreamHandler(c echo.Context) error {
+	application := AuthenticatedApplication(c)
+	if application == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "인증된 Application 정보가 없습니다.")
+	}
+	notifierID := notification.NotifierID(application.DefaultNotifierID)
+
+	var lastEventID int64
+	if s := c.Request().Header.Get("Last-Event-ID"); s != "" {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || n < 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Last-Event-ID는 0 이상의 숫자이어야 합니다.")
+		}
+		lastEventID = n
+	}
+
+	missedEvents, eventC, unsubscribe := h.notificationSender.SubscribeEvents(lastEventID)
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	for _, e := range missedEvents {
+		if e.NotifierID != notifierID {
+			continue
+		}
+		if err := writeNotificationEvent(res, e); err != nil {
+			return nil
+		}
+	}
+
+	ticker := time.NewTicker(notificationEventKeepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case e, ok := <-eventC:
+			// Notification 서비스가 중지된 경우
+			if ok == false {
+				return nil
+			}
+			if e.NotifierID != notifierID {
+				continue
+			}
+			if err := writeNotificationEvent(res, e); err != nil {
+				return nil
+			}
+
+		case <-ticker.C:
+			if _, err := fmt.Fprint(res, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+
+		case <-c.Request().Context().Done():
+			return nil
+
+		// 서버가 종료되는 경우
+		case <-h.eventStreamStopC:
+			return nil
+		}
+	}
+}
+
+func writeNotificationEvent(res *echo.Response, e notification.NotificationEvent) error {
+	data, err := json.Marshal(&model.NotificationEvent{
+		ID:            e.ID,
+		NotifierID:    string(e.NotifierID),
+		TaskID:        string(e.TaskID),
+		Title:         e.Title,
+		Message:       e.Message,
+		ErrorOccurred: e.ErrorOccurred,
+		SentAt:        e.SentAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err = fmt.Fprintf(res, "id: %d\ndata: %s\n\n", e.ID, data); err != nil {
+		return err
+	}
+	res.Flush()
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"github.com/darkkaiser/notify-server/service/api/model"
+	"github.com/darkkaiser/notify-server/service/notification"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// eventTestNotificationSender 알림 이벤트 구독만 지원하는 테스트용 NotificationSender
+type eventTestNotificationSender struct {
+	notification.NotificationSender
+
+	missedEvents []notification.NotificationEvent
+	eventC       chan notification.NotificationEvent
+}
+
+func (s *eventTestNotificationSender) SubscribeEvents(_ int64) ([]notification.NotificationEvent, <-chan notification.NotificationEvent, func()) {
+	return s.missedEvents, s.eventC, func() {}
+}
+
+func TestHandler_NotificationEventStreamHandler(t *testing.T) {
+	sender := &eventTestNotificationSender{
+		missedEvents: []notification.NotificationEvent{
+			{ID: 1, NotifierID: "app-notifier", Message: "missed-mine"},
+			{ID: 2, NotifierID: "other-notifier", Message: "missed-other"},
+		},
+		eventC: make(chan notification.NotificationEvent, 2),
+	}
+	sender.eventC <- notification.NotificationEvent{ID: 3, NotifierID: "other-notifier", Message: "live-other"}
+	sender.eventC <- notification.NotificationEvent{ID: 4, NotifierID: "app-notifier", Message: "live-mine"}
+
+	h := &Handler{
+		notificationSender: sender,
+		eventStreamStopC:   make(chan struct{}),
+	}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil)
+	req.Header.Set("Last-Event-ID", "0")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(model.ContextKeyAllowedApplication, &model.AllowedApplication{ID: "app", DefaultNotifierID: "app-notifier"})
+
+	doneC := make(chan error)
+	go func() {
+		doneC <- h.NotificationEventStreamHandler(c)
+	}()
+
+	// 서버가 종료되면 클라이언트가 연결을 유지하고 있더라도 스트림이 종료된다.
+	time.Sleep(100 * time.Millisecond)
+	h.StopEventStreams()
+
+	select {
+	case err := <-doneC:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("서버가 종료되었지만 알림 이벤트 스트림이 종료되지 않았습니다.")
+	}
+
+	// 인증된 Application의 기본 Notifier로 발송된 알림 이벤트만 전송된다.
+	body := rec.Body.String()
+	assert.Contains(t, body, "missed-mine")
+	assert.Contains(t, body, "live-mine")
+	assert.NotContains(t, body, "missed-other")
+	assert.NotContains(t, body, "live-other")
+}
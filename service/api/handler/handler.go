@@ -4,6 +4,8 @@ import (
 	"github.com/darkkaiser/notify-server/g"
 	"github.com/darkkaiser/notify-server/service/api/model"
 	"github.com/darkkaiser/notify-server/service/notification"
+	"github.com/darkkaiser/notify-server/service/task"
+	"sync"
 )
 
 //
@@ -12,10 +14,19 @@ import (
 type Handler struct {
 	allowedApplications []*model.AllowedApplication
 
+	webhooks []*model.Webhook
+
 	notificationSender notification.NotificationSender
+
+	taskRunner  task.TaskRunner
+	taskMonitor task.TaskMonitor
+
+	// 서버가 종료될 때 연결을 유지하고 있는 알림 이벤트 스트림을 종료시키기 위한 채널
+	eventStreamStopC    chan struct{}
+	eventStreamStopOnce sync.Once
 }
 
-func NewHandler(config *g.AppConfig, notificationSender notification.NotificationSender) *Handler {
+func NewHandler(config *g.AppConfig, notificationSender notification.NotificationSender, taskRunner task.TaskRunner, taskMonitor task.TaskMonitor) *Handler {
 	// 허용된 Application 목록을 구한다.
 	var applications []*model.AllowedApplication
 	for _, application := range config.NotifyAPI.Applications {
@@ -25,12 +36,39 @@ func NewHandler(config *g.AppConfig, notificationSender notification.Notificatio
 			Description:       application.Description,
 			DefaultNotifierID: application.DefaultNotifierID,
 			AppKey:            application.AppKey,
+			HashedAppKey:      application.HashedAppKey,
+			Admin:             application.Admin,
+		})
+	}
+
+	// 등록된 Webhook 목록을 구한다.
+	var webhooks []*model.Webhook
+	for _, webhook := range config.NotifyAPI.Webhooks {
+		webhooks = append(webhooks, &model.Webhook{
+			ID:        webhook.ID,
+			TaskID:    webhook.TaskID,
+			CommandID: webhook.CommandID,
 		})
 	}
 
 	return &Handler{
 		allowedApplications: applications,
 
+		webhooks: webhooks,
+
 		notificationSender: notificationSender,
+
+		taskRunner:  taskRunner,
+		taskMonitor: taskMonitor,
+
+		eventStreamStopC: make(chan struct{}),
 	}
 }
+
+// StopEventStreams 연결을 유지하고 있는 모든 알림 이벤트 스트림을 종료시킨다.
+// 알림 이벤트 스트림은 클라이언트가 연결을 종료할 때까지 응답이 완료되지 않으므로, 서버를 종료하기 전에 호출하여야 한다.
+func (h *Handler) StopEventStreams() {
+	h.eventStreamStopOnce.Do(func() {
+		close(h.eventStreamStopC)
+	})
+}
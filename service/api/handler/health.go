@@ -0,0 +1,47 @@
+package handler
+
+import (
+	_log_ "github.com/darkkaiser/notify-server/log"
+	"github.com/darkkaiser/notify-server/service/api/model"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+func (h *Handler) HealthHandler(c echo.Context) error {
+	components := []struct {
+		name   string
+		health func() error
+	}{
+		{"task_service", h.taskMonitor.Health},
+		{"notification_service", h.notificationSender.Health},
+		{"telegram", h.notificationSender.TelegramHealth},
+	}
+
+	health := &model.Health{
+		Status:          model.HealthStatusOK,
+		Components:      make(map[string]string, len(components)),
+		RunningTasks:    h.taskMonitor.GetRunningTaskCount(),
+		SchedulerPaused: h.taskMonitor.IsPaused(),
+	}
+	for _, component := range components {
+		if err := component.health(); err != nil {
+			_log_.WithContext(c.Request().Context()).WithField(_log_.FieldComponent, component.name).WithError(err).Warn("컴포넌트의 상태가 정상이 아닙니다.")
+
+			health.Status = model.HealthStatusDegraded
+			health.Components[component.name] = err.Error()
+		} else {
+			health.Components[component.name] = model.HealthStatusOK
+		}
+	}
+
+	if health.Status != model.HealthStatusOK {
+		return c.JSON(http.StatusServiceUnavailable, health)
+	}
+
+	// 일시 중지된 상태에서도 서버는 정상적으로 동작중이므로 상태 코드는 200을 반환한다.
+	if health.SchedulerPaused == true {
+		health.Status = model.HealthStatusPaused
+	}
+
+	return c.JSON(http.StatusOK, health)
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/darkkaiser/notify-server/service/api/model"
+	"github.com/darkkaiser/notify-server/service/notification"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultNotificationHistoryLimit = 20
+	maxNotificationHistoryLimit     = 100
+)
+
+func (h *Handler) NotificationHistoryHandler(c echo.Context) error {
+	limit := defaultNotificationHistoryLimit
+	if s := c.QueryParam("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 || n > maxNotificationHistoryLimit {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("limit는 1~%d 범위의 숫자이어야 합니다.", maxNotificationHistoryLimit))
+		}
+		limit = n
+	}
+
+	offset := 0
+	if s := c.QueryParam("offset"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "offset은 0 이상의 숫자이어야 합니다.")
+		}
+		offset = n
+	}
+
+	histories, err := h.notificationSender.NotificationHistories(limit, offset)
+	if err != nil {
+		if errors.Is(err, notification.ErrNotificationHistoryNotSupported) == true {
+			return echo.NewHTTPError(http.StatusNotImplemented, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("알림메시지 발송 이력 조회가 실패하였습니다.(error:%s)", err))
+	}
+
+	result := make([]*model.NotificationHistory, 0, len(histories))
+	for _, history := range histories {
+		result = append(result, &model.NotificationHistory{
+			ID:             history.ID,
+			NotifierID:     string(history.NotifierID),
+			TaskID:         string(history.TaskID),
+			MessagePreview: history.MessagePreview,
+			SentAt:         history.SentAt,
+			ErrorOccurred:  history.ErrorOccurred,
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) NotifierListHandler(c echo.Context) error {
+	notifiers := h.notificationSender.ListNotifiers()
+
+	result := make([]*model.Notifier, 0, len(notifiers))
+	for _, notifier := range notifiers {
+		n := &model.Notifier{
+			ID:           string(notifier.ID),
+			Type:         notifier.Type,
+			SupportsHTML: notifier.SupportHTMLMessage,
+			Status:       model.NotifierStatusOK,
+		}
+		if notifier.Err != nil {
+			n.Status = model.NotifierStatusError
+			n.ErrorMessage = notifier.Err.Error()
+		}
+
+		result = append(result, n)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) BroadcastHandler(c echo.Context) error {
+	m := new(model.BroadcastMessage)
+	if err := c.Bind(m); err != nil {
+		return err
+	}
+	if strings.TrimSpace(m.Message) == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "발송할 메시지가 입력되지 않았습니다.")
+	}
+
+	if err := h.notificationSender.BroadcastToAll(m.Message, m.IsError); err != nil {
+		var broadcastErr *notification.BroadcastError
+		if errors.As(err, &broadcastErr) == true {
+			return echo.NewHTTPError(http.StatusBadGateway, fmt.Sprintf("일부 Notifier로의 알림메시지 발송이 실패하였습니다.(error:%s)", err))
+		}
+		return echo.NewHTTPError(http.StatusServiceUnavailable, fmt.Sprintf("알림메시지 발송이 실패하였습니다.(error:%s)", err))
+	}
+
+	return c.JSON(http.StatusOK, map[string]int{
+		"result_code": 0,
+	})
+}
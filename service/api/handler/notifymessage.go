@@ -13,11 +13,11 @@ func (h *Handler) NotifyMessageSendHandler(c echo.Context) error {
 		return err
 	}
 
-	appKey := c.QueryParam("app_key")
+	appKey := requestAppKey(c)
 
 	for _, application := range h.allowedApplications {
 		if application.ID == m.ApplicationID {
-			if application.AppKey != appKey {
+			if application.MatchAppKey(appKey) == false {
 				return echo.NewHTTPError(http.StatusUnauthorized, fmt.Sprintf("APP_KEY가 유효하지 않습니다.(ID:%s)", m.ApplicationID))
 			}
 
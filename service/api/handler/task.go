@@ -0,0 +1,270 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/darkkaiser/notify-server/service/api/model"
+	"github.com/darkkaiser/notify-server/service/task"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+func (h *Handler) TaskListHandler(c echo.Context) error {
+	runningTasks := h.taskMonitor.RunningTasks()
+
+	// 실행이 시작된 순서대로 정렬한다.
+	sort.Slice(runningTasks, func(i, j int) bool {
+		return runningTasks[i].RunTime.Before(runningTasks[j].RunTime)
+	})
+
+	now := time.Now()
+	tasks := make([]*model.RunningTask, 0, len(runningTasks))
+	for _, t := range runningTasks {
+		var elapsedMs int64
+		if t.RunTime.IsZero() == false {
+			elapsedMs = now.Sub(t.RunTime).Milliseconds()
+		}
+
+		tasks = append(tasks, &model.RunningTask{
+			InstanceID: string(t.InstanceID),
+			TaskID:     string(t.TaskID),
+			CommandID:  string(t.CommandID),
+			RunBy:      t.RunBy.String(),
+			StartedAt:  t.RunTime,
+			ElapsedMs:  elapsedMs,
+		})
+	}
+
+	return c.JSON(http.StatusOK, tasks)
+}
+
+const (
+	defaultTaskRunTimeoutSeconds = 60
+	maxTaskRunTimeoutSeconds     = 600
+)
+
+// TaskRunHandler 작업을 실행하고 작업이 끝날 때까지 기다린 후 작업 결과 메시지를 반환한다.
+// 작업 결과 메시지는 인증된 Application의 기본 Notifier로도 발송된다.
+func (h *Handler) TaskRunHandler(c echo.Context) error {
+	r := new(model.TaskRunRequest)
+	if err := c.Bind(r); err != nil {
+		return err
+	}
+	if r.TaskID == "" || r.CommandID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "task_id 또는 command_id가 입력되지 않았습니다.")
+	}
+	if r.TimeoutSeconds < 0 || r.TimeoutSeconds > maxTaskRunTimeoutSeconds {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("timeout_seconds는 0~%d 범위의 숫자이어야 합니다.", maxTaskRunTimeoutSeconds))
+	}
+	if r.TimeoutSeconds == 0 {
+		r.TimeoutSeconds = defaultTaskRunTimeoutSeconds
+	}
+
+	application := AuthenticatedApplication(c)
+
+	// 클라이언트의 연결이 끊어지면 실행중인 작업도 취소된다.
+	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Duration(r.TimeoutSeconds)*time.Second)
+	defer cancel()
+
+	taskID := task.TaskID(r.TaskID)
+	taskCommandID := task.TaskCommandID(r.CommandID)
+
+	result, err := h.taskRunner.TaskRunOnce(ctx, taskID, taskCommandID, application.DefaultNotifierID)
+	if err != nil {
+		switch {
+		case errors.Is(err, task.ErrNotSupportedTask) == true || errors.Is(err, task.ErrNotSupportedCommand) == true:
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("%s.(ID:%s::%s)", err, taskID, taskCommandID))
+		case errors.Is(err, task.ErrTaskAlreadyRunning) == true:
+			return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("%s.(ID:%s::%s)", err, taskID, taskCommandID))
+		case errors.Is(err, task.ErrTaskQueueFull) == true:
+			return echo.NewHTTPError(http.StatusServiceUnavailable, fmt.Sprintf("%s.", err))
+		case errors.Is(err, context.DeadlineExceeded) == true:
+			return echo.NewHTTPError(http.StatusGatewayTimeout, fmt.Sprintf("작업 실행 시간(%d초)이 초과되어 작업이 취소되었습니다.(ID:%s::%s)", r.TimeoutSeconds, taskID, taskCommandID))
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("작업 실행이 실패하였습니다.(error:%s)", err))
+	}
+
+	return c.JSON(http.StatusOK, &model.TaskRunResult{
+		InstanceID: string(result.InstanceID),
+		TaskID:     string(taskID),
+		CommandID:  string(taskCommandID),
+		Message:    result.Message,
+	})
+}
+
+func (h *Handler) TaskCancelHandler(c echo.Context) error {
+	instanceID := task.TaskInstanceID(c.Param("instanceId"))
+
+	switch h.taskMonitor.TaskInstanceStatus(instanceID) {
+	case task.TaskInstanceStatusNotFound:
+		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("해당 작업에 대한 정보를 찾을 수 없습니다.(ID:%s)", instanceID))
+
+	case task.TaskInstanceStatusCompleted:
+		return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("이미 완료되었거나 취소된 작업입니다.(ID:%s)", instanceID))
+	}
+
+	if h.taskRunner.TaskCancel(instanceID) == false {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("작업취소 요청이 실패하였습니다.(ID:%s)", instanceID))
+	}
+
+	return c.JSON(http.StatusOK, map[string]int{
+		"result_code": 0,
+	})
+}
+
+func (h *Handler) TaskResultDataDeleteHandler(c echo.Context) error {
+	taskID := task.TaskID(c.Param("taskId"))
+	taskCommandID := task.TaskCommandID(c.Param("commandId"))
+
+	if err := h.taskRunner.TaskResultDataDelete(taskID, taskCommandID); err != nil {
+		switch {
+		case errors.Is(err, task.ErrNotSupportedTask) == true || errors.Is(err, task.ErrNotSupportedCommand) == true:
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("%s.(ID:%s::%s)", err, taskID, taskCommandID))
+		case errors.Is(err, task.ErrTaskAlreadyRunning) == true:
+			return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("%s. 작업이 완료된 후에 다시 시도하여 주세요.(ID:%s::%s)", err, taskID, taskCommandID))
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("작업결과데이터 삭제가 실패하였습니다.(error:%s)", err))
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *Handler) TaskResultDataExportHandler(c echo.Context) error {
+	taskID := task.TaskID(c.Param("taskId"))
+	taskCommandID := task.TaskCommandID(c.Param("commandId"))
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("지원하지 않는 내보내기 형식(%s)입니다.", format))
+	}
+
+	taskResultData, err := h.taskMonitor.TaskResultData(taskID, taskCommandID)
+	if err != nil {
+		if errors.Is(err, task.ErrNotSupportedTask) == true || errors.Is(err, task.ErrNotSupportedCommand) == true {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("%s.(ID:%s::%s)", err, taskID, taskCommandID))
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("작업결과데이터 조회가 실패하였습니다.(error:%s)", err))
+	}
+
+	exporter, ok := taskResultData.(task.TaskResultDataExporter)
+	if ok == false {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, fmt.Sprintf("작업결과데이터의 내보내기를 지원하지 않는 작업입니다.(ID:%s::%s)", taskID, taskCommandID))
+	}
+
+	data, err := exporter.ToCSV()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("작업결과데이터의 CSV 변환이 실패하였습니다.(error:%s)", err))
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="snapshot.csv"`)
+
+	return c.Blob(http.StatusOK, "text/csv; charset=UTF-8", data)
+}
+
+const (
+	defaultTaskHistoryLimit = 20
+	maxTaskHistoryLimit     = 100
+)
+
+func (h *Handler) TaskHistoryHandler(c echo.Context) error {
+	taskID := task.TaskID(c.Param("taskId"))
+
+	limit := defaultTaskHistoryLimit
+	if s := c.QueryParam("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 || n > maxTaskHistoryLimit {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("limit는 1~%d 범위의 숫자이어야 합니다.", maxTaskHistoryLimit))
+		}
+		limit = n
+	}
+
+	histories, err := h.taskMonitor.TaskExecutionHistories(taskID, limit)
+	if err != nil {
+		if errors.Is(err, task.ErrExecutionHistoryNotSupported) == true {
+			return echo.NewHTTPError(http.StatusNotImplemented, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("작업 실행 이력 조회가 실패하였습니다.(error:%s)", err))
+	}
+
+	result := make([]*model.TaskExecutionHistory, 0, len(histories))
+	for _, history := range histories {
+		result = append(result, &model.TaskExecutionHistory{
+			ID:            history.ID,
+			TaskID:        string(history.TaskID),
+			CommandID:     string(history.CommandID),
+			InstanceID:    string(history.InstanceID),
+			RunBy:         history.RunBy.String(),
+			StartedAt:     history.StartedAt,
+			FinishedAt:    history.FinishedAt,
+			DurationMs:    history.DurationMs,
+			Success:       history.Success,
+			MessageLength: history.MessageLength,
+			Error:         history.Error,
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+const (
+	defaultTaskScheduleLimit = 5
+	maxTaskScheduleLimit     = 50
+)
+
+// TaskScheduleListHandler 스케쥴러에 등록된 작업별로 다음 실행 시간을 반환한다.
+func (h *Handler) TaskScheduleListHandler(c echo.Context) error {
+	limit := defaultTaskScheduleLimit
+	if s := c.QueryParam("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 || n > maxTaskScheduleLimit {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("limit는 1~%d 범위의 숫자이어야 합니다.", maxTaskScheduleLimit))
+		}
+		limit = n
+	}
+
+	schedules := h.taskMonitor.Schedules(limit)
+
+	result := make([]*model.TaskSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		nextFires := make([]string, 0, len(schedule.NextFireTimes))
+		for _, t := range schedule.NextFireTimes {
+			nextFires = append(nextFires, t.Format(time.RFC3339))
+		}
+
+		result = append(result, &model.TaskSchedule{
+			TaskID:    string(schedule.TaskID),
+			CommandID: string(schedule.CommandID),
+			CronExpr:  schedule.TimeSpec,
+			NextFires: nextFires,
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) TaskServicePauseHandler(c echo.Context) error {
+	if err := h.taskRunner.Pause(); err != nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, fmt.Sprintf("작업 실행의 일시 중지가 실패하였습니다.(error:%s)", err))
+	}
+
+	return c.JSON(http.StatusOK, map[string]int{
+		"result_code": 0,
+	})
+}
+
+func (h *Handler) TaskServiceResumeHandler(c echo.Context) error {
+	if err := h.taskRunner.Resume(); err != nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, fmt.Sprintf("작업 실행의 재개가 실패하였습니다.(error:%s)", err))
+	}
+
+	return c.JSON(http.StatusOK, map[string]int{
+		"result_code": 0,
+	})
+}
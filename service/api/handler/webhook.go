@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	_log_ "github.com/darkkaiser/notify-server/log"
+	"github.com/darkkaiser/notify-server/service/api/model"
+	"github.com/darkkaiser/notify-server/service/task"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+)
+
+// Webhook 요청 본문의 최대 크기
+// Webhook 요청은 notify_api.max_request_body_bytes 설정의 제한을 받지 않으므로 이 크기로 제한한다.
+const maxWebhookBodyBytes = 1 << 20
+
+// WebhookHandler 외부 서비스(GitHub 등)의 Webhook 호출을 받아 설정된 작업을 실행한다.
+// 요청 본문은 JSON 형식이어야 하며, 작업 결과는 인증된 Application의 기본 Notifier로 발송된다.
+func (h *Handler) WebhookHandler(c echo.Context) error {
+	webhookID := c.Param("webhookId")
+
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxWebhookBodyBytes+1))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("요청 본문을 읽을 수 없습니다.(error:%s)", err))
+	}
+	if len(body) > maxWebhookBodyBytes {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("요청 본문의 크기가 최대 크기(%d바이트)를 초과하였습니다.", maxWebhookBodyBytes))
+	}
+	if len(body) > 0 && json.Valid(body) == false {
+		return echo.NewHTTPError(http.StatusBadRequest, "요청 본문이 JSON 형식이 아닙니다.")
+	}
+
+	var webhook *model.Webhook
+	for _, w := range h.webhooks {
+		if w.ID == webhookID {
+			webhook = w
+			break
+		}
+	}
+	if webhook == nil {
+		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("등록되지 않은 Webhook입니다.(WebhookID:%s)", webhookID))
+	}
+
+	application := AuthenticatedApplication(c)
+
+	// 작업의 로그에서 Webhook 호출 요청을 찾을 수 있도록 요청ID를 함께 전달한다.
+	taskCtx := task.NewContext().With(task.TaskCtxKeyRequestID, _log_.RequestIDFromContext(c.Request().Context()))
+
+	if h.taskRunner.TaskRunWithContext(task.TaskID(webhook.TaskID), task.TaskCommandID(webhook.CommandID), taskCtx, application.DefaultNotifierID, false, task.TaskRunByWebhook) == false {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("작업 실행 요청이 실패하였습니다.(ID:%s::%s)", webhook.TaskID, webhook.CommandID))
+	}
+
+	return c.JSON(http.StatusOK, map[string]int{
+		"result_code": 0,
+	})
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"github.com/darkkaiser/notify-server/service/api/model"
+	"github.com/darkkaiser/notify-server/service/task"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// webhookTestTaskRunner 작업 실행 요청만 기록하는 테스트용 TaskRunner
+type webhookTestTaskRunner struct {
+	task.TaskRunner
+
+	taskID     task.TaskID
+	commandID  task.TaskCommandID
+	notifierID string
+	taskRunBy  task.TaskRunBy
+}
+
+func (r *webhookTestTaskRunner) TaskRunWithContext(taskID task.TaskID, taskCommandID task.TaskCommandID, _ task.TaskContext, notifierID string, _ bool, taskRunBy task.TaskRunBy) bool {
+	r.taskID, r.commandID, r.notifierID, r.taskRunBy = taskID, taskCommandID, notifierID, taskRunBy
+	return true
+}
+
+func TestHandler_WebhookHandler(t *testing.T) {
+	cases := []struct {
+		name         string
+		webhookID    string
+		body         string
+		expectedCode int
+	}{
+		{name: "등록되지 않은 Webhook", webhookID: "unknown", body: `{}`, expectedCode: http.StatusNotFound},
+		{name: "JSON 형식이 아닌 요청 본문", webhookID: "release", body: `{"ref":`, expectedCode: http.StatusBadRequest},
+		{name: "최대 크기를 초과한 요청 본문", webhookID: "release", body: `"` + strings.Repeat("a", maxWebhookBodyBytes) + `"`, expectedCode: http.StatusRequestEntityTooLarge},
+		{name: "빈 요청 본문", webhookID: "release", body: ``, expectedCode: http.StatusOK},
+		{name: "작업 실행", webhookID: "release", body: `{"ref":"refs/tags/v1.0.0"}`, expectedCode: http.StatusOK},
+	}
+
+	e := echo.New()
+	for _, c := range cases {
+		taskRunner := &webhookTestTaskRunner{}
+		h := &Handler{
+			webhooks:   []*model.Webhook{{ID: "release", TaskID: "GITHUB", CommandID: "WatchNewReleases"}},
+			taskRunner: taskRunner,
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/webhook/"+c.webhookID, strings.NewReader(c.body))
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("webhookId")
+		ctx.SetParamValues(c.webhookID)
+		ctx.Set(model.ContextKeyAllowedApplication, &model.AllowedApplication{ID: "app", DefaultNotifierID: "app-notifier"})
+
+		err := h.WebhookHandler(ctx)
+		if c.expectedCode != http.StatusOK {
+			httpErr, ok := err.(*echo.HTTPError)
+			if assert.True(t, ok, c.name) == true {
+				assert.Equal(t, c.expectedCode, httpErr.Code, c.name)
+			}
+			assert.Equal(t, task.TaskID(""), taskRunner.taskID, c.name)
+			continue
+		}
+
+		assert.NoError(t, err, c.name)
+		assert.Equal(t, http.StatusOK, rec.Code, c.name)
+		assert.Equal(t, task.TaskID("GITHUB"), taskRunner.taskID, c.name)
+		assert.Equal(t, task.TaskCommandID("WatchNewReleases"), taskRunner.commandID, c.name)
+		assert.Equal(t, "app-notifier", taskRunner.notifierID, c.name)
+		assert.Equal(t, task.TaskRunByWebhook, taskRunner.taskRunBy, c.name)
+	}
+}
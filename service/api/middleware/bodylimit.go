@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strings"
+)
+
+type RequestBodySizeLimitConfig struct {
+	// 요청 본문의 최대 크기
+	MaxBytes int64
+
+	// true를 반환하는 요청은 요청 본문의 크기를 제한하지 않는다(핸들러에서 별도의 최대 크기로 제한하는 요청에 사용한다).
+	Skipper func(c echo.Context) bool
+}
+
+// RequestBodySizeLimit 요청 본문의 크기를 maxBytes로 제한하는 미들웨어를 반환한다.
+func RequestBodySizeLimit(maxBytes int64) echo.MiddlewareFunc {
+	return RequestBodySizeLimitWithConfig(RequestBodySizeLimitConfig{MaxBytes: maxBytes})
+}
+
+// RequestBodySizeLimitWithConfig 요청 본문의 크기를 제한하는 미들웨어를 반환한다.
+// 요청 본문이 최대 크기를 초과하는 경우 413(Request Entity Too Large)으로 응답한다.
+func RequestBodySizeLimitWithConfig(config RequestBodySizeLimitConfig) echo.MiddlewareFunc {
+	maxBytes := config.MaxBytes
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Skipper != nil && config.Skipper(c) == true {
+				return next(c)
+			}
+
+			req := c.Request()
+
+			// Content-Length 헤더로 크기를 알 수 있는 경우 본문을 읽지 않고 바로 거부한다.
+			if req.ContentLength > maxBytes {
+				return newRequestBodyTooLargeError(maxBytes)
+			}
+
+			if req.Body != nil && req.Body != http.NoBody {
+				req.Body = http.MaxBytesReader(c.Response(), req.Body, maxBytes)
+			}
+
+			err := next(c)
+			if err != nil && isRequestBodyTooLarge(err) == true {
+				return newRequestBodyTooLargeError(maxBytes)
+			}
+
+			return err
+		}
+	}
+}
+
+func newRequestBodyTooLargeError(maxBytes int64) *echo.HTTPError {
+	return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("요청 본문의 크기가 최대 크기(%d바이트)를 초과하였습니다.", maxBytes))
+}
+
+// isRequestBodyTooLarge http.MaxBytesReader가 반환한 에러인지 확인한다.
+// 핸들러에서 본문을 읽다가 발생한 에러는 echo.HTTPError로 감싸져서 반환될 수 있으므로 에러 메시지로 확인한다.
+func isRequestBodyTooLarge(err error) bool {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) == true {
+		if httpErr.Code == http.StatusRequestEntityTooLarge {
+			return false
+		}
+		if httpErr.Internal != nil && strings.Contains(httpErr.Internal.Error(), "http: request body too large") == true {
+			return true
+		}
+		return strings.Contains(fmt.Sprint(httpErr.Message), "http: request body too large")
+	}
+
+	return strings.Contains(err.Error(), "http: request body too large")
+}
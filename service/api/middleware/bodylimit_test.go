@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestBodySizeLimitWithConfig(t *testing.T) {
+	e := echo.New()
+	e.Use(RequestBodySizeLimitWithConfig(RequestBodySizeLimitConfig{
+		MaxBytes: 8,
+		Skipper: func(c echo.Context) bool {
+			return c.Path() == "/webhook/:webhookId"
+		},
+	}))
+
+	handler := func(c echo.Context) error {
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest).SetInternal(err)
+		}
+		return c.String(http.StatusOK, string(body))
+	}
+	e.POST("/message", handler)
+	e.POST("/webhook/:webhookId", handler)
+
+	testCases := []struct {
+		name     string
+		path     string
+		body     string
+		expected int
+	}{
+		{name: "최대 크기 이하의 본문", path: "/message", body: "12345678", expected: http.StatusOK},
+		{name: "최대 크기를 초과한 본문", path: "/message", body: "123456789", expected: http.StatusRequestEntityTooLarge},
+		{name: "크기를 제한하지 않는 요청", path: "/webhook/release", body: "123456789", expected: http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Code)
+		})
+	}
+}
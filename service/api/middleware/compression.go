@@ -0,0 +1,183 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// 압축하지 않는 응답 데이터의 기본 최대 크기
+const defaultCompressionMinLength = 1024
+
+type ResponseCompressionConfig struct {
+	// 응답 데이터의 크기가 MinLength 보다 작으면 압축하지 않는다.
+	MinLength int
+}
+
+// ResponseCompression 클라이언트가 gzip 압축을 지원하는 경우 응답 데이터를 gzip으로 압축하는 미들웨어를 반환한다.
+func ResponseCompression() echo.MiddlewareFunc {
+	return ResponseCompressionWithConfig(ResponseCompressionConfig{MinLength: defaultCompressionMinLength})
+}
+
+func ResponseCompressionWithConfig(config ResponseCompressionConfig) echo.MiddlewareFunc {
+	if config.MinLength <= 0 {
+		config.MinLength = defaultCompressionMinLength
+	}
+
+	pool := &sync.Pool{
+		New: func() interface{} {
+			return gzip.NewWriter(io.Discard)
+		},
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			res := c.Response()
+			res.Header().Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+
+			if strings.Contains(c.Request().Header.Get(echo.HeaderAcceptEncoding), "gzip") == false {
+				return next(c)
+			}
+
+			w := &compressionResponseWriter{
+				ResponseWriter: res.Writer,
+				pool:           pool,
+				minLength:      config.MinLength,
+				statusCode:     http.StatusOK,
+			}
+			res.Writer = w
+			defer func() {
+				w.close()
+				res.Writer = w.ResponseWriter
+			}()
+
+			return next(c)
+		}
+	}
+}
+
+type compressionMode int
+
+const (
+	compressionModeBuffering compressionMode = iota // 압축 여부를 결정하기 위하여 응답 데이터를 버퍼에 저장중
+	compressionModeGzip                             // gzip으로 압축하여 전송
+	compressionModeIdentity                         // 압축하지 않고 전송
+)
+
+type compressionResponseWriter struct {
+	http.ResponseWriter
+
+	pool      *sync.Pool
+	minLength int
+
+	mode        compressionMode
+	wroteHeader bool
+	statusCode  int
+	buf         bytes.Buffer
+	gw          *gzip.Writer
+}
+
+func (w *compressionResponseWriter) WriteHeader(statusCode int) {
+	// 응답 데이터의 크기에 따라 압축 여부가 결정되므로 헤더 전송을 미룬다.
+	w.wroteHeader = true
+	w.statusCode = statusCode
+}
+
+func (w *compressionResponseWriter) Write(b []byte) (int, error) {
+	switch w.mode {
+	case compressionModeGzip:
+		return w.gw.Write(b)
+	case compressionModeIdentity:
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buf.Write(b)
+	if w.buf.Len() >= w.minLength {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(b), nil
+}
+
+// decide 압축 여부를 결정하고 헤더와 버퍼에 저장된 응답 데이터를 전송한다.
+func (w *compressionResponseWriter) decide(compress bool) error {
+	header := w.Header()
+
+	// 이미 인코딩된 데이터이거나 본문이 없는 응답은 압축하지 않는다.
+	if header.Get(echo.HeaderContentEncoding) != "" || w.statusCode < http.StatusOK || w.statusCode == http.StatusNoContent || w.statusCode == http.StatusNotModified {
+		compress = false
+	}
+
+	if compress == true {
+		w.mode = compressionModeGzip
+
+		header.Set(echo.HeaderContentEncoding, "gzip")
+		header.Del(echo.HeaderContentLength)
+		w.ResponseWriter.WriteHeader(w.statusCode)
+
+		w.gw = w.pool.Get().(*gzip.Writer)
+		w.gw.Reset(w.ResponseWriter)
+
+		_, err := w.gw.Write(w.buf.Bytes())
+		w.buf.Reset()
+		return err
+	}
+
+	w.mode = compressionModeIdentity
+
+	w.ResponseWriter.WriteHeader(w.statusCode)
+
+	_, err := w.ResponseWriter.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+func (w *compressionResponseWriter) close() {
+	switch w.mode {
+	case compressionModeBuffering:
+		// 응답 데이터의 크기가 작으므로 압축하지 않고 전송한다.
+		if w.wroteHeader == true || w.buf.Len() > 0 {
+			_ = w.decide(false)
+		}
+
+	case compressionModeGzip:
+		_ = w.gw.Close()
+		w.gw.Reset(io.Discard)
+		w.pool.Put(w.gw)
+		w.gw = nil
+	}
+}
+
+func (w *compressionResponseWriter) Flush() {
+	if w.mode == compressionModeBuffering {
+		_ = w.decide(w.buf.Len() >= w.minLength)
+	}
+	if w.mode == compressionModeGzip {
+		_ = w.gw.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok == true {
+		flusher.Flush()
+	}
+}
+
+func (w *compressionResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok == true {
+		// 연결을 넘겨준 이후에는 응답 데이터를 전송하지 않는다.
+		w.mode = compressionModeIdentity
+		return hijacker.Hijack()
+	}
+	return nil, nil, errors.New("http.Hijacker 인터페이스를 지원하지 않습니다")
+}
+
+func (w *compressionResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type DeprecatedEndpointConfig struct {
+	// API가 제거될 예정인 날짜, 값이 없으면 Sunset 헤더를 설정하지 않는다.(RFC 8594)
+	SunsetDate time.Time
+
+	// API를 대신하여 사용할 수 있는 API의 URL, 값이 없으면 Link 헤더를 설정하지 않는다.(RFC 8288)
+	AlternativeURL string
+}
+
+// DeprecatedEndpoint 더 이상 사용하지 않을 예정인 API임을 응답 헤더로 알리는 미들웨어를 반환한다.
+func DeprecatedEndpoint(config DeprecatedEndpointConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Response().Header()
+			header.Set("Deprecation", "true")
+			if config.SunsetDate.IsZero() == false {
+				header.Set("Sunset", config.SunsetDate.UTC().Format(http.TimeFormat))
+			}
+			if config.AlternativeURL != "" {
+				header.Add("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", config.AlternativeURL))
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net"
+	"net/http"
+	"strings"
+)
+
+type IPAllowlistConfig struct {
+	// 접근을 허용할 IP 대역(CIDR) 목록
+	CIDRs []string
+
+	// 프록시 뒤에서 동작하는 경우, X-Forwarded-For 헤더의 마지막 IP(신뢰하는 프록시가 추가한 IP)를 요청한 IP로 사용한다.
+	// 헤더의 앞쪽 IP는 클라이언트가 임의로 입력할 수 있으므로 사용하지 않는다.
+	// 프록시를 거치지 않는 환경에서 true로 설정하면 헤더를 조작하여 접근이 허용될 수 있다.
+	TrustProxy bool
+}
+
+// IPAllowlist 요청한 IP가 허용된 IP 대역에 포함되지 않는 경우 403 Forbidden을 반환하는 미들웨어를 반환한다.
+// CIDR 목록에 유효하지 않은 값이 있는 경우 에러를 반환한다.
+func IPAllowlist(config IPAllowlistConfig) (echo.MiddlewareFunc, error) {
+	networks := make([]*net.IPNet, 0, len(config.CIDRs))
+	for _, cidr := range config.CIDRs {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("접근 허용 IP 대역(%s)이 유효하지 않습니다.(error:%s)", cidr, err)
+		}
+		networks = append(networks, network)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ip := net.ParseIP(requestIP(c.Request(), config.TrustProxy))
+			if ip != nil {
+				for _, network := range networks {
+					if network.Contains(ip) == true {
+						return next(c)
+					}
+				}
+			}
+
+			return echo.NewHTTPError(http.StatusForbidden, "접근이 허용되지 않은 IP입니다.")
+		}
+	}, nil
+}
+
+// requestIP 요청한 IP를 반환한다.
+// 프록시는 X-Forwarded-For 헤더의 끝에 자신에게 요청한 IP를 추가하므로, 신뢰하는 프록시가 추가한 마지막 IP를 사용한다.
+func requestIP(req *http.Request, trustProxy bool) string {
+	if trustProxy == true {
+		if xffs := req.Header.Values(echo.HeaderXForwardedFor); len(xffs) > 0 {
+			ips := strings.Split(xffs[len(xffs)-1], ",")
+			return strings.TrimSpace(ips[len(ips)-1])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
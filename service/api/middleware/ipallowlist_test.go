@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIPAllowlist(t *testing.T) {
+	testCases := []struct {
+		name       string
+		trustProxy bool
+		remoteAddr string
+		xff        []string
+		expected   int
+	}{
+		{name: "허용된 IP", remoteAddr: "10.0.0.5:1234", expected: http.StatusOK},
+		{name: "허용되지 않은 IP", remoteAddr: "192.0.2.1:1234", expected: http.StatusForbidden},
+		{name: "프록시를 신뢰하지 않는 경우 X-Forwarded-For 헤더를 무시한다", remoteAddr: "192.0.2.1:1234", xff: []string{"10.0.0.5"}, expected: http.StatusForbidden},
+		{name: "프록시가 추가한 허용된 IP", trustProxy: true, remoteAddr: "127.0.0.1:1234", xff: []string{"10.0.0.5"}, expected: http.StatusOK},
+		{name: "프록시가 추가한 허용되지 않은 IP", trustProxy: true, remoteAddr: "127.0.0.1:1234", xff: []string{"192.0.2.1"}, expected: http.StatusForbidden},
+		{name: "클라이언트가 X-Forwarded-For 헤더의 앞쪽에 허용된 IP를 입력한 경우", trustProxy: true, remoteAddr: "127.0.0.1:1234", xff: []string{"10.0.0.5, 192.0.2.1"}, expected: http.StatusForbidden},
+		{name: "클라이언트가 X-Forwarded-For 헤더를 여러 번 입력한 경우", trustProxy: true, remoteAddr: "127.0.0.1:1234", xff: []string{"10.0.0.5", "192.0.2.1"}, expected: http.StatusForbidden},
+		{name: "여러 프록시를 거친 허용된 IP", trustProxy: true, remoteAddr: "127.0.0.1:1234", xff: []string{"192.0.2.1, 10.0.0.5"}, expected: http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := IPAllowlist(IPAllowlistConfig{CIDRs: []string{"10.0.0.0/8"}, TrustProxy: tc.trustProxy})
+			assert.NoError(t, err)
+
+			e := echo.New()
+			e.GET("/", func(c echo.Context) error { return c.NoContent(http.StatusOK) }, m)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			for _, xff := range tc.xff {
+				req.Header.Add(echo.HeaderXForwardedFor, xff)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Code)
+		})
+	}
+}
+
+func TestIPAllowlist_InvalidCIDR(t *testing.T) {
+	_, err := IPAllowlist(IPAllowlistConfig{CIDRs: []string{"10.0.0.0/33"}})
+	assert.Error(t, err)
+}
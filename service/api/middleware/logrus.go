@@ -1,16 +1,72 @@
 package middleware
 
 import (
+	"bytes"
+	"encoding/base64"
+	_log_ "github.com/darkkaiser/notify-server/log"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/gommon/log"
 	"github.com/sirupsen/logrus"
 	"io"
+	"net/http"
+	"regexp"
 	"strconv"
 	"time"
 )
 
+// 요청 본문을 로그로 출력할 때 기본으로 출력하는 최대 크기
+const defaultMaxBodyBytes = 1024
+
+// LogrusLoggerOptions 요청 로그의 출력 옵션
+type LogrusLoggerOptions struct {
+	// POST, PUT, PATCH 요청의 본문을 로그로 출력할지의 여부, 운영 환경에서는 사용하지 않도록 기본값은 false이다.
+	LogRequestBody bool
+
+	// 로그로 출력할 요청 본문의 최대 크기(0인 경우 1024바이트)
+	MaxBodyBytes int
+}
+
+// appKeyRegexps 로그에 APP_KEY가 그대로 출력되지 않도록 JSON 필드와 쿼리(폼) 파라미터의 app_key 값을 찾는다.
+// 요청 본문은 최대 크기만큼 잘려서 출력되므로, 닫는 따옴표 없이 본문이 끝나는 app_key 값도 찾는다.
+var appKeyRegexps = []*regexp.Regexp{
+	regexp.MustCompile(`("app_key"\s*:\s*")(?:[^"\\]|\\.)*\\?("|$)`),
+	regexp.MustCompile(`(app_key=)[^&\s]*()`),
+}
+
+// maskAppKey app_key 값을 '****'로 변경한다.
+func maskAppKey(b []byte) []byte {
+	for _, re := range appKeyRegexps {
+		b = re.ReplaceAll(b, []byte("${1}****${2}"))
+	}
+	return b
+}
+
+// readRequestBody 요청 본문을 최대 maxBodyBytes 만큼 읽어서 반환하고, 핸들러에서 본문 전체를 다시 읽을 수 있도록 요청 본문을 복원한다.
+func readRequestBody(req *http.Request, maxBodyBytes int) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(req.Body, int64(maxBodyBytes)))
+
+	// 읽은 본문 뒤에 아직 읽지 않은 나머지 본문을 이어서 읽을 수 있도록 한다.
+	req.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(buf), req.Body), req.Body}
+
+	return buf, err
+}
+
 type Logger struct {
 	*logrus.Logger
+
+	// 모든 로그에 함께 출력될 필드(요청ID 등)
+	Fields logrus.Fields
+}
+
+func (l Logger) entry() *logrus.Entry {
+	return logrus.NewEntry(l.Logger).WithFields(l.Fields)
 }
 
 func (l Logger) Output() io.Writer {
@@ -66,92 +122,102 @@ func (l Logger) SetHeader(string) {
 }
 
 func (l Logger) Print(i ...interface{}) {
-	logrus.Print(i...)
+	l.entry().Print(i...)
 }
 
 func (l Logger) Printf(format string, args ...interface{}) {
-	logrus.Printf(format, args...)
+	l.entry().Printf(format, args...)
 }
 
 func (l Logger) Printj(j log.JSON) {
-	logrus.WithFields(logrus.Fields(j)).Print()
+	l.entry().WithFields(logrus.Fields(j)).Print()
 }
 
 func (l Logger) Debug(i ...interface{}) {
-	logrus.Debug(i...)
+	l.entry().Debug(i...)
 }
 
 func (l Logger) Debugf(format string, args ...interface{}) {
-	logrus.Debugf(format, args...)
+	l.entry().Debugf(format, args...)
 }
 
 func (l Logger) Debugj(j log.JSON) {
-	logrus.WithFields(logrus.Fields(j)).Debug()
+	l.entry().WithFields(logrus.Fields(j)).Debug()
 }
 
 func (l Logger) Info(i ...interface{}) {
-	logrus.Info(i...)
+	l.entry().Info(i...)
 }
 
 func (l Logger) Infof(format string, args ...interface{}) {
-	logrus.Infof(format, args...)
+	l.entry().Infof(format, args...)
 }
 
 func (l Logger) Infoj(j log.JSON) {
-	logrus.WithFields(logrus.Fields(j)).Info()
+	l.entry().WithFields(logrus.Fields(j)).Info()
 }
 
 func (l Logger) Warn(i ...interface{}) {
-	logrus.Warn(i...)
+	l.entry().Warn(i...)
 }
 
 func (l Logger) Warnf(format string, args ...interface{}) {
-	logrus.Warnf(format, args...)
+	l.entry().Warnf(format, args...)
 }
 
 func (l Logger) Warnj(j log.JSON) {
-	logrus.WithFields(logrus.Fields(j)).Warn()
+	l.entry().WithFields(logrus.Fields(j)).Warn()
 }
 
 func (l Logger) Error(i ...interface{}) {
-	logrus.Error(i...)
+	l.entry().Error(i...)
 }
 
 func (l Logger) Errorf(format string, args ...interface{}) {
-	logrus.Errorf(format, args...)
+	l.entry().Errorf(format, args...)
 }
 
 func (l Logger) Errorj(j log.JSON) {
-	logrus.WithFields(logrus.Fields(j)).Error()
+	l.entry().WithFields(logrus.Fields(j)).Error()
 }
 
 func (l Logger) Fatal(i ...interface{}) {
-	logrus.Fatal(i...)
+	l.entry().Fatal(i...)
 }
 
 func (l Logger) Fatalf(format string, args ...interface{}) {
-	logrus.Fatalf(format, args...)
+	l.entry().Fatalf(format, args...)
 }
 
 func (l Logger) Fatalj(j log.JSON) {
-	logrus.WithFields(logrus.Fields(j)).Fatal()
+	l.entry().WithFields(logrus.Fields(j)).Fatal()
 }
 
 func (l Logger) Panic(i ...interface{}) {
-	logrus.Panic(i...)
+	l.entry().Panic(i...)
 }
 
 func (l Logger) Panicf(format string, args ...interface{}) {
-	logrus.Panicf(format, args...)
+	l.entry().Panicf(format, args...)
 }
 
 func (l Logger) Panicj(j log.JSON) {
-	logrus.WithFields(logrus.Fields(j)).Panic()
+	l.entry().WithFields(logrus.Fields(j)).Panic()
 }
 
-func logrusMiddlewareHandler(c echo.Context, next echo.HandlerFunc) error {
+func logrusMiddlewareHandler(c echo.Context, next echo.HandlerFunc, opts LogrusLoggerOptions) error {
 	req := c.Request()
 	res := c.Response()
+
+	// 요청 본문은 핸들러에서 읽기 전에 미리 읽어둔다.
+	var requestBody []byte
+	if opts.LogRequestBody == true && (req.Method == http.MethodPost || req.Method == http.MethodPut || req.Method == http.MethodPatch) {
+		var err error
+		if requestBody, err = readRequestBody(req, opts.MaxBodyBytes); err != nil {
+			logrus.WithError(err).Warn("요청 본문을 읽는 중에 오류가 발생하였습니다.")
+		}
+	}
+
 	start := time.Now()
 	if err := next(c); err != nil {
 		c.Error(err)
@@ -168,11 +234,11 @@ func logrusMiddlewareHandler(c echo.Context, next echo.HandlerFunc) error {
 		bytesIn = "0"
 	}
 
-	logrus.WithFields(map[string]interface{}{
+	fields := map[string]interface{}{
 		"time_rfc3339":  time.Now().Format(time.RFC3339),
 		"remote_ip":     c.RealIP(),
 		"host":          req.Host,
-		"uri":           req.RequestURI,
+		"uri":           string(maskAppKey([]byte(req.RequestURI))),
 		"method":        req.Method,
 		"path":          p,
 		"referer":       req.Referer(),
@@ -182,17 +248,34 @@ func logrusMiddlewareHandler(c echo.Context, next echo.HandlerFunc) error {
 		"latency_human": stop.Sub(start).String(),
 		"bytes_in":      bytesIn,
 		"bytes_out":     strconv.FormatInt(res.Size, 10),
-	}).Info("echo log")
+	}
+	if requestID := RequestIDFrom(c); requestID != "" {
+		fields[_log_.FieldRequestID] = requestID
+	}
+	if requestBody != nil {
+		// 바이너리 데이터도 출력할 수 있도록 base64로 인코딩한다.
+		fields["request_body"] = base64.StdEncoding.EncodeToString(maskAppKey(requestBody))
+	}
+
+	logrus.WithFields(fields).Info("echo log")
 
 	return nil
 }
 
-func logrusLogger(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		return logrusMiddlewareHandler(c, next)
-	}
+func LogrusLogger() echo.MiddlewareFunc {
+	return LogrusLoggerWithOptions(LogrusLoggerOptions{})
 }
 
-func LogrusLogger() echo.MiddlewareFunc {
-	return logrusLogger
+// LogrusLoggerWithOptions 요청 로그를 출력하는 미들웨어를 반환한다.
+// LogRequestBody가 설정된 경우 POST, PUT, PATCH 요청의 본문을 MaxBodyBytes 만큼 app_key를 가린 후 base64로 인코딩하여 함께 출력한다.
+func LogrusLoggerWithOptions(opts LogrusLoggerOptions) echo.MiddlewareFunc {
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = defaultMaxBodyBytes
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return logrusMiddlewareHandler(c, next, opts)
+		}
+	}
 }
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaskAppKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "JSON 필드", input: `{"app_key":"secret","message":"hi"}`, expected: `{"app_key":"****","message":"hi"}`},
+		{name: "JSON 필드(공백 포함)", input: `{"app_key" : "secret"}`, expected: `{"app_key" : "****"}`},
+		{name: "JSON 필드(이스케이프된 따옴표)", input: `{"app_key":"sec\"ret","message":"hi"}`, expected: `{"app_key":"****","message":"hi"}`},
+		{name: "잘린 JSON 필드", input: `{"message":"hi","app_key":"secr`, expected: `{"message":"hi","app_key":"****`},
+		{name: "백슬래시에서 잘린 JSON 필드", input: `{"app_key":"sec\`, expected: `{"app_key":"****`},
+		{name: "쿼리 파라미터", input: `/api/v1/notice/message?app_key=secret&id=1`, expected: `/api/v1/notice/message?app_key=****&id=1`},
+		{name: "마지막 쿼리 파라미터", input: `/api/v1/notice/message?id=1&app_key=secret`, expected: `/api/v1/notice/message?id=1&app_key=****`},
+		{name: "app_key 없음", input: `{"message":"hi"}`, expected: `{"message":"hi"}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, string(maskAppKey([]byte(tc.input))))
+		})
+	}
+}
+
+func TestReadRequestBody(t *testing.T) {
+	t.Run("본문 없음", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+		buf, err := readRequestBody(req, 10)
+		assert.NoError(t, err)
+		assert.Nil(t, buf)
+	})
+
+	t.Run("최대 크기보다 작은 본문", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("hello"))
+
+		buf, err := readRequestBody(req, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(buf))
+
+		body, err := io.ReadAll(req.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(body))
+	})
+
+	t.Run("최대 크기보다 큰 본문", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("hello, world"))
+
+		buf, err := readRequestBody(req, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(buf))
+
+		// 핸들러에서는 잘리지 않은 본문 전체를 읽을 수 있어야 한다.
+		body, err := io.ReadAll(req.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello, world", string(body))
+	})
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"github.com/darkkaiser/notify-server/metrics"
+	"github.com/labstack/echo/v4"
+	"strconv"
+)
+
+func metricsMiddlewareHandler(c echo.Context, next echo.HandlerFunc) error {
+	err := next(c)
+	if err != nil {
+		c.Error(err)
+	}
+
+	// 요청 URL 대신 등록된 라우트 경로를 사용하여 레이블 값의 종류가 무한히 늘어나지 않도록 한다.
+	path := c.Path()
+	if path == "" {
+		path = "unknown"
+	}
+
+	metrics.HTTPRequestsTotal.WithLabelValues(c.Request().Method, path, strconv.Itoa(c.Response().Status)).Inc()
+
+	return nil
+}
+
+func httpMetrics(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return metricsMiddlewareHandler(c, next)
+	}
+}
+
+func Metrics() echo.MiddlewareFunc {
+	return httpMetrics
+}
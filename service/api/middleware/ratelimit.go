@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"github.com/darkkaiser/notify-server/service/api/model"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitByApp 인증된 Application 별로 요청 횟수를 제한한다.
+// 반드시 Application 인증을 처리하는 미들웨어의 뒤에 위치하여야 한다.
+func RateLimitByApp(r, burst float64) echo.MiddlewareFunc {
+	var limiters sync.Map
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			application, ok := c.Get(model.ContextKeyAllowedApplication).(*model.AllowedApplication)
+			if ok == false || application == nil {
+				return next(c)
+			}
+
+			// 처음 요청한 Application인 경우 새로운 Limiter를 생성한다.
+			v, _ := limiters.LoadOrStore(application.ID, rate.NewLimiter(rate.Limit(r), int(burst)))
+			limiter := v.(*rate.Limiter)
+
+			reservation := limiter.Reserve()
+			if reservation.OK() == false {
+				return echo.NewHTTPError(http.StatusTooManyRequests, "요청 횟수가 허용된 범위를 초과하였습니다.")
+			}
+			if delay := reservation.Delay(); delay > 0 {
+				reservation.Cancel()
+
+				c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+
+				return echo.NewHTTPError(http.StatusTooManyRequests, "요청 횟수가 허용된 범위를 초과하였습니다.")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// slidingWindowLimiter 일정 시간(window) 동안의 요청 시각을 IP 별로 기록하여 요청 횟수를 제한한다.
+type slidingWindowLimiter struct {
+	window      time.Duration
+	maxRequests int
+
+	mu        sync.Mutex
+	requests  map[string][]time.Time
+	lastSweep time.Time
+}
+
+// allow 요청을 허용할지의 여부를 반환한다. 허용되지 않는 경우 다시 요청할 수 있을 때까지 남은 시간을 함께 반환한다.
+func (l *slidingWindowLimiter) allow(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// 오랫동안 요청이 없었던 IP의 기록을 주기적으로 삭제한다.
+	if now.Sub(l.lastSweep) >= l.window {
+		for k, timestamps := range l.requests {
+			if len(timestamps) == 0 || now.Sub(timestamps[len(timestamps)-1]) >= l.window {
+				delete(l.requests, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	// 윈도우를 벗어난 요청 기록을 제거한다.
+	timestamps := l.requests[key]
+	i := 0
+	for i < len(timestamps) && now.Sub(timestamps[i]) >= l.window {
+		i++
+	}
+	timestamps = timestamps[i:]
+
+	if len(timestamps) >= l.maxRequests {
+		l.requests[key] = timestamps
+		return false, timestamps[0].Add(l.window).Sub(now)
+	}
+
+	l.requests[key] = append(timestamps, now)
+
+	return true, 0
+}
+
+// RateLimitSlidingWindow 슬라이딩 윈도우 방식으로 IP 별 요청 횟수를 제한한다.
+// 토큰 버킷 방식과는 달리 윈도우 내에서 허용된 횟수를 초과하는 순간적인 요청 폭주를 허용하지 않는다.
+func RateLimitSlidingWindow(windowDuration time.Duration, maxRequests int) echo.MiddlewareFunc {
+	l := &slidingWindowLimiter{
+		window:      windowDuration,
+		maxRequests: maxRequests,
+
+		requests: make(map[string][]time.Time),
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if ok, retryAfter := l.allow(c.RealIP(), time.Now()); ok == false {
+				c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+
+				return echo.NewHTTPError(http.StatusTooManyRequests, "요청 횟수가 허용된 범위를 초과하였습니다.")
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"runtime/debug"
+)
+
+// 알림메시지에 포함되는 고루틴 스택의 최대 크기(바이트)
+const panicStackMaxBytes = 2000
+
+// PanicNotificationSender panic이 발생한 경우 관리자에게 알림메시지를 발송한다.
+type PanicNotificationSender interface {
+	NotifyWithErrorToDefault(message string) bool
+}
+
+// PanicRecovery 요청을 처리하는 중에 발생한 panic을 복구하여 500 Internal Server Error를 반환하는 미들웨어를 반환한다.
+// panic이 발생하면 panic 값, 요청 경로 및 고루틴 스택을 로그로 남기고, notificationSender가 nil이 아닌 경우 기본 Notifier로 알림메시지를 발송한다.
+func PanicRecovery(notificationSender PanicNotificationSender) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					return
+				}
+				// 클라이언트와의 연결을 중단하기 위해 발생시킨 panic은 복구하지 않는다.
+				if r == http.ErrAbortHandler {
+					panic(r)
+				}
+
+				stack := debug.Stack()
+				if len(stack) > panicStackMaxBytes {
+					stack = stack[:panicStackMaxBytes]
+				}
+
+				req := c.Request()
+				c.Logger().Errorf("API 요청(%s %s)을 처리하는 중에 panic이 발생하였습니다.(panic:%v)\n%s", req.Method, req.URL.Path, r, debug.Stack())
+
+				if notificationSender != nil {
+					m := fmt.Sprintf("API 요청을 처리하는 중에 panic이 발생하였습니다.😱\n\n☑ 요청 : %s %s\n☑ panic : %v\n\n%s", req.Method, req.URL.Path, r, stack)
+
+					// 알림메시지 발송이 지연되더라도 응답이 늦어지지 않도록 한다.
+					go notificationSender.NotifyWithErrorToDefault(m)
+				}
+
+				err = echo.NewHTTPError(http.StatusInternalServerError).SetInternal(fmt.Errorf("panic: %v", r))
+			}()
+
+			return next(c)
+		}
+	}
+}
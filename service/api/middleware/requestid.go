@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	_log_ "github.com/darkkaiser/notify-server/log"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ContextKeyRequestID 요청ID가 저장되는 echo.Context의 키
+	ContextKeyRequestID = "RequestID"
+
+	// 요청ID의 최대 길이, 클라이언트에서 전달된 요청ID가 이보다 길면 새로 생성한다.
+	maxRequestIDLength = 128
+)
+
+// RequestID 요청마다 고유한 요청ID를 부여하는 미들웨어를 반환한다.
+//
+// 클라이언트(또는 프록시)에서 X-Request-ID 헤더를 전달한 경우 해당 값을 그대로 사용하며, 그렇지 않은 경우 UUID v4를 생성한다.
+// 요청ID는 응답 헤더(X-Request-ID)와 echo.Context에 저장되고, 요청을 처리하는 동안 c.Logger()로 출력되는 모든 로그에 함께 출력된다.
+// 핸들러와 작업의 로그에도 요청ID가 출력될 수 있도록 요청의 Context에도 저장한다.
+func RequestID() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			requestID := c.Request().Header.Get(echo.HeaderXRequestID)
+			if requestID == "" || len(requestID) > maxRequestIDLength {
+				requestID = uuid.NewString()
+			}
+
+			c.Set(ContextKeyRequestID, requestID)
+			c.SetRequest(c.Request().WithContext(_log_.ContextWithRequestID(c.Request().Context(), requestID)))
+			c.Response().Header().Set(echo.HeaderXRequestID, requestID)
+			c.SetLogger(Logger{
+				Logger: logrus.StandardLogger(),
+				Fields: logrus.Fields{_log_.FieldRequestID: requestID},
+			})
+
+			return next(c)
+		}
+	}
+}
+
+// RequestIDFrom echo.Context에 저장된 요청ID를 반환한다.
+func RequestIDFrom(c echo.Context) string {
+	if requestID, ok := c.Get(ContextKeyRequestID).(string); ok == true {
+		return requestID
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type RequestTimeoutConfig struct {
+	// 요청을 처리하는 최대 시간
+	Timeout time.Duration
+
+	// true를 반환하는 요청은 처리 시간을 제한하지 않는다(SSE 등 연결을 계속 유지하는 요청에 사용한다).
+	Skipper func(c echo.Context) bool
+}
+
+// RequestTimeout 요청을 처리하는 시간을 d로 제한하는 미들웨어를 반환한다.
+func RequestTimeout(d time.Duration) echo.MiddlewareFunc {
+	return RequestTimeoutWithConfig(RequestTimeoutConfig{Timeout: d})
+}
+
+// RequestTimeoutWithConfig 요청의 Context에 처리 제한 시간을 설정하고, 제한 시간 안에 응답하지 못한 경우 503(Service Unavailable)으로 응답한다.
+// echo.Context는 핸들러가 반환되면 재사용되므로 http.TimeoutHandler처럼 핸들러를 별도의 고루틴에서 실행하지 않는다.
+// 따라서 핸들러는 요청의 Context가 취소되면 처리를 중단하고 반환하여야 한다.
+func RequestTimeoutWithConfig(config RequestTimeoutConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Timeout <= 0 || (config.Skipper != nil && config.Skipper(c) == true) {
+				return next(c)
+			}
+
+			req := c.Request()
+
+			ctx, cancel := context.WithTimeout(req.Context(), config.Timeout)
+			defer cancel()
+
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+
+			// 핸들러에서 설정한 제한 시간이 아닌 요청의 처리 제한 시간이 초과된 경우에만 503으로 응답한다.
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) == true && c.Response().Committed == false {
+				return echo.NewHTTPError(http.StatusServiceUnavailable, fmt.Sprintf("요청 처리 시간(%s)이 초과되었습니다.", config.Timeout)).SetInternal(err)
+			}
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestTimeoutWithConfig(t *testing.T) {
+	newEcho := func(timeout time.Duration) *echo.Echo {
+		e := echo.New()
+		e.Use(RequestTimeoutWithConfig(RequestTimeoutConfig{
+			Timeout: timeout,
+			Skipper: func(c echo.Context) bool {
+				return c.Path() == "/run"
+			},
+		}))
+
+		// 요청의 Context가 취소되거나 200ms가 지나면 응답한다.
+		handler := func(c echo.Context) error {
+			select {
+			case <-c.Request().Context().Done():
+				return c.Request().Context().Err()
+			case <-time.After(200 * time.Millisecond):
+				return c.NoContent(http.StatusOK)
+			}
+		}
+		e.GET("/message", handler)
+		e.GET("/run", handler)
+
+		return e
+	}
+
+	testCases := []struct {
+		name     string
+		timeout  time.Duration
+		path     string
+		expected int
+	}{
+		{name: "제한 시간 안에 처리된 요청", timeout: time.Second, path: "/message", expected: http.StatusOK},
+		{name: "제한 시간이 초과된 요청", timeout: 50 * time.Millisecond, path: "/message", expected: http.StatusServiceUnavailable},
+		{name: "처리 시간을 제한하지 않는 요청", timeout: 50 * time.Millisecond, path: "/run", expected: http.StatusOK},
+		{name: "제한 시간이 설정되지 않은 경우", timeout: 0, path: "/message", expected: http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			rec := httptest.NewRecorder()
+			newEcho(tc.timeout).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Code)
+		})
+	}
+}
@@ -1,5 +1,14 @@
 package model
 
+import (
+	"crypto/subtle"
+	"github.com/darkkaiser/notify-server/utils"
+	"strings"
+)
+
+// 인증된 Application 정보를 echo.Context에 저장할 때 사용하는 키
+const ContextKeyAllowedApplication = "AllowedApplication"
+
 //
 // AllowedApplication
 //
@@ -9,4 +18,20 @@ type AllowedApplication struct {
 	Description       string
 	DefaultNotifierID string
 	AppKey            string
+	HashedAppKey      string
+
+	// 작업결과데이터 삭제 등 관리용 API의 사용 가능 여부
+	Admin bool
+}
+
+// MatchAppKey 요청된 APP_KEY가 Application의 APP_KEY와 일치하는지 확인한다.
+// 해싱된 APP_KEY가 설정된 경우, 요청된 APP_KEY를 해싱하여 비교한다.
+func (a *AllowedApplication) MatchAppKey(appKey string) bool {
+	if a.HashedAppKey != "" {
+		return subtle.ConstantTimeCompare([]byte(utils.HashKey(appKey)), []byte(strings.ToLower(a.HashedAppKey))) == 1
+	}
+	if a.AppKey != "" {
+		return subtle.ConstantTimeCompare([]byte(appKey), []byte(a.AppKey)) == 1
+	}
+	return false
 }
@@ -0,0 +1,6 @@
+package model
+
+type BroadcastMessage struct {
+	Message string `json:"message"`
+	IsError bool   `json:"is_error"`
+}
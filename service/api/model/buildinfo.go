@@ -0,0 +1,10 @@
+package model
+
+type BuildInfo struct {
+	Version     string `json:"version"`
+	BuildDate   string `json:"build_date"`
+	BuildNumber string `json:"build_number"`
+	GoVersion   string `json:"go_version"`
+	OS          string `json:"os"`
+	Arch        string `json:"arch"`
+}
@@ -0,0 +1,14 @@
+package model
+
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusDegraded = "degraded"
+	HealthStatusPaused   = "paused"
+)
+
+type Health struct {
+	Status          string            `json:"status"`
+	Components      map[string]string `json:"components"`
+	RunningTasks    int               `json:"running_tasks"`
+	SchedulerPaused bool              `json:"scheduler_paused"`
+}
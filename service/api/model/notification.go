@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+type NotificationHistory struct {
+	ID             int64     `json:"id"`
+	NotifierID     string    `json:"notifier_id"`
+	TaskID         string    `json:"task_id"`
+	MessagePreview string    `json:"message_preview"`
+	SentAt         time.Time `json:"sent_at"`
+	ErrorOccurred  bool      `json:"error_occurred"`
+}
+
+type NotificationEvent struct {
+	ID            int64     `json:"id"`
+	NotifierID    string    `json:"notifier_id"`
+	TaskID        string    `json:"task_id"`
+	Title         string    `json:"title"`
+	Message       string    `json:"message"`
+	ErrorOccurred bool      `json:"error_occurred"`
+	SentAt        time.Time `json:"sent_at"`
+}
+
+const (
+	NotifierStatusOK    = "ok"
+	NotifierStatusError = "error"
+)
+
+type Notifier struct {
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	SupportsHTML bool   `json:"supports_html"`
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+type RunningTask struct {
+	InstanceID string    `json:"instance_id"`
+	TaskID     string    `json:"task_id"`
+	CommandID  string    `json:"command_id"`
+	RunBy      string    `json:"run_by"`
+	StartedAt  time.Time `json:"started_at"`
+	ElapsedMs  int64     `json:"elapsed_ms"`
+}
+
+type TaskExecutionHistory struct {
+	ID            int64     `json:"id"`
+	TaskID        string    `json:"task_id"`
+	CommandID     string    `json:"command_id"`
+	InstanceID    string    `json:"instance_id"`
+	RunBy         string    `json:"run_by"`
+	StartedAt     time.Time `json:"started_at"`
+	FinishedAt    time.Time `json:"finished_at"`
+	DurationMs    int64     `json:"duration_ms"`
+	Success       bool      `json:"success"`
+	MessageLength int       `json:"message_length"`
+	Error         string    `json:"error"`
+}
+
+type TaskRunRequest struct {
+	TaskID         string `json:"task_id"`
+	CommandID      string `json:"command_id"`
+	TimeoutSeconds int    `json:"timeout_seconds"`
+}
+
+type TaskRunResult struct {
+	InstanceID string `json:"instance_id"`
+	TaskID     string `json:"task_id"`
+	CommandID  string `json:"command_id"`
+	Message    string `json:"message"`
+}
+
+type TaskSchedule struct {
+	TaskID    string   `json:"task_id"`
+	CommandID string   `json:"command_id"`
+	CronExpr  string   `json:"cron_expr"`
+	NextFires []string `json:"next_fires"`
+}
@@ -0,0 +1,10 @@
+package model
+
+//
+// Webhook
+//
+type Webhook struct {
+	ID        string
+	TaskID    string
+	CommandID string
+}
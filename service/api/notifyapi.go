@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"github.com/darkkaiser/notify-server/g"
 	"github.com/darkkaiser/notify-server/service/api/handler"
+	_middleware_ "github.com/darkkaiser/notify-server/service/api/middleware"
 	"github.com/darkkaiser/notify-server/service/api/router"
 	"github.com/darkkaiser/notify-server/service/notification"
+	"github.com/darkkaiser/notify-server/service/task"
 	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 	"net/http"
 	"sync"
@@ -23,9 +26,12 @@ type NotifyAPIService struct {
 	runningMu sync.Mutex
 
 	notificationSender notification.NotificationSender
+
+	taskRunner  task.TaskRunner
+	taskMonitor task.TaskMonitor
 }
 
-func NewNotifyAPIService(config *g.AppConfig, notificationSender notification.NotificationSender) *NotifyAPIService {
+func NewNotifyAPIService(config *g.AppConfig, notificationSender notification.NotificationSender, taskRunner task.TaskRunner, taskMonitor task.TaskMonitor) *NotifyAPIService {
 	return &NotifyAPIService{
 		config: config,
 
@@ -33,6 +39,9 @@ func NewNotifyAPIService(config *g.AppConfig, notificationSender notification.No
 		runningMu: sync.Mutex{},
 
 		notificationSender: notificationSender,
+
+		taskRunner:  taskRunner,
+		taskMonitor: taskMonitor,
 	}
 }
 
@@ -45,6 +54,12 @@ func (s *NotifyAPIService) Run(serviceStopCtx context.Context, serviceStopWaiter
 	if s.notificationSender == nil {
 		log.Panic("NotificationSender 객체가 초기화되지 않았습니다.")
 	}
+	if s.taskRunner == nil {
+		log.Panic("TaskRunner 객체가 초기화되지 않았습니다.")
+	}
+	if s.taskMonitor == nil {
+		log.Panic("TaskMonitor 객체가 초기화되지 않았습니다.")
+	}
 
 	if s.running == true {
 		defer serviceStopWaiter.Done()
@@ -64,12 +79,87 @@ func (s *NotifyAPIService) Run(serviceStopCtx context.Context, serviceStopWaiter
 func (s *NotifyAPIService) run0(serviceStopCtx context.Context, serviceStopWaiter *sync.WaitGroup) {
 	defer serviceStopWaiter.Done()
 
-	h := handler.NewHandler(s.config, s.notificationSender)
+	h := handler.NewHandler(s.config, s.notificationSender, s.taskRunner, s.taskMonitor)
+
+	// 인증이 필요한 API에 적용할 미들웨어 목록
+	// 요청 횟수 제한은 인증된 Application 별로 적용되므로 반드시 인증 미들웨어의 뒤에 위치하여야 한다.
+	authMiddlewares := []echo.MiddlewareFunc{h.RequireAuthentication}
+	if s.config.NotifyAPI.RateLimit.Rate > 0 {
+		authMiddlewares = append(authMiddlewares, _middleware_.RateLimitByApp(s.config.NotifyAPI.RateLimit.Rate, float64(s.config.NotifyAPI.RateLimit.Burst)))
+	}
+
+	// 관리용 API에 적용할 미들웨어 목록
+	// 접근 허용 IP 대역이 설정된 경우, 허용된 IP에서만 접근할 수 있다.
+	adminMiddlewares := append([]echo.MiddlewareFunc{}, authMiddlewares...)
+	if len(s.config.NotifyAPI.AdminIPAllowlist.CIDRs) > 0 {
+		ipAllowlist, err := _middleware_.IPAllowlist(_middleware_.IPAllowlistConfig{
+			CIDRs:      s.config.NotifyAPI.AdminIPAllowlist.CIDRs,
+			TrustProxy: s.config.NotifyAPI.AdminIPAllowlist.TrustProxy,
+		})
+		if err != nil {
+			log.Panic(err)
+		}
+		adminMiddlewares = append([]echo.MiddlewareFunc{ipAllowlist}, adminMiddlewares...)
+	}
+
+	e := router.New(s.notificationSender, _middleware_.LogrusLoggerOptions{
+		LogRequestBody: s.config.NotifyAPI.HTTPLog.LogRequestBody,
+		MaxBodyBytes:   s.config.NotifyAPI.HTTPLog.MaxBodyBytes,
+	})
+	// 요청 횟수 제한 등에서 사용하는 c.RealIP()가 클라이언트가 임의로 입력한 X-Forwarded-For, X-Real-IP 헤더를 사용하지 않도록 한다.
+	// 프록시 뒤에서 동작하는 경우에는 신뢰하는 프록시(루프백, 사설 IP 대역)가 추가한 X-Forwarded-For 헤더의 IP만 사용한다.
+	if s.config.NotifyAPI.AdminIPAllowlist.TrustProxy == true {
+		e.IPExtractor = echo.ExtractIPFromXFFHeader()
+	} else {
+		e.IPExtractor = echo.ExtractIPDirect()
+	}
+	e.Use(_middleware_.RequestBodySizeLimitWithConfig(_middleware_.RequestBodySizeLimitConfig{
+		MaxBytes: s.config.NotifyAPI.MaxRequestBodyBytes,
+		// 외부 서비스(GitHub 등)의 Webhook 요청 본문은 최대 크기보다 클 수 있으므로, 핸들러에서 별도의 최대 크기로 제한한다.
+		Skipper: func(c echo.Context) bool {
+			return c.Path() == "/api/v1/webhook/:webhookId"
+		},
+	}))
+	e.Use(_middleware_.RequestTimeoutWithConfig(_middleware_.RequestTimeoutConfig{
+		Timeout: time.Duration(s.config.NotifyAPI.RequestTimeoutSeconds) * time.Second,
+		// 알림 이벤트 스트림(SSE)은 연결을 계속 유지하므로 처리 시간을 제한하지 않는다.
+		// 작업 실행 API는 요청된 작업 실행 제한 시간(timeout_seconds, 최대 600초)을 핸들러에서 직접 적용한다.
+		Skipper: func(c echo.Context) bool {
+			return c.Path() == "/api/v1/events" || c.Path() == "/api/v1/run"
+		},
+	}))
+	if s.config.NotifyAPI.RateLimit.SlidingWindow.WindowSeconds > 0 {
+		e.Use(_middleware_.RateLimitSlidingWindow(time.Duration(s.config.NotifyAPI.RateLimit.SlidingWindow.WindowSeconds)*time.Second, s.config.NotifyAPI.RateLimit.SlidingWindow.MaxRequests))
+	}
+	e.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
 
-	e := router.New()
 	grp := e.Group("/api/v1")
 	{
-		grp.POST("/notice/message", h.NotifyMessageSendHandler)
+		// 인증되지 않은 요청이 알림메시지 발송을 반복하지 않도록 다른 API와 같이 인증 및 요청 횟수 제한을 적용한다.
+		grp.POST("/notice/message", h.NotifyMessageSendHandler, authMiddlewares...)
+
+		// 로드밸런서 등에서 상태를 확인할 수 있도록 인증 없이 접근을 허용한다.
+		grp.GET("/health", h.HealthHandler)
+
+		// 배포된 버전을 확인할 수 있도록 인증 없이 접근을 허용한다.
+		grp.GET("/build-info", h.BuildInfoHandler)
+
+		grp.GET("/tasks", h.TaskListHandler, authMiddlewares...)
+		grp.POST("/run", h.TaskRunHandler, authMiddlewares...)
+		grp.POST("/webhook/:webhookId", h.WebhookHandler, authMiddlewares...)
+		grp.DELETE("/tasks/:instanceId", h.TaskCancelHandler, authMiddlewares...)
+		grp.GET("/tasks/:taskId/history", h.TaskHistoryHandler, adminMiddlewares...)
+		grp.DELETE("/tasks/:taskId/commands/:commandId/snapshot", h.TaskResultDataDeleteHandler, append(adminMiddlewares, h.RequireAdmin)...)
+		grp.GET("/tasks/:taskId/commands/:commandId/snapshot/export", h.TaskResultDataExportHandler, append(adminMiddlewares, h.RequireAdmin)...)
+
+		grp.GET("/notifications/history", h.NotificationHistoryHandler, adminMiddlewares...)
+		grp.GET("/notifiers", h.NotifierListHandler, append(adminMiddlewares, h.RequireAdmin)...)
+		grp.GET("/events", h.NotificationEventStreamHandler, authMiddlewares...)
+
+		grp.GET("/admin/schedules", h.TaskScheduleListHandler, append(adminMiddlewares, h.RequireAdmin)...)
+		grp.POST("/admin/pause", h.TaskServicePauseHandler, append(adminMiddlewares, h.RequireAdmin)...)
+		grp.POST("/admin/resume", h.TaskServiceResumeHandler, append(adminMiddlewares, h.RequireAdmin)...)
+		grp.POST("/admin/broadcast", h.BroadcastHandler, append(adminMiddlewares, h.RequireAdmin)...)
 	}
 
 	echo.NotFoundHandler = func(c echo.Context) error {
@@ -103,16 +193,22 @@ func (s *NotifyAPIService) run0(serviceStopCtx context.Context, serviceStopWaite
 		log.Debug("NotifyAPI 서비스 중지중...")
 
 		// 웹서버를 종료한다.
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		// 새로운 요청은 더 이상 받지 않고, 처리중인 요청은 종료 대기 시간 동안 완료될 때까지 기다린다.
+		// 알림 이벤트 스트림은 클라이언트가 연결을 종료할 때까지 완료되지 않으므로 먼저 종료시킨다.
+		h.StopEventStreams()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(s.config.NotifyAPI.ShutdownTimeoutSeconds)*time.Second)
 		defer cancel()
 
 		if err := e.Shutdown(ctx); err != nil {
-			log.Error(err)
+			log.Errorf("처리중인 요청이 종료 대기 시간 내에 완료되지 않았습니다.(error:%s)", err)
 		}
 
 		s.runningMu.Lock()
 		s.running = false
 		s.notificationSender = nil
+		s.taskRunner = nil
+		s.taskMonitor = nil
 		s.runningMu.Unlock()
 
 		log.Debug("NotifyAPI 서비스 중지됨")
@@ -8,7 +8,9 @@ import (
 	"net/http"
 )
 
-func New() *echo.Echo {
+// New 공통 미들웨어가 등록된 echo 인스턴스를 생성한다.
+// panic이 발생한 경우 panicNotificationSender로 관리자에게 알림메시지를 발송한다.
+func New(panicNotificationSender _middleware_.PanicNotificationSender, loggerOptions _middleware_.LogrusLoggerOptions) *echo.Echo {
 	e := echo.New()
 
 	e.Debug = true
@@ -17,7 +19,9 @@ func New() *echo.Echo {
 	// echo에서 출력되는 로그를 Logrus Logger로 출력되도록 한다.
 	// echo Logger의 인터페이스를 래핑한 객체를 이용하여 Logrus Logger로 보낸다.
 	e.Logger = _middleware_.Logger{Logger: log.StandardLogger()}
-	e.Use(_middleware_.LogrusLogger())
+	e.Use(_middleware_.RequestID())
+	e.Use(_middleware_.LogrusLoggerWithOptions(loggerOptions))
+	e.Use(_middleware_.Metrics())
 	// echo 기본 로그출력 구문, 필요치 않음!!!
 	/*
 		e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
@@ -30,8 +34,9 @@ func New() *echo.Echo {
 		AllowOrigins: []string{"*"},
 		AllowMethods: []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete},
 	}))
-	e.Use(middleware.Recover()) // Recover from panics anywhere in the chain
+	e.Use(_middleware_.PanicRecovery(panicNotificationSender)) // Recover from panics anywhere in the chain
 	e.Use(middleware.Secure())
+	e.Use(_middleware_.ResponseCompression())
 
 	return e
 }
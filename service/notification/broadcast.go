@@ -0,0 +1,23 @@
+package notification
+
+import (
+	"strings"
+)
+
+// BroadcastError 모든 Notifier로 알림메시지를 발송할 때 발송이 실패한 Notifier의 에러 목록
+type BroadcastError struct {
+	Errors []error
+}
+
+func (e *BroadcastError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+func (e *BroadcastError) Unwrap() []error {
+	return e.Errors
+}
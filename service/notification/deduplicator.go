@@ -0,0 +1,85 @@
+package notification
+
+import (
+	"context"
+	"crypto/sha256"
+	"github.com/darkkaiser/notify-server/service/task"
+	log "github.com/sirupsen/logrus"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Deduplicator 동일한 알림메시지가 TTL 시간 내에 반복해서 발송되지 않도록 Notifier를 감싼다.
+type Deduplicator struct {
+	notifierHandler
+
+	ttl time.Duration
+
+	// 최근에 발송된 알림메시지의 해시값과 발송 시간
+	sentMessages sync.Map
+
+	// 발송이 생략된 알림메시지의 갯수
+	suppressedCount int64
+}
+
+func newDeduplicator(h notifierHandler, ttl time.Duration) *Deduplicator {
+	return &Deduplicator{
+		notifierHandler: h,
+
+		ttl: ttl,
+	}
+}
+
+func (d *Deduplicator) Notify(message string, taskCtx task.TaskContext) bool {
+	key := sha256.Sum256([]byte(message))
+
+	now := time.Now()
+	if sentTime, loaded := d.sentMessages.LoadOrStore(key, now); loaded == true {
+		if now.Sub(sentTime.(time.Time)) < d.ttl {
+			suppressedCount := atomic.AddInt64(&d.suppressedCount, 1)
+
+			log.Infof("'%s' Notifier에서 %s 이내에 발송된 알림메시지와 동일하여 발송을 생략합니다.(생략된 알림메시지 누적 갯수:%d)", d.ID(), d.ttl, suppressedCount)
+
+			return true
+		}
+
+		d.sentMessages.Store(key, now)
+	}
+
+	succeeded := d.notifierHandler.Notify(message, taskCtx)
+	if succeeded == false {
+		// 발송이 실패한 알림메시지는 다시 발송될 수 있도록 한다.
+		d.sentMessages.Delete(key)
+	}
+
+	return succeeded
+}
+
+func (d *Deduplicator) Run(taskRunner task.TaskRunner, notificationStopCtx context.Context, notificationStopWaiter *sync.WaitGroup) {
+	go d.cleanUp(notificationStopCtx)
+
+	d.notifierHandler.Run(taskRunner, notificationStopCtx, notificationStopWaiter)
+}
+
+// cleanUp TTL 시간이 지난 알림메시지의 해시값을 주기적으로 삭제한다.
+func (d *Deduplicator) cleanUp(notificationStopCtx context.Context) {
+	ticker := time.NewTicker(d.ttl)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			now := time.Now()
+			d.sentMessages.Range(func(key, value interface{}) bool {
+				if now.Sub(value.(time.Time)) >= d.ttl {
+					d.sentMessages.Delete(key)
+				}
+				return true
+			})
+
+		case <-notificationStopCtx.Done():
+			return
+		}
+	}
+}
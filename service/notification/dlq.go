@@ -0,0 +1,368 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/darkkaiser/notify-server/g"
+	_log_ "github.com/darkkaiser/notify-server/log"
+	"github.com/darkkaiser/notify-server/service/task"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	// 발송이 실패한 알림메시지를 다시 발송하는 최대 횟수
+	notificationDLQMaxRetries = 5
+
+	// 발송이 실패한 알림메시지를 다시 발송하기 전에 대기하는 시간(재시도할 때마다 2배씩 증가한다)
+	notificationDLQRetryDelay    = time.Minute
+	notificationDLQMaxRetryDelay = time.Hour
+
+	// 다시 발송할 알림메시지를 확인하는 주기
+	notificationDLQCheckInterval = 30 * time.Second
+)
+
+// notificationSendResult Notifier에서 알림메시지를 발송한 결과
+type notificationSendResult struct {
+	notifierID NotifierID
+	data       *notificationSendData
+	err        error
+}
+
+// notificationDLQPayload DLQ에 저장되는 알림메시지
+type notificationDLQPayload struct {
+	Message       string             `json:"message"`
+	HasTaskCtx    bool               `json:"has_task_ctx"`
+	Title         string             `json:"title,omitempty"`
+	TaskID        task.TaskID        `json:"task_id,omitempty"`
+	TaskCommandID task.TaskCommandID `json:"task_command_id,omitempty"`
+	ErrorOccurred bool               `json:"error_occurred,omitempty"`
+}
+
+func newNotificationDLQPayload(data *notificationSendData) *notificationDLQPayload {
+	payload := &notificationDLQPayload{Message: data.message}
+	if data.taskCtx != nil {
+		payload.HasTaskCtx = true
+		payload.Title, _ = data.taskCtx.Value(task.TaskCtxKeyTitle).(string)
+		payload.TaskID, _ = data.taskCtx.Value(task.TaskCtxKeyTaskID).(task.TaskID)
+		payload.TaskCommandID, _ = data.taskCtx.Value(task.TaskCtxKeyTaskCommandID).(task.TaskCommandID)
+		payload.ErrorOccurred, _ = data.taskCtx.Value(task.TaskCtxKeyErrorOccurred).(bool)
+	}
+	return payload
+}
+
+func (p *notificationDLQPayload) taskContext() task.TaskContext {
+	if p.HasTaskCtx == false {
+		return nil
+	}
+
+	taskCtx := task.NewContext()
+	if p.Title != "" {
+		taskCtx.With(task.TaskCtxKeyTitle, p.Title)
+	}
+	if p.TaskID != "" {
+		taskCtx.WithTask(p.TaskID, p.TaskCommandID)
+	}
+	if p.ErrorOccurred == true {
+		taskCtx.WithError()
+	}
+	return taskCtx
+}
+
+// notificationDLQItem 발송이 실패하여 다시 발송을 기다리는 알림메시지
+type notificationDLQItem struct {
+	id         int64
+	notifierID NotifierID
+	payload    *notificationDLQPayload
+	retryCount int
+}
+
+// notificationDLQ 발송이 실패한 알림메시지를 SQLite 데이터베이스의 notification_dlq 테이블에 저장하고 다시 발송한다.
+// 최대 횟수만큼 다시 발송하여도 실패한 알림메시지는 notification_dlq_dead 테이블로 옮긴다.
+type notificationDLQ struct {
+	db *sql.DB
+}
+
+// newNotificationDLQ 작업결과데이터 저장소가 sqlite인 경우에만 DLQ를 생성한다.
+func newNotificationDLQ(config *g.AppConfig) (*notificationDLQ, error) {
+	if config.Storage.Type != task.TaskResultStoreTypeSQLite {
+		return nil, nil
+	}
+
+	path := config.Storage.SQLite.Path
+	if path == "" {
+		path = fmt.Sprintf("%s.db", g.AppName)
+	}
+
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)", path))
+	if err != nil {
+		return nil, fmt.Errorf("알림메시지 DLQ 데이터베이스(%s)를 열 수 없습니다.(error:%s)", path, err)
+	}
+
+	if _, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS notification_dlq (
+			id            INTEGER PRIMARY KEY AUTOINCREMENT,
+			notifier_id   TEXT NOT NULL,
+			payload_json  TEXT NOT NULL,
+			failed_at     DATETIME NOT NULL,
+			retry_count   INTEGER NOT NULL,
+			last_error    TEXT NOT NULL,
+			next_retry_at DATETIME NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS notification_dlq_dead (
+			id           INTEGER PRIMARY KEY,
+			notifier_id  TEXT NOT NULL,
+			payload_json TEXT NOT NULL,
+			failed_at    DATETIME NOT NULL,
+			retry_count  INTEGER NOT NULL,
+			last_error   TEXT NOT NULL
+		)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("알림메시지 DLQ 데이터베이스(%s)의 테이블 생성이 실패하였습니다.(error:%s)", path, err)
+	}
+
+	return &notificationDLQ{db: db}, nil
+}
+
+// notificationDLQNextRetryDelay retryCount번 다시 발송한 알림메시지를 다음에 다시 발송하기 전에 대기하는 시간
+func notificationDLQNextRetryDelay(retryCount int) time.Duration {
+	delay := notificationDLQRetryDelay
+	for i := 0; i < retryCount && delay < notificationDLQMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > notificationDLQMaxRetryDelay {
+		delay = notificationDLQMaxRetryDelay
+	}
+	return delay
+}
+
+func (q *notificationDLQ) add(notifierID NotifierID, data *notificationSendData, sendErr error) error {
+	payloadJSON, err := json.Marshal(newNotificationDLQPayload(data))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	_, err = q.db.Exec(`
+		INSERT INTO notification_dlq (notifier_id, payload_json, failed_at, retry_count, last_error, next_retry_at) VALUES (?, ?, ?, 0, ?, ?)`,
+		string(notifierID), string(payloadJSON), now, sendErr.Error(), now.Add(notificationDLQNextRetryDelay(0)))
+
+	return err
+}
+
+func (q *notificationDLQ) remove(id int64) error {
+	_, err := q.db.Exec(`DELETE FROM notification_dlq WHERE id = ?`, id)
+	return err
+}
+
+// retryFailed 다시 발송한 알림메시지의 발송이 실패한 경우 재시도 횟수를 증가시킨다.
+// 최대 횟수만큼 다시 발송한 경우 notification_dlq_dead 테이블로 옮기고 dead를 true로 반환한다.
+func (q *notificationDLQ) retryFailed(id int64, sendErr error) (dead bool, err error) {
+	tx, err := q.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var retryCount int
+	if err = tx.QueryRow(`SELECT retry_count FROM notification_dlq WHERE id = ?`, id).Scan(&retryCount); err != nil {
+		return false, err
+	}
+	retryCount++
+
+	if retryCount >= notificationDLQMaxRetries {
+		if _, err = tx.Exec(`
+			INSERT INTO notification_dlq_dead (id, notifier_id, payload_json, failed_at, retry_count, last_error)
+			SELECT id, notifier_id, payload_json, failed_at, ?, ? FROM notification_dlq WHERE id = ?`, retryCount, sendErr.Error(), id); err != nil {
+			return false, err
+		}
+		if _, err = tx.Exec(`DELETE FROM notification_dlq WHERE id = ?`, id); err != nil {
+			return false, err
+		}
+
+		return true, tx.Commit()
+	}
+
+	if _, err = tx.Exec(`
+		UPDATE notification_dlq SET retry_count = ?, last_error = ?, next_retry_at = ? WHERE id = ?`,
+		retryCount, sendErr.Error(), time.Now().Add(notificationDLQNextRetryDelay(retryCount)), id); err != nil {
+		return false, err
+	}
+
+	return false, tx.Commit()
+}
+
+// dueItems 다시 발송할 시간이 된 알림메시지를 반환한다.
+// 반환된 알림메시지는 발송 결과를 받지 못하더라도(서버 재시작 등) 최대 대기 시간이 지나면 다시 발송되도록 다음 발송 시간을 미룬다.
+// noinspection GoUnhandledErrorResult
+func (q *notificationDLQ) dueItems(now time.Time) ([]*notificationDLQItem, error) {
+	rows, err := q.db.Query(`
+		SELECT id, notifier_id, payload_json, retry_count FROM notification_dlq WHERE next_retry_at <= ? ORDER BY id`, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*notificationDLQItem
+	for rows.Next() {
+		var payloadJSON string
+		item := &notificationDLQItem{payload: &notificationDLQPayload{}}
+		if err = rows.Scan(&item.id, &item.notifierID, &payloadJSON, &item.retryCount); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(payloadJSON), item.payload); err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for _, item := range items {
+		if _, err = q.db.Exec(`UPDATE notification_dlq SET next_retry_at = ? WHERE id = ?`, now.Add(notificationDLQMaxRetryDelay), item.id); err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+func (q *notificationDLQ) close() error {
+	return q.db.Close()
+}
+
+// handleSendResult 발송이 실패한 알림메시지를 대체 Notifier로 발송하거나 DLQ에 저장하고, 다시 발송한 알림메시지의 발송 결과를 DLQ에 반영한다.
+func (s *NotificationService) handleSendResult(result *notificationSendResult) {
+	id := result.data.dlqID
+
+	entry := _log_.WithNotifierID(string(result.notifierID))
+
+	// 처음 발송한(대체 Notifier로 발송한 경우 포함) 알림메시지
+	if id == 0 {
+		if result.err == nil {
+			return
+		}
+
+		if s.resendWithFallback(result) == true {
+			return
+		}
+
+		// 대체 Notifier로도 발송할 수 없는 경우, 처음 발송한 Notifier로 다시 발송하도록 DLQ에 저장한다.
+		if s.dlq != nil {
+			notifierID := result.notifierID
+			if len(result.data.failedNotifierIDs) > 0 {
+				notifierID = result.data.failedNotifierIDs[0]
+			}
+			if err := s.dlq.add(notifierID, result.data, result.err); err != nil {
+				entry.WithError(err).Error("발송이 실패한 알림메시지를 DLQ에 저장하지 못하였습니다.")
+			}
+		}
+		return
+	}
+
+	// DLQ에서 다시 발송한 알림메시지
+	if result.err == nil {
+		if err := s.dlq.remove(id); err != nil {
+			entry.WithError(err).Warnf("다시 발송된 알림메시지를 DLQ에서 삭제하지 못하였습니다.(ID:%d)", id)
+		}
+		return
+	}
+
+	dead, err := s.dlq.retryFailed(id, result.err)
+	if err != nil {
+		entry.WithError(err).Errorf("다시 발송이 실패한 알림메시지의 재시도 정보를 DLQ에 저장하지 못하였습니다.(ID:%d)", id)
+		return
+	}
+	if dead == true {
+		m := fmt.Sprintf("'%s' Notifier로 알림메시지를 %d회 다시 발송하였으나 모두 실패하였습니다.😱\n\n☑ %s", result.notifierID, notificationDLQMaxRetries, result.err)
+
+		entry.Error(m)
+
+		// 실패한 Notifier가 기본 Notifier인 경우, 오류 알림메시지도 발송이 실패하여 다시 DLQ에 저장되는 것이 반복되므로 로그만 남긴다.
+		s.runningMu.Lock()
+		defaultNotifierHandler := s.defaultNotifierHandler
+		s.runningMu.Unlock()
+		if defaultNotifierHandler != nil && defaultNotifierHandler.ID() == result.notifierID {
+			return
+		}
+
+		s.NotifyWithErrorToDefault(m)
+	}
+}
+
+// retryDueItems 다시 발송할 시간이 된 알림메시지를 발송한다.
+// 다시 발송하는 알림메시지는 중복 발송 방지 기능을 거치지 않고 Notifier로 바로 전달한다.
+func (s *NotificationService) retryDueItems() {
+	items, err := s.dlq.dueItems(time.Now())
+	if err != nil {
+		log.Errorf("DLQ에서 다시 발송할 알림메시지를 읽어들이지 못하였습니다.(error:%s)", err)
+		return
+	}
+
+	handlers := make([]notifierHandler, len(items))
+
+	s.runningMu.Lock()
+	if s.running == false {
+		s.runningMu.Unlock()
+		return
+	}
+	for i, item := range items {
+		for _, h := range s.notifierHandlers {
+			if h.ID() == item.notifierID {
+				handlers[i] = h
+				break
+			}
+		}
+	}
+	s.runningMu.Unlock()
+
+	for i, item := range items {
+		data := &notificationSendData{
+			message: item.payload.Message,
+			taskCtx: item.payload.taskContext(),
+			dlqID:   item.id,
+		}
+
+		// 환경설정에서 삭제된 Notifier의 알림메시지는 발송이 실패한 것으로 처리한다.
+		if handlers[i] == nil {
+			s.handleSendResult(&notificationSendResult{notifierID: item.notifierID, data: data, err: fmt.Errorf("알 수 없는 Notifier('%s')입니다", item.notifierID)})
+			continue
+		}
+
+		_log_.WithNotifierID(string(item.notifierID)).Infof("발송이 실패한 알림메시지를 다시 발송합니다.(ID:%d, 재시도:%d/%d)", item.id, item.retryCount+1, notificationDLQMaxRetries)
+
+		handlers[i].resend(data)
+	}
+}
+
+// runSendResultHandler 알림메시지의 발송 결과를 처리하고, DLQ가 설정된 경우 다시 발송할 시간이 된 알림메시지를 주기적으로 발송한다.
+// 서비스가 중지되면 DLQ의 데이터베이스를 닫고 반환한다.
+func (s *NotificationService) runSendResultHandler(serviceStopCtx context.Context) {
+	var retryC <-chan time.Time
+	if s.dlq != nil {
+		defer s.dlq.close()
+
+		ticker := time.NewTicker(notificationDLQCheckInterval)
+		defer ticker.Stop()
+
+		retryC = ticker.C
+	}
+
+	for {
+		select {
+		case result := <-s.sendResultC:
+			s.handleSendResult(result)
+
+		case <-retryC:
+			s.retryDueItems()
+
+		case <-serviceStopCtx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"github.com/darkkaiser/notify-server/g"
+	"github.com/darkkaiser/notify-server/service/task"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestNotificationDLQ(t *testing.T, path string) *notificationDLQ {
+	config := &g.AppConfig{}
+	config.Storage.Type = task.TaskResultStoreTypeSQLite
+	config.Storage.SQLite.Path = path
+
+	dlq, err := newNotificationDLQ(config)
+	assert.NoError(t, err)
+
+	return dlq
+}
+
+func TestNotificationDLQNextRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, notificationDLQNextRetryDelay(0))
+	assert.Equal(t, 2*time.Minute, notificationDLQNextRetryDelay(1))
+	assert.Equal(t, 16*time.Minute, notificationDLQNextRetryDelay(4))
+
+	// 최대 대기 시간을 넘지 않는다.
+	assert.Equal(t, time.Hour, notificationDLQNextRetryDelay(6))
+	assert.Equal(t, time.Hour, notificationDLQNextRetryDelay(100))
+}
+
+func TestNotificationDLQ_RetryAndDeadLetter(t *testing.T) {
+	dlq := newTestNotificationDLQ(t, filepath.Join(t.TempDir(), "dlq.db"))
+	defer dlq.close()
+
+	sendErr := errors.New("send failed")
+	assert.NoError(t, dlq.add("telegram-1", &notificationSendData{message: "message", taskCtx: task.NewContext().WithTask("NAVER", "WatchNewPerformances")}, sendErr))
+
+	// 다시 발송할 시간이 되기 전에는 반환되지 않는다.
+	items, err := dlq.dueItems(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(items))
+
+	now := time.Now().Add(notificationDLQNextRetryDelay(0))
+	items, err = dlq.dueItems(now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(items))
+	assert.Equal(t, NotifierID("telegram-1"), items[0].notifierID)
+	assert.Equal(t, "message", items[0].payload.Message)
+	assert.Equal(t, task.TaskID("NAVER"), items[0].payload.taskContext().Value(task.TaskCtxKeyTaskID))
+
+	// 반환된 알림메시지는 발송 결과를 받기 전까지 다시 반환되지 않는다.
+	items2, err := dlq.dueItems(now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(items2))
+
+	// 최대 횟수만큼 다시 발송이 실패하면 notification_dlq_dead 테이블로 옮겨진다.
+	id := items[0].id
+	for i := 1; i < notificationDLQMaxRetries; i++ {
+		dead, err := dlq.retryFailed(id, sendErr)
+		assert.NoError(t, err)
+		assert.False(t, dead, fmt.Sprintf("재시도:%d", i))
+	}
+	dead, err := dlq.retryFailed(id, sendErr)
+	assert.NoError(t, err)
+	assert.True(t, dead)
+
+	var count int
+	assert.NoError(t, dlq.db.QueryRow(`SELECT COUNT(*) FROM notification_dlq`).Scan(&count))
+	assert.Equal(t, 0, count)
+	assert.NoError(t, dlq.db.QueryRow(`SELECT retry_count FROM notification_dlq_dead WHERE id = ?`, id).Scan(&count))
+	assert.Equal(t, notificationDLQMaxRetries, count)
+}
+
+func TestNotificationDLQ_RecoverAfterRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dlq.db")
+
+	dlq := newTestNotificationDLQ(t, path)
+	assert.NoError(t, dlq.add("telegram-1", &notificationSendData{message: "message"}, errors.New("send failed")))
+
+	// 다시 발송하기 위해 반환되었지만 발송 결과를 받지 못한 상태에서 서버가 재시작된다.
+	items, err := dlq.dueItems(time.Now().Add(notificationDLQNextRetryDelay(0)))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(items))
+	assert.NoError(t, dlq.close())
+
+	dlq = newTestNotificationDLQ(t, path)
+	defer dlq.close()
+
+	// 최대 대기 시간이 지나면 다시 반환된다.
+	items, err = dlq.dueItems(time.Now().Add(notificationDLQNextRetryDelay(0) + notificationDLQMaxRetryDelay))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(items))
+	assert.Equal(t, "message", items[0].payload.Message)
+	assert.Nil(t, items[0].payload.taskContext())
+}
+
+func TestNotificationService_HandleSendResult_DeadLetter(t *testing.T) {
+	dlq := newTestNotificationDLQ(t, filepath.Join(t.TempDir(), "dlq.db"))
+	defer dlq.close()
+
+	defaultNotifier := &testNotifier{notifier: notifier{id: "telegram-1", notificationSendC: make(chan *notificationSendData, 10)}}
+	s := &NotificationService{
+		defaultNotifierHandler: defaultNotifier,
+		notifierHandlers:       []notifierHandler{defaultNotifier},
+		eventBroker:            newNotificationEventBroker(),
+		dlq:                    dlq,
+	}
+
+	// addDeadItem 다음 재시도에서 notification_dlq_dead 테이블로 옮겨지는 알림메시지를 추가한다.
+	addDeadItem := func(notifierID NotifierID) int64 {
+		assert.NoError(t, dlq.add(notifierID, &notificationSendData{message: "message"}, errors.New("send failed")))
+
+		var id int64
+		assert.NoError(t, dlq.db.QueryRow(`SELECT MAX(id) FROM notification_dlq`).Scan(&id))
+		_, err := dlq.db.Exec(`UPDATE notification_dlq SET retry_count = ? WHERE id = ?`, notificationDLQMaxRetries-1, id)
+		assert.NoError(t, err)
+
+		return id
+	}
+
+	// 처음 발송이 실패한 알림메시지는 DLQ에 저장된다.
+	s.handleSendResult(&notificationSendResult{notifierID: "email-1", data: &notificationSendData{message: "message"}, err: errors.New("send failed")})
+	var count int
+	assert.NoError(t, dlq.db.QueryRow(`SELECT COUNT(*) FROM notification_dlq`).Scan(&count))
+	assert.Equal(t, 1, count)
+
+	// 기본 Notifier가 아닌 Notifier의 알림메시지가 최종 실패하면 기본 Notifier로 알린다.
+	id := addDeadItem("email-1")
+	s.handleSendResult(&notificationSendResult{notifierID: "email-1", data: &notificationSendData{message: "message", dlqID: id}, err: errors.New("send failed")})
+	assert.Equal(t, 1, len(defaultNotifier.notificationSendC))
+
+	// 기본 Notifier의 알림메시지가 최종 실패한 경우에는 기본 Notifier로 알리지 않는다.
+	id = addDeadItem("telegram-1")
+	s.handleSendResult(&notificationSendResult{notifierID: "telegram-1", data: &notificationSendData{message: "message", dlqID: id}, err: errors.New("send failed")})
+	assert.Equal(t, 1, len(defaultNotifier.notificationSendC))
+}
@@ -0,0 +1,153 @@
+package notification
+
+import (
+	"github.com/darkkaiser/notify-server/service/task"
+	log "github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+const (
+	// 재연결한 구독자에게 다시 전달할 수 있도록 알림 이벤트를 보관하는 시간
+	notificationEventRetention = 5 * time.Minute
+
+	// 보관할 수 있는 알림 이벤트의 최대 갯수
+	notificationEventBufferSize = 1000
+
+	// 구독자별로 전달되지 않고 대기할 수 있는 알림 이벤트의 최대 갯수
+	notificationEventSubscriberBufferSize = 100
+)
+
+// NotificationEvent 발송된 알림메시지를 구독자에게 전달하기 위한 이벤트
+type NotificationEvent struct {
+	ID            int64
+	NotifierID    NotifierID
+	TaskID        task.TaskID
+	Title         string
+	Message       string
+	ErrorOccurred bool
+	SentAt        time.Time
+}
+
+// notificationEventBroker 발송된 알림메시지를 모든 구독자에게 전달하고, 최근의 알림 이벤트를 링 버퍼에 보관한다.
+// 모든 Notifier의 알림 이벤트를 하나의 링 버퍼에 보관하며, 구독자가 필요한 Notifier의 알림 이벤트만 골라서 사용한다.
+type notificationEventBroker struct {
+	mu sync.Mutex
+
+	lastID int64
+
+	subscribers []chan NotificationEvent
+
+	// 링 버퍼에 보관된 알림 이벤트
+	events     [notificationEventBufferSize]NotificationEvent
+	eventsHead int
+	eventsLen  int
+
+	closed bool
+}
+
+func newNotificationEventBroker() *notificationEventBroker {
+	return &notificationEventBroker{}
+}
+
+func (b *notificationEventBroker) publish(notifierID NotifierID, message string, taskCtx task.TaskContext) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed == true {
+		return
+	}
+
+	b.lastID++
+	e := NotificationEvent{
+		ID:         b.lastID,
+		NotifierID: notifierID,
+		Message:    message,
+		SentAt:     time.Now(),
+	}
+	if taskCtx != nil {
+		e.TaskID, _ = taskCtx.Value(task.TaskCtxKeyTaskID).(task.TaskID)
+		e.Title, _ = taskCtx.Value(task.TaskCtxKeyTitle).(string)
+		e.ErrorOccurred, _ = taskCtx.Value(task.TaskCtxKeyErrorOccurred).(bool)
+	}
+
+	// 링 버퍼가 가득 찬 경우 가장 오래된 알림 이벤트를 덮어쓴다.
+	b.removeExpiredEvents(e.SentAt)
+	if b.eventsLen == notificationEventBufferSize {
+		b.eventsHead = (b.eventsHead + 1) % notificationEventBufferSize
+		b.eventsLen--
+	}
+	b.events[(b.eventsHead+b.eventsLen)%notificationEventBufferSize] = e
+	b.eventsLen++
+
+	for _, c := range b.subscribers {
+		// 이벤트를 읽어가지 않는 구독자로 인하여 알림메시지 발송이 지연되지 않도록 한다.
+		select {
+		case c <- e:
+		default:
+			log.Warnf("알림 이벤트를 읽어가지 않는 구독자가 있어 이벤트를 전달하지 않습니다.(EventID:%d)", e.ID)
+		}
+	}
+}
+
+// removeExpiredEvents 보관 시간이 지난 알림 이벤트를 링 버퍼에서 삭제한다.
+// 호출하는 곳에서 mu를 잠근 상태이어야 한다.
+func (b *notificationEventBroker) removeExpiredEvents(now time.Time) {
+	for b.eventsLen > 0 && now.Sub(b.events[b.eventsHead].SentAt) >= notificationEventRetention {
+		b.events[b.eventsHead] = NotificationEvent{}
+		b.eventsHead = (b.eventsHead + 1) % notificationEventBufferSize
+		b.eventsLen--
+	}
+}
+
+// subscribe 알림 이벤트의 구독을 시작한다.
+// lastEventID 이후에 발생되어 링 버퍼에 보관중인 알림 이벤트와 이후에 발생되는 알림 이벤트를 수신할 채널을 반환한다.
+// 구독을 마치면 반드시 반환된 unsubscribe 함수를 호출하여야 한다.
+func (b *notificationEventBroker) subscribe(lastEventID int64) (missedEvents []NotificationEvent, eventC <-chan NotificationEvent, unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := make(chan NotificationEvent, notificationEventSubscriberBufferSize)
+
+	// 서비스가 중지된 경우 닫힌 채널을 반환하여 구독이 바로 종료되도록 한다.
+	if b.closed == true {
+		close(c)
+		return nil, c, func() {}
+	}
+
+	if lastEventID > 0 {
+		b.removeExpiredEvents(time.Now())
+		for i := 0; i < b.eventsLen; i++ {
+			if e := b.events[(b.eventsHead+i)%notificationEventBufferSize]; e.ID > lastEventID {
+				missedEvents = append(missedEvents, e)
+			}
+		}
+	}
+
+	b.subscribers = append(b.subscribers, c)
+
+	return missedEvents, c, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		for i, subscriber := range b.subscribers {
+			if subscriber == c {
+				b.subscribers = append(b.subscribers[:i], b.subscribers[i+1:]...)
+				close(c)
+				break
+			}
+		}
+	}
+}
+
+// close 모든 구독자의 채널을 닫아 구독을 종료시킨다.
+func (b *notificationEventBroker) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, c := range b.subscribers {
+		close(c)
+	}
+	b.subscribers = nil
+	b.closed = true
+}
@@ -0,0 +1,130 @@
+package notification
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/darkkaiser/notify-server/g"
+	_log_ "github.com/darkkaiser/notify-server/log"
+	"github.com/darkkaiser/notify-server/service/task"
+	_ "modernc.org/sqlite"
+	"time"
+)
+
+// 알림메시지 발송 이력에 저장되는 메시지의 최대 글자수
+const notificationHistoryMessagePreviewMaxLength = 200
+
+var ErrNotificationHistoryNotSupported = errors.New("알림메시지 발송 이력은 sqlite 저장소에서만 지원됩니다")
+
+// NotificationHistory 알림메시지 발송 이력
+type NotificationHistory struct {
+	ID             int64
+	NotifierID     NotifierID
+	TaskID         task.TaskID
+	MessagePreview string
+	SentAt         time.Time
+	ErrorOccurred  bool
+}
+
+func newNotificationHistory(notifierID NotifierID, message string, taskCtx task.TaskContext) *NotificationHistory {
+	history := &NotificationHistory{
+		NotifierID:     notifierID,
+		MessagePreview: message,
+		SentAt:         time.Now(),
+	}
+
+	if r := []rune(message); len(r) > notificationHistoryMessagePreviewMaxLength {
+		history.MessagePreview = string(r[:notificationHistoryMessagePreviewMaxLength])
+	}
+
+	if taskCtx != nil {
+		history.TaskID, _ = taskCtx.Value(task.TaskCtxKeyTaskID).(task.TaskID)
+		history.ErrorOccurred, _ = taskCtx.Value(task.TaskCtxKeyErrorOccurred).(bool)
+	}
+
+	return history
+}
+
+// notificationHistoryStore 알림메시지 발송 이력을 작업결과데이터와 같은 SQLite 데이터베이스의 notification_history 테이블에 저장한다.
+type notificationHistoryStore struct {
+	db *sql.DB
+}
+
+// newNotificationHistoryStore 작업결과데이터 저장소가 sqlite인 경우에만 알림메시지 발송 이력 저장소를 생성한다.
+func newNotificationHistoryStore(config *g.AppConfig) (*notificationHistoryStore, error) {
+	if config.Storage.Type != task.TaskResultStoreTypeSQLite {
+		return nil, nil
+	}
+
+	path := config.Storage.SQLite.Path
+	if path == "" {
+		path = fmt.Sprintf("%s.db", g.AppName)
+	}
+
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)", path))
+	if err != nil {
+		return nil, fmt.Errorf("알림메시지 발송 이력 데이터베이스(%s)를 열 수 없습니다.(error:%s)", path, err)
+	}
+
+	if _, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS notification_history (
+			id              INTEGER PRIMARY KEY AUTOINCREMENT,
+			notifier_id     TEXT NOT NULL,
+			task_id         TEXT NOT NULL,
+			message_preview TEXT NOT NULL,
+			sent_at         DATETIME NOT NULL,
+			error_occurred  BOOLEAN NOT NULL
+		)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("알림메시지 발송 이력 데이터베이스(%s)의 테이블 생성이 실패하였습니다.(error:%s)", path, err)
+	}
+
+	return &notificationHistoryStore{db: db}, nil
+}
+
+func (s *notificationHistoryStore) save(history *NotificationHistory) error {
+	_, err := s.db.Exec(`
+		INSERT INTO notification_history (notifier_id, task_id, message_preview, sent_at, error_occurred) VALUES (?, ?, ?, ?, ?)`,
+		string(history.NotifierID), string(history.TaskID), history.MessagePreview, history.SentAt, history.ErrorOccurred)
+
+	return err
+}
+
+// noinspection GoUnhandledErrorResult
+func (s *notificationHistoryStore) histories(limit, offset int) ([]*NotificationHistory, error) {
+	rows, err := s.db.Query(`
+		SELECT id, notifier_id, task_id, message_preview, sent_at, error_occurred
+		FROM notification_history ORDER BY id DESC LIMIT ? OFFSET ?`, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	histories := make([]*NotificationHistory, 0, limit)
+	for rows.Next() {
+		var history NotificationHistory
+		if err = rows.Scan(&history.ID, &history.NotifierID, &history.TaskID, &history.MessagePreview, &history.SentAt, &history.ErrorOccurred); err != nil {
+			return nil, err
+		}
+
+		histories = append(histories, &history)
+	}
+
+	return histories, rows.Err()
+}
+
+func (s *notificationHistoryStore) close() error {
+	return s.db.Close()
+}
+
+// saveHistories 알림메시지 발송이 지연되지 않도록 발송 이력은 별도의 고루틴에서 저장한다.
+// historyC가 닫히면 데이터베이스를 닫고 반환한다.
+func (s *notificationHistoryStore) saveHistories(historyC <-chan *NotificationHistory) {
+	defer s.close()
+
+	for history := range historyC {
+		if err := s.save(history); err != nil {
+			_log_.WithNotifierID(string(history.NotifierID)).WithError(err).Warn("알림메시지 발송 이력의 저장이 실패하였습니다.")
+		}
+	}
+}
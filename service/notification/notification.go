@@ -2,15 +2,30 @@ package notification
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/darkkaiser/notify-server/g"
+	_log_ "github.com/darkkaiser/notify-server/log"
+	"github.com/darkkaiser/notify-server/metrics"
 	"github.com/darkkaiser/notify-server/service/task"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+	"strconv"
 	"sync"
+	"time"
+	"unicode/utf8"
 )
 
 type NotifierID string
 
+// Health()에서 기본 Notifier의 상태 확인 제한 시간
+const healthPingTimeout = 5 * time.Second
+
+const (
+	// 여러 개의 알림메시지를 하나로 합칠 때 알림메시지 사이에 넣는 구분자
+	notificationBatchSeparator = "\n\n"
+)
+
 //
 // notifier
 //
@@ -19,7 +34,19 @@ type notifier struct {
 
 	supportHTMLMessage bool
 
+	// 여러 개의 알림메시지를 하나로 합쳐서 발송할 때 합쳐진 알림메시지의 최대 글자수(0인 경우 합쳐서 발송하지 않는다)
+	batchMaxLength int
+
+	// 연속된 알림메시지 발송 사이의 최소 시간(0인 경우 발송 횟수를 제한하지 않는다)
+	rateLimit time.Duration
+
+	// rateLimit에 따라 알림메시지의 발송을 제한하는 Limiter(nil인 경우 발송 횟수를 제한하지 않는다)
+	rateLimiter *rate.Limiter
+
 	notificationSendC chan *notificationSendData
+
+	// 알림메시지의 발송 결과를 전달받을 채널(nil인 경우 발송 결과를 전달하지 않는다)
+	sendResultC chan<- *notificationSendResult
 }
 
 type notifierHandler interface {
@@ -30,6 +57,26 @@ type notifierHandler interface {
 	Run(taskRunner task.TaskRunner, notificationStopCtx context.Context, notificationStopWaiter *sync.WaitGroup)
 
 	SupportHTMLMessage() bool
+
+	// SupportsBatching 여러 개의 알림메시지를 하나로 합쳐서 발송할 수 있는지 확인한다.
+	SupportsBatching() bool
+
+	// BatchMaxLength 여러 개의 알림메시지를 하나로 합쳐서 발송할 때 합쳐진 알림메시지의 최대 글자수를 반환한다.
+	BatchMaxLength() int
+
+	// RateLimit 연속된 알림메시지 발송 사이의 최소 시간을 반환한다.
+	// 발송 횟수의 제한이 없는 경우 0을 반환한다.
+	RateLimit() time.Duration
+
+	// Ping 알림메시지를 발송할 수 있는 상태인지 외부 서비스에 확인한다.
+	Ping(ctx context.Context) error
+
+	// resend DLQ에 저장된 알림메시지를 다시 발송한다.
+	resend(data *notificationSendData) (succeeded bool)
+
+	setSendResultC(sendResultC chan<- *notificationSendResult)
+
+	setRateLimiter(rateLimiter *rate.Limiter)
 }
 
 func (n *notifier) ID() NotifierID {
@@ -50,6 +97,12 @@ func (n *notifier) Notify(message string, taskCtx task.TaskContext) (succeeded b
 		taskCtx: taskCtx,
 	}
 
+	errorOccurred := false
+	if taskCtx != nil {
+		errorOccurred, _ = taskCtx.Value(task.TaskCtxKeyErrorOccurred).(bool)
+	}
+	metrics.NotificationsSentTotal.WithLabelValues(string(n.ID()), strconv.FormatBool(errorOccurred)).Inc()
+
 	return true
 }
 
@@ -57,12 +110,76 @@ func (n *notifier) SupportHTMLMessage() bool {
 	return n.supportHTMLMessage
 }
 
+func (n *notifier) SupportsBatching() bool {
+	return n.batchMaxLength > 0
+}
+
+func (n *notifier) BatchMaxLength() int {
+	return n.batchMaxLength
+}
+
+func (n *notifier) RateLimit() time.Duration {
+	return n.rateLimit
+}
+
+func (n *notifier) resend(data *notificationSendData) (succeeded bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			succeeded = false
+
+			log.Errorf("알림메시지 재발송중에 panic이 발생하였습니다.(NotifierID:%s, Message:%s, panic:%s", n.ID(), data.message, r)
+		}
+	}()
+
+	n.notificationSendC <- data
+
+	return true
+}
+
+func (n *notifier) setSendResultC(sendResultC chan<- *notificationSendResult) {
+	n.sendResultC = sendResultC
+}
+
+func (n *notifier) setRateLimiter(rateLimiter *rate.Limiter) {
+	n.rateLimiter = rateLimiter
+}
+
+// waitRateLimit 알림메시지를 발송할 수 있을 때까지 대기한다.
+// 대기하는 도중에 ctx가 취소된 경우 에러를 반환한다.
+func (n *notifier) waitRateLimit(ctx context.Context) error {
+	if n.rateLimiter == nil {
+		return nil
+	}
+
+	return n.rateLimiter.Wait(ctx)
+}
+
+// reportSendResult 알림메시지의 발송 결과를 전달한다.
+// 발송 결과를 처리하지 못하고 밀려있는 경우 알림메시지 발송이 지연되지 않도록 발송 결과를 전달하지 않는다.
+func (n *notifier) reportSendResult(data *notificationSendData, err error) {
+	if n.sendResultC == nil {
+		return
+	}
+
+	select {
+	case n.sendResultC <- &notificationSendResult{notifierID: n.ID(), data: data, err: err}:
+	default:
+		_log_.WithNotifierID(string(n.ID())).Warn("알림메시지 발송 결과의 처리가 지연되어 발송 결과를 전달하지 않습니다.")
+	}
+}
+
 //
 // notificationSendData
 //
 type notificationSendData struct {
 	message string
 	taskCtx task.TaskContext
+
+	// DLQ에서 다시 발송하는 알림메시지인 경우 DLQ 항목의 ID
+	dlqID int64
+
+	// 대체 Notifier로 발송하는 알림메시지인 경우 이미 발송이 실패한 Notifier 목록(처음 발송한 Notifier부터 순서대로)
+	failedNotifierIDs []NotifierID
 }
 
 //
@@ -72,6 +189,29 @@ type NotificationSender interface {
 	Notify(notifierID string, title string, message string, errorOccurred bool) bool
 	NotifyToDefault(message string) bool
 	NotifyWithErrorToDefault(message string) bool
+
+	// NotifyBatch 여러 개의 알림메시지를 발송한다.
+	// 여러 개의 알림메시지를 합쳐서 발송할 수 있는 Notifier인 경우 같은 Notifier로 발송되는 알림메시지를 구분자로 연결하여 한 번에 발송한다.
+	NotifyBatch(notifications []task.Notification) error
+
+	// Health Notification 서비스가 정상적으로 동작중인지 확인한다.
+	Health() error
+	// TelegramHealth 등록된 모든 Telegram Notifier가 정상적으로 동작중인지 확인한다.
+	TelegramHealth() error
+
+	// NotificationHistories 최근 발송된 순서대로 offset 이후의 최대 limit개의 알림메시지 발송 이력을 반환한다.
+	NotificationHistories(limit, offset int) ([]*NotificationHistory, error)
+
+	// SubscribeEvents 발송된 알림메시지의 이벤트 구독을 시작한다.
+	// lastEventID 이후에 발생되어 보관중인 이벤트와 이후에 발생되는 이벤트를 수신할 채널을 반환하며, 구독을 마치면 반드시 unsubscribe 함수를 호출하여야 한다.
+	SubscribeEvents(lastEventID int64) (missedEvents []NotificationEvent, eventC <-chan NotificationEvent, unsubscribe func())
+
+	// ListNotifiers 등록된 모든 Notifier의 정보와 상태를 반환한다.
+	ListNotifiers() []*NotifierInfo
+
+	// BroadcastToAll 등록된 모든 Notifier로 알림메시지를 발송한다.
+	// 일부 Notifier에서 발송이 실패하더라도 나머지 Notifier로 계속 발송하며, 실패한 Notifier의 목록을 BroadcastError로 반환한다.
+	BroadcastToAll(message string, errorOccurred bool) error
 }
 
 //
@@ -86,12 +226,43 @@ type NotificationService struct {
 	defaultNotifierHandler notifierHandler
 	notifierHandlers       []notifierHandler
 
+	// 알림메시지 발송이 실패하였거나 Notifier를 찾을 수 없을 때 순서대로 발송을 시도할 대체 Notifier 목록
+	fallbackNotifierHandlers []notifierHandler
+
+	// Notifier별 HTML 메시지 지원 여부(NotifierID → bool)
+	// 작업이 실행될 때마다 조회되므로 runningMu를 잠그지 않고 읽을 수 있도록 Notifier가 등록될 때 저장해둔다.
+	supportHTMLMessageCache sync.Map
+
 	taskRunner task.TaskRunner
 
+	historyStore *notificationHistoryStore
+	historyC     chan *NotificationHistory
+
+	dlq         *notificationDLQ
+	sendResultC chan *notificationSendResult
+
+	eventBroker *notificationEventBroker
+
 	notificationStopWaiter *sync.WaitGroup
 }
 
 func NewService(config *g.AppConfig, taskRunner task.TaskRunner) *NotificationService {
+	historyStore, err := newNotificationHistoryStore(config)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	dlq, err := newNotificationDLQ(config)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	// 발송이 실패한 알림메시지를 DLQ에 저장하거나 대체 Notifier로 발송할 수 있도록 발송 결과를 전달받는다.
+	var sendResultC chan *notificationSendResult
+	if dlq != nil || len(config.Notifiers.FallbackChain) > 0 {
+		sendResultC = make(chan *notificationSendResult, 100)
+	}
+
 	return &NotificationService{
 		config: config,
 
@@ -102,6 +273,14 @@ func NewService(config *g.AppConfig, taskRunner task.TaskRunner) *NotificationSe
 
 		taskRunner: taskRunner,
 
+		historyStore: historyStore,
+		historyC:     make(chan *NotificationHistory, 100),
+
+		dlq:         dlq,
+		sendResultC: sendResultC,
+
+		eventBroker: newNotificationEventBroker(),
+
 		notificationStopWaiter: &sync.WaitGroup{},
 	}
 }
@@ -126,7 +305,9 @@ func (s *NotificationService) Run(serviceStopCtx context.Context, serviceStopWai
 
 	// Telegram Notifier의 작업을 시작한다.
 	for _, telegram := range s.config.Notifiers.Telegrams {
-		h := newTelegramNotifier(NotifierID(telegram.ID), telegram.BotToken, telegram.ChatID, s.config)
+		h := s.withDeduplication(newTelegramNotifier(NotifierID(telegram.ID), telegram.BotToken, telegram.ChatID, s.config))
+		h.setSendResultC(s.sendResultC)
+		h.setRateLimiter(newNotifierRateLimiter(h.RateLimit()))
 		s.notifierHandlers = append(s.notifierHandlers, h)
 
 		s.notificationStopWaiter.Add(1)
@@ -135,6 +316,32 @@ func (s *NotificationService) Run(serviceStopCtx context.Context, serviceStopWai
 		log.Debugf("'%s' Telegram Notifier가 Notification 서비스에 등록되었습니다.", telegram.ID)
 	}
 
+	// Discord Notifier의 작업을 시작한다.
+	for _, discord := range s.config.Notifiers.Discords {
+		h := s.withDeduplication(newDiscordNotifier(NotifierID(discord.ID), discord.WebhookURL, s.config))
+		h.setSendResultC(s.sendResultC)
+		h.setRateLimiter(newNotifierRateLimiter(h.RateLimit()))
+		s.notifierHandlers = append(s.notifierHandlers, h)
+
+		s.notificationStopWaiter.Add(1)
+		go h.Run(s.taskRunner, serviceStopCtx, s.notificationStopWaiter)
+
+		log.Debugf("'%s' Discord Notifier가 Notification 서비스에 등록되었습니다.", discord.ID)
+	}
+
+	// Email Notifier의 작업을 시작한다.
+	for _, email := range s.config.Notifiers.Emails {
+		h := s.withDeduplication(newEmailNotifier(NotifierID(email.ID), email.Host, email.Port, email.Username, email.Password, email.From, email.To, email.SubjectPrefix, s.config))
+		h.setSendResultC(s.sendResultC)
+		h.setRateLimiter(newNotifierRateLimiter(h.RateLimit()))
+		s.notifierHandlers = append(s.notifierHandlers, h)
+
+		s.notificationStopWaiter.Add(1)
+		go h.Run(s.taskRunner, serviceStopCtx, s.notificationStopWaiter)
+
+		log.Debugf("'%s' Email Notifier가 Notification 서비스에 등록되었습니다.", email.ID)
+	}
+
 	// 기본 Notifier를 구한다.
 	for _, h := range s.notifierHandlers {
 		if h.ID() == NotifierID(s.config.Notifiers.DefaultNotifierID) {
@@ -146,6 +353,28 @@ func (s *NotificationService) Run(serviceStopCtx context.Context, serviceStopWai
 		log.Panicf("기본 NotifierID('%s')를 찾을 수 없습니다.", s.config.Notifiers.DefaultNotifierID)
 	}
 
+	// 대체 Notifier 목록을 구한다.
+	for _, id := range s.config.Notifiers.FallbackChain {
+		for _, h := range s.notifierHandlers {
+			if h.ID() == NotifierID(id) {
+				s.fallbackNotifierHandlers = append(s.fallbackNotifierHandlers, h)
+				break
+			}
+		}
+	}
+
+	s.refreshSupportHTMLMessageCache()
+
+	// 알림메시지 발송 이력의 저장을 시작한다.
+	if s.historyStore != nil {
+		go s.historyStore.saveHistories(s.historyC)
+	}
+
+	// 발송이 실패한 알림메시지의 처리를 시작한다.
+	if s.sendResultC != nil {
+		go s.runSendResultHandler(serviceStopCtx)
+	}
+
 	go s.run0(serviceStopCtx, serviceStopWaiter)
 
 	s.running = true
@@ -153,6 +382,23 @@ func (s *NotificationService) Run(serviceStopCtx context.Context, serviceStopWai
 	log.Debug("Notification 서비스 시작됨")
 }
 
+// newNotifierRateLimiter 연속된 알림메시지 발송 사이의 최소 시간이 rateLimit이 되도록 발송을 제한하는 Limiter를 생성한다.
+// rateLimit이 0 이하인 경우 발송 횟수를 제한하지 않으므로 nil을 반환한다.
+func newNotifierRateLimiter(rateLimit time.Duration) *rate.Limiter {
+	if rateLimit <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Every(rateLimit), 1)
+}
+
+// withDeduplication 알림메시지 중복 발송 방지 시간이 설정된 경우, 동일한 알림메시지가 반복해서 발송되지 않도록 Notifier를 감싼다.
+func (s *NotificationService) withDeduplication(h notifierHandler) notifierHandler {
+	if s.config.Notifiers.Deduplication.TTLSeconds > 0 {
+		return newDeduplicator(h, time.Duration(s.config.Notifiers.Deduplication.TTLSeconds)*time.Second)
+	}
+	return h
+}
+
 func (s *NotificationService) run0(serviceStopCtx context.Context, serviceStopWaiter *sync.WaitGroup) {
 	defer serviceStopWaiter.Done()
 
@@ -166,8 +412,15 @@ func (s *NotificationService) run0(serviceStopCtx context.Context, serviceStopWa
 		s.runningMu.Lock()
 		s.running = false
 		s.taskRunner = nil
+		if s.historyC != nil {
+			close(s.historyC)
+			s.historyC = nil
+		}
+		s.eventBroker.close()
 		s.notifierHandlers = nil
 		s.defaultNotifierHandler = nil
+		s.fallbackNotifierHandlers = nil
+		s.refreshSupportHTMLMessageCache()
 		s.runningMu.Unlock()
 
 		log.Debug("Notification 서비스 중지됨")
@@ -186,13 +439,13 @@ func (s *NotificationService) Notify(notifierID string, title string, message st
 func (s *NotificationService) NotifyToDefault(message string) bool {
 	s.runningMu.Lock()
 	defer s.runningMu.Unlock()
-	return s.defaultNotifierHandler.Notify(message, nil)
+	return s.notify(s.defaultNotifierHandler, message, nil)
 }
 
 func (s *NotificationService) NotifyWithErrorToDefault(message string) bool {
 	s.runningMu.Lock()
 	defer s.runningMu.Unlock()
-	return s.defaultNotifierHandler.Notify(message, task.NewContext().WithError())
+	return s.notify(s.defaultNotifierHandler, message, task.NewContext().WithError())
 }
 
 func (s *NotificationService) NotifyWithTaskContext(notifierID string, message string, taskCtx task.TaskContext) bool {
@@ -202,7 +455,10 @@ func (s *NotificationService) NotifyWithTaskContext(notifierID string, message s
 	id := NotifierID(notifierID)
 	for _, h := range s.notifierHandlers {
 		if h.ID() == id {
-			return h.Notify(message, taskCtx)
+			if s.notify(h, message, taskCtx) == true {
+				return true
+			}
+			return s.notifyWithFallback(id, message, taskCtx)
 		}
 	}
 
@@ -210,21 +466,236 @@ func (s *NotificationService) NotifyWithTaskContext(notifierID string, message s
 
 	log.Error(m)
 
-	s.defaultNotifierHandler.Notify(m, task.NewContext().WithError())
+	// 대체 Notifier가 설정된 경우 알림메시지를 대체 Notifier로 발송한다.
+	if s.notifyWithFallback(id, message, taskCtx) == true {
+		return true
+	}
+
+	s.notify(s.defaultNotifierHandler, m, task.NewContext().WithError())
+
+	return false
+}
+
+func (s *NotificationService) NotifyBatch(notifications []task.Notification) error {
+	failedCount := 0
+	for _, n := range s.batchNotifications(notifications) {
+		if s.NotifyWithTaskContext(n.NotifierID, n.Message, n.TaskCtx) == false {
+			failedCount++
+		}
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("%d개의 알림메시지 발송이 실패하였습니다", failedCount)
+	}
+
+	return nil
+}
+
+// batchNotifications 여러 개의 알림메시지를 합쳐서 발송할 수 있는 Notifier로 발송되는 알림메시지를 Notifier의 최대 글자수를 넘지 않도록 합친다.
+// 같은 Notifier로 발송되고, 같은 TaskContext와 최대 글자수(MaxLength)를 가진 알림메시지만 합치며 알림메시지의 순서는 유지된다.
+func (s *NotificationService) batchNotifications(notifications []task.Notification) []task.Notification {
+	s.runningMu.Lock()
+	batchMaxLengths := make(map[string]int, len(s.notifierHandlers))
+	for _, h := range s.notifierHandlers {
+		if h.SupportsBatching() == true {
+			batchMaxLengths[string(h.ID())] = h.BatchMaxLength()
+		}
+	}
+	s.runningMu.Unlock()
+
+	batches := make([]task.Notification, 0, len(notifications))
+
+	// Notifier별로 마지막으로 합쳐진 알림메시지의 위치
+	lastBatchIndexes := make(map[string]int)
+	for _, n := range notifications {
+		if batchMaxLength, exists := batchMaxLengths[n.NotifierID]; exists == true {
+			if n.MaxLength > 0 && n.MaxLength < batchMaxLength {
+				batchMaxLength = n.MaxLength
+			}
+
+			if i, exists := lastBatchIndexes[n.NotifierID]; exists == true && batches[i].TaskCtx == n.TaskCtx && batches[i].MaxLength == n.MaxLength {
+				if utf8.RuneCountInString(batches[i].Message)+utf8.RuneCountInString(notificationBatchSeparator)+utf8.RuneCountInString(n.Message) <= batchMaxLength {
+					batches[i].Message += notificationBatchSeparator + n.Message
+					continue
+				}
+			}
+			lastBatchIndexes[n.NotifierID] = len(batches)
+		}
+
+		batches = append(batches, n)
+	}
+
+	return batches
+}
+
+// resendWithFallback 발송이 실패한 알림메시지를 아직 발송을 시도하지 않은 첫 번째 대체 Notifier로 발송한다.
+// 대체 Notifier에서도 발송이 실패하면 handleSendResult()에서 다시 호출되어 대체 Notifier 목록의 다음 Notifier로 발송을 시도한다.
+func (s *NotificationService) resendWithFallback(result *notificationSendResult) bool {
+	failedNotifierIDs := append(append([]NotifierID{}, result.data.failedNotifierIDs...), result.notifierID)
+
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	for _, h := range s.fallbackNotifierHandlers {
+		if containsNotifierID(failedNotifierIDs, h.ID()) == true {
+			continue
+		}
+
+		data := &notificationSendData{
+			message:           result.data.message,
+			taskCtx:           result.data.taskCtx,
+			failedNotifierIDs: failedNotifierIDs,
+		}
+		if h.resend(data) == true {
+			_log_.WithNotifierID(string(h.ID())).Warnf("'%s' Notifier의 알림메시지 발송이 실패하여 대체 Notifier로 발송합니다.", result.notifierID)
+			return true
+		}
+
+		failedNotifierIDs = append(failedNotifierIDs, h.ID())
+	}
+
+	return false
+}
+
+func containsNotifierID(ids []NotifierID, id NotifierID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyWithFallback 대체 Notifier 목록의 순서대로 알림메시지 발송을 시도하고, 처음으로 발송에 성공하면 멈춘다.
+// 발송이 실패한 Notifier(failedID)는 대체 Notifier 목록에 포함되어 있더라도 다시 시도하지 않는다.
+// 호출하는 곳에서 runningMu를 잠근 상태이어야 한다.
+func (s *NotificationService) notifyWithFallback(failedID NotifierID, message string, taskCtx task.TaskContext) bool {
+	for _, h := range s.fallbackNotifierHandlers {
+		if h.ID() == failedID {
+			continue
+		}
+
+		if s.notify(h, message, taskCtx) == true {
+			_log_.WithNotifierID(string(h.ID())).Warnf("'%s' Notifier로 알림메시지를 발송할 수 없어 대체 Notifier로 발송하였습니다.", failedID)
+			return true
+		}
+	}
 
 	return false
 }
 
+// notify 알림메시지를 발송하고, 발송된 알림메시지의 이력을 저장한 후 구독자에게 이벤트를 전달한다.
+// 호출하는 곳에서 runningMu를 잠근 상태이어야 한다.
+func (s *NotificationService) notify(h notifierHandler, message string, taskCtx task.TaskContext) bool {
+	if h.Notify(message, taskCtx) == false {
+		return false
+	}
+
+	if s.historyStore != nil && s.historyC != nil {
+		// 저장이 밀려있는 경우 알림메시지 발송이 지연되지 않도록 이력을 저장하지 않는다.
+		select {
+		case s.historyC <- newNotificationHistory(h.ID(), message, taskCtx):
+		default:
+			_log_.WithNotifierID(string(h.ID())).Warn("알림메시지 발송 이력의 저장이 지연되어 이력을 저장하지 않습니다.")
+		}
+	}
+
+	s.eventBroker.publish(h.ID(), message, taskCtx)
+
+	return true
+}
+
+func (s *NotificationService) BroadcastToAll(message string, errorOccurred bool) error {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	if s.running == false {
+		return errors.New("Notification 서비스가 실행중이 아닙니다")
+	}
+
+	var broadcastErr *BroadcastError
+	for _, h := range s.notifierHandlers {
+		taskCtx := task.NewContext()
+		if errorOccurred == true {
+			taskCtx.WithError()
+		}
+
+		if s.notify(h, message, taskCtx) == false {
+			if broadcastErr == nil {
+				broadcastErr = &BroadcastError{}
+			}
+			broadcastErr.Errors = append(broadcastErr.Errors, fmt.Errorf("'%s' Notifier의 알림메시지 발송이 실패하였습니다", h.ID()))
+		}
+	}
+
+	if broadcastErr != nil {
+		return broadcastErr
+	}
+
+	return nil
+}
+
+func (s *NotificationService) NotificationHistories(limit, offset int) ([]*NotificationHistory, error) {
+	if s.historyStore == nil {
+		return nil, ErrNotificationHistoryNotSupported
+	}
+
+	return s.historyStore.histories(limit, offset)
+}
+
+func (s *NotificationService) SubscribeEvents(lastEventID int64) ([]NotificationEvent, <-chan NotificationEvent, func()) {
+	return s.eventBroker.subscribe(lastEventID)
+}
+
 func (s *NotificationService) SupportHTMLMessage(notifierID string) bool {
+	supportHTMLMessage, _ := s.supportHTMLMessageCache.Load(NotifierID(notifierID))
+	return supportHTMLMessage == true
+}
+
+// refreshSupportHTMLMessageCache 등록된 Notifier의 HTML 메시지 지원 여부를 다시 저장한다.
+// Notifier가 등록되거나 삭제된 경우 호출되어야 하며, 호출하는 곳에서 runningMu를 잠근 상태이어야 한다.
+func (s *NotificationService) refreshSupportHTMLMessageCache() {
+	s.supportHTMLMessageCache.Range(func(key, _ interface{}) bool {
+		s.supportHTMLMessageCache.Delete(key)
+		return true
+	})
+
+	for _, h := range s.notifierHandlers {
+		s.supportHTMLMessageCache.Store(h.ID(), h.SupportHTMLMessage())
+	}
+}
+
+// Health 기본 Notifier에 Ping()을 호출하여 알림메시지를 발송할 수 있는 상태인지 확인한다.
+// Ping()은 외부 서비스에 요청을 보내므로 runningMu를 잠그지 않은 상태에서 호출한다.
+func (s *NotificationService) Health() error {
+	s.runningMu.Lock()
+	defaultNotifierHandler := s.defaultNotifierHandler
+	s.runningMu.Unlock()
+
+	if defaultNotifierHandler == nil {
+		return errors.New("기본 Notifier가 등록되지 않았습니다")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthPingTimeout)
+	defer cancel()
+
+	return defaultNotifierHandler.Ping(ctx)
+}
+
+func (s *NotificationService) TelegramHealth() error {
 	s.runningMu.Lock()
 	defer s.runningMu.Unlock()
 
-	id := NotifierID(notifierID)
 	for _, h := range s.notifierHandlers {
-		if h.ID() == id {
-			return h.SupportHTMLMessage()
+		if d, ok := h.(*Deduplicator); ok == true {
+			h = d.notifierHandler
+		}
+		if telegram, ok := h.(*telegramNotifier); ok == true {
+			if err := telegram.Health(); err != nil {
+				return err
+			}
 		}
 	}
 
-	return false
+	return nil
 }
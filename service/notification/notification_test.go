@@ -0,0 +1,233 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"github.com/darkkaiser/notify-server/g"
+	"github.com/darkkaiser/notify-server/service/task"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testNotifier struct {
+	notifier
+
+	// Ping()이 반환할 에러(기본값은 nil)
+	PingError error
+}
+
+func (n *testNotifier) Run(taskRunner task.TaskRunner, notificationStopCtx context.Context, notificationStopWaiter *sync.WaitGroup) {
+}
+
+func (n *testNotifier) Ping(ctx context.Context) error {
+	return n.PingError
+}
+
+func TestNotificationService_Health(t *testing.T) {
+	s := &NotificationService{}
+
+	// 기본 Notifier가 등록되지 않은 경우
+	assert.Error(t, s.Health())
+
+	n := &testNotifier{notifier: notifier{id: "default"}}
+	s.defaultNotifierHandler = n
+	assert.NoError(t, s.Health())
+
+	// 기본 Notifier의 Ping()이 실패한 경우 에러를 그대로 반환한다.
+	n.PingError = errors.New("ping failed")
+	assert.Equal(t, n.PingError, s.Health())
+}
+
+func TestNotificationService_SupportHTMLMessage(t *testing.T) {
+	s := &NotificationService{
+		notifierHandlers: []notifierHandler{
+			&testNotifier{notifier: notifier{id: "html", supportHTMLMessage: true}},
+			&testNotifier{notifier: notifier{id: "text", supportHTMLMessage: false}},
+		},
+	}
+
+	// Notifier가 등록되기 전에는 HTML 메시지를 지원하지 않는다.
+	assert.False(t, s.SupportHTMLMessage("html"))
+
+	s.refreshSupportHTMLMessageCache()
+
+	supportHTMLMessage, ok := s.supportHTMLMessageCache.Load(NotifierID("html"))
+	assert.True(t, ok)
+	assert.Equal(t, true, supportHTMLMessage)
+	supportHTMLMessage, ok = s.supportHTMLMessageCache.Load(NotifierID("text"))
+	assert.True(t, ok)
+	assert.Equal(t, false, supportHTMLMessage)
+
+	assert.True(t, s.SupportHTMLMessage("html"))
+	assert.False(t, s.SupportHTMLMessage("text"))
+	assert.False(t, s.SupportHTMLMessage("unknown"))
+
+	// Notifier가 삭제되면 저장된 HTML 메시지 지원 여부도 삭제된다.
+	s.notifierHandlers = s.notifierHandlers[1:]
+	s.refreshSupportHTMLMessageCache()
+
+	assert.False(t, s.SupportHTMLMessage("html"))
+	_, ok = s.supportHTMLMessageCache.Load(NotifierID("html"))
+	assert.False(t, ok)
+}
+
+func TestNotificationService_NotifyWithFallback(t *testing.T) {
+	// Discord Webhook 서버, 요청 본문을 receivedC로 전달하고 statusCode로 응답한다.
+	newWebhookServer := func(statusCode int) (*httptest.Server, chan string) {
+		receivedC := make(chan string, 10)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			receivedC <- string(body)
+			w.WriteHeader(statusCode)
+		}))
+		t.Cleanup(server.Close)
+
+		return server, receivedC
+	}
+
+	// receive 알림메시지가 수신될 때까지 대기한다.
+	receive := func(receivedC chan string) string {
+		select {
+		case body := <-receivedC:
+			return body
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "알림메시지가 수신되지 않았습니다.")
+			return ""
+		}
+	}
+
+	failedServer, failedReceivedC := newWebhookServer(http.StatusInternalServerError)
+	okServer, okReceivedC := newWebhookServer(http.StatusNoContent)
+
+	config := &g.AppConfig{}
+	failed := newDiscordNotifier("discord-1", failedServer.URL, config)
+	ok := newDiscordNotifier("discord-2", okServer.URL, config)
+	email := &testNotifier{notifier: notifier{id: "email-1", notificationSendC: make(chan *notificationSendData, 10)}}
+
+	sendResultC := make(chan *notificationSendResult, 10)
+	s := &NotificationService{
+		defaultNotifierHandler:   failed,
+		notifierHandlers:         []notifierHandler{failed, ok, email},
+		fallbackNotifierHandlers: []notifierHandler{failed, ok, email},
+		sendResultC:              sendResultC,
+		eventBroker:              newNotificationEventBroker(),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notificationStopWaiter := &sync.WaitGroup{}
+	for _, h := range []notifierHandler{failed, ok} {
+		h.setSendResultC(sendResultC)
+		notificationStopWaiter.Add(1)
+		go h.Run(nil, ctx, notificationStopWaiter)
+	}
+	go s.runSendResultHandler(ctx)
+
+	// 발송에 성공하면 대체 Notifier로 발송하지 않는다.
+	assert.True(t, s.NotifyWithTaskContext("discord-2", "message-1", nil))
+	assert.Contains(t, receive(okReceivedC), "message-1")
+
+	// 발송이 실패하면 대체 Notifier 목록의 순서대로 발송을 시도한다.
+	assert.True(t, s.NotifyWithTaskContext("discord-1", "message-2", nil))
+	assert.Contains(t, receive(failedReceivedC), "message-2")
+	assert.Contains(t, receive(okReceivedC), "message-2")
+
+	// 대체 Notifier의 발송도 실패하면 대체 Notifier 목록의 다음 Notifier로 발송한다.
+	s.handleSendResult(&notificationSendResult{notifierID: "discord-2", data: &notificationSendData{message: "message-3", failedNotifierIDs: []NotifierID{"discord-1"}}, err: errors.New("send failed")})
+	select {
+	case data := <-email.notificationSendC:
+		assert.Equal(t, "message-3", data.message)
+		assert.Equal(t, []NotifierID{"discord-1", "discord-2"}, data.failedNotifierIDs)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "대체 Notifier로 알림메시지가 발송되지 않았습니다.")
+	}
+
+	// 모든 대체 Notifier의 발송이 실패한 경우 더이상 발송을 시도하지 않는다.
+	assert.False(t, s.resendWithFallback(&notificationSendResult{notifierID: "email-1", data: &notificationSendData{message: "message-4", failedNotifierIDs: []NotifierID{"discord-1", "discord-2"}}, err: errors.New("send failed")}))
+
+	// 알 수 없는 Notifier인 경우에도 대체 Notifier로 발송한다.
+	assert.True(t, s.NotifyWithTaskContext("unknown", "message-5", nil))
+	assert.Contains(t, receive(okReceivedC), "message-5")
+
+	cancel()
+	notificationStopWaiter.Wait()
+}
+
+func TestNotificationService_NotifyBatch(t *testing.T) {
+	batching := &testNotifier{notifier: notifier{id: "telegram", batchMaxLength: 100, notificationSendC: make(chan *notificationSendData, 10)}}
+	sequential := &testNotifier{notifier: notifier{id: "discord", notificationSendC: make(chan *notificationSendData, 10)}}
+
+	s := &NotificationService{
+		defaultNotifierHandler: batching,
+		notifierHandlers:       []notifierHandler{batching, sequential},
+		eventBroker:            newNotificationEventBroker(),
+	}
+
+	assert.NoError(t, s.NotifyBatch([]task.Notification{
+		{NotifierID: "telegram", Message: "message-1"},
+		{NotifierID: "discord", Message: "message-1"},
+		{NotifierID: "telegram", Message: "message-2"},
+		{NotifierID: "discord", Message: "message-2"},
+	}))
+
+	// 합쳐서 발송할 수 있는 Notifier는 구분자로 연결된 하나의 알림메시지로 발송한다.
+	assert.Equal(t, 1, len(batching.notificationSendC))
+	assert.Equal(t, "message-1"+notificationBatchSeparator+"message-2", (<-batching.notificationSendC).message)
+
+	// 합쳐서 발송할 수 없는 Notifier는 순서대로 하나씩 발송한다.
+	assert.Equal(t, 2, len(sequential.notificationSendC))
+	assert.Equal(t, "message-1", (<-sequential.notificationSendC).message)
+	assert.Equal(t, "message-2", (<-sequential.notificationSendC).message)
+
+	// Notifier의 최대 글자수를 넘는 경우 나누어서 발송한다.
+	longMessage := strings.Repeat("가", batching.BatchMaxLength()-1)
+	assert.NoError(t, s.NotifyBatch([]task.Notification{
+		{NotifierID: "telegram", Message: longMessage},
+		{NotifierID: "telegram", Message: "message"},
+	}))
+	assert.Equal(t, 2, len(batching.notificationSendC))
+	<-batching.notificationSendC
+	<-batching.notificationSendC
+
+	// 알림메시지에 최대 글자수가 지정된 경우 Notifier의 최대 글자수보다 작더라도 지정된 최대 글자수를 넘지 않도록 합친다.
+	assert.NoError(t, s.NotifyBatch([]task.Notification{
+		{NotifierID: "telegram", Message: "(1/3)\nmessage-1", MaxLength: 32},
+		{NotifierID: "telegram", Message: "(2/3)\nmessage-2", MaxLength: 32},
+		{NotifierID: "telegram", Message: "(3/3)\nmessage-3", MaxLength: 32},
+	}))
+	assert.Equal(t, 2, len(batching.notificationSendC))
+	assert.Equal(t, "(1/3)\nmessage-1"+notificationBatchSeparator+"(2/3)\nmessage-2", (<-batching.notificationSendC).message)
+	assert.Equal(t, "(3/3)\nmessage-3", (<-batching.notificationSendC).message)
+
+	// 발송이 실패한 알림메시지가 있는 경우
+	close(sequential.notificationSendC)
+	assert.Error(t, s.NotifyBatch([]task.Notification{{NotifierID: "discord", Message: "message"}}))
+}
+
+func TestNotifier_RateLimit(t *testing.T) {
+	// 발송 횟수의 제한이 없는 경우 Limiter를 생성하지 않는다.
+	assert.Nil(t, newNotifierRateLimiter(0))
+
+	n := &testNotifier{notifier: notifier{id: "telegram", rateLimit: 50 * time.Millisecond}}
+	assert.Equal(t, 50*time.Millisecond, n.RateLimit())
+	assert.NoError(t, n.waitRateLimit(context.Background()))
+
+	// 연속된 알림메시지 발송 사이에는 최소 RateLimit() 만큼의 간격을 둔다.
+	n.setRateLimiter(newNotifierRateLimiter(n.RateLimit()))
+	start := time.Now()
+	assert.NoError(t, n.waitRateLimit(context.Background()))
+	assert.NoError(t, n.waitRateLimit(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	// 대기하는 도중에 취소된 경우 에러를 반환한다.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, n.waitRateLimit(ctx))
+}
@@ -0,0 +1,208 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/darkkaiser/notify-server/g"
+	_log_ "github.com/darkkaiser/notify-server/log"
+	"github.com/darkkaiser/notify-server/service/task"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// Embed의 색상(10진수 RGB 값)
+	discordEmbedColorDefault = 0x5865F2
+	discordEmbedColorError   = 0xED4245
+)
+
+// Discord Webhook 메시지의 최대 글자수, 최대 글자수를 초과하면 Discord에서 메시지를 거부하므로 초과하는 부분은 잘라낸다.
+const (
+	discordContentMaxLength          = 2000
+	discordEmbedTitleMaxLength       = 256
+	discordEmbedDescriptionMaxLength = 4096
+)
+
+// 최대 글자수를 초과하여 잘라낸 메시지의 끝에 붙이는 문자열
+const discordTruncatedSuffix = "\n…(이하 생략)"
+
+type discordWebhookMessage struct {
+	Content string                `json:"content,omitempty"`
+	Embeds  []discordWebhookEmbed `json:"embeds,omitempty"`
+}
+
+type discordWebhookEmbed struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Color       int    `json:"color"`
+}
+
+type discordNotifier struct {
+	notifier
+
+	webhookURL string
+
+	httpClient *http.Client
+
+	// TaskID와 TaskCommandID로 알림메시지의 제목을 구하기 위한 맵
+	commandTitles map[string]string
+}
+
+func newDiscordNotifier(id NotifierID, webhookURL string, config *g.AppConfig) notifierHandler {
+	notifier := &discordNotifier{
+		notifier: notifier{
+			id: id,
+
+			supportHTMLMessage: false,
+
+			notificationSendC: make(chan *notificationSendData, 10),
+		},
+
+		webhookURL: webhookURL,
+
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+
+		commandTitles: make(map[string]string),
+	}
+
+	for _, t := range config.Tasks {
+		for _, c := range t.Commands {
+			notifier.commandTitles[discordCommandTitleKey(task.TaskID(t.ID), task.TaskCommandID(c.ID))] = fmt.Sprintf("%s > %s", t.Title, c.Title)
+		}
+	}
+
+	return notifier
+}
+
+func discordCommandTitleKey(taskID task.TaskID, taskCommandID task.TaskCommandID) string {
+	return fmt.Sprintf("%s::%s", taskID, taskCommandID)
+}
+
+func (n *discordNotifier) Run(_ task.TaskRunner, notificationStopCtx context.Context, notificationStopWaiter *sync.WaitGroup) {
+	defer notificationStopWaiter.Done()
+
+	log.Debugf("'%s' Discord Notifier의 작업이 시작됨", n.ID())
+
+	for {
+		select {
+		case notificationSendData := <-n.notificationSendC:
+			if err := n.waitRateLimit(notificationStopCtx); err != nil {
+				n.reportSendResult(notificationSendData, err)
+				continue
+			}
+
+			err := n.send(n.newWebhookMessage(notificationSendData.message, notificationSendData.taskCtx))
+			if err != nil {
+				_log_.WithNotifierID(string(n.ID())).WithError(err).Error("알림메시지 발송이 실패하였습니다.")
+			}
+			n.reportSendResult(notificationSendData, err)
+
+		case <-notificationStopCtx.Done():
+			close(n.notificationSendC)
+
+			n.notificationSendC = nil
+
+			log.Debugf("'%s' Discord Notifier의 작업이 중지됨", n.ID())
+
+			return
+		}
+	}
+}
+
+func (n *discordNotifier) newWebhookMessage(message string, taskCtx task.TaskContext) *discordWebhookMessage {
+	if taskCtx == nil {
+		return &discordWebhookMessage{Content: truncateDiscordText(message, discordContentMaxLength)}
+	}
+
+	var title string
+	if t, ok := taskCtx.Value(task.TaskCtxKeyTitle).(string); ok == true && len(t) > 0 {
+		title = t
+	} else {
+		taskID, ok1 := taskCtx.Value(task.TaskCtxKeyTaskID).(task.TaskID)
+		taskCommandID, ok2 := taskCtx.Value(task.TaskCtxKeyTaskCommandID).(task.TaskCommandID)
+		if ok1 == true && ok2 == true {
+			title = n.commandTitles[discordCommandTitleKey(taskID, taskCommandID)]
+		}
+	}
+
+	errorOccurred, _ := taskCtx.Value(task.TaskCtxKeyErrorOccurred).(bool)
+
+	// 제목이 없는 단순 메시지는 일반 텍스트로 발송한다.
+	if len(title) == 0 && errorOccurred == false {
+		return &discordWebhookMessage{Content: truncateDiscordText(message, discordContentMaxLength)}
+	}
+
+	embed := discordWebhookEmbed{
+		Title:       title,
+		Description: message,
+		Color:       discordEmbedColorDefault,
+	}
+	if errorOccurred == true {
+		embed.Description = fmt.Sprintf("%s\n\n*** 오류가 발생하였습니다. ***", message)
+		embed.Color = discordEmbedColorError
+	}
+	embed.Title = truncateDiscordText(embed.Title, discordEmbedTitleMaxLength)
+	embed.Description = truncateDiscordText(embed.Description, discordEmbedDescriptionMaxLength)
+
+	return &discordWebhookMessage{Embeds: []discordWebhookEmbed{embed}}
+}
+
+// truncateDiscordText text가 maxLength 글자를 초과하는 경우 초과하는 부분을 잘라내고 생략되었음을 표시한다.
+func truncateDiscordText(text string, maxLength int) string {
+	r := []rune(text)
+	if len(r) <= maxLength {
+		return text
+	}
+
+	suffix := []rune(discordTruncatedSuffix)
+	if maxLength <= len(suffix) {
+		return string(r[:maxLength])
+	}
+
+	return string(r[:maxLength-len(suffix)]) + discordTruncatedSuffix
+}
+
+// Ping Webhook 정보를 조회하여 Webhook URL이 유효한지 확인한다.
+// noinspection GoUnhandledErrorResult
+func (n *discordNotifier) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, n.webhookURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Discord Webhook 정보 조회가 실패하였습니다.(%s)", resp.Status)
+	}
+
+	return nil
+}
+
+// noinspection GoUnhandledErrorResult
+func (n *discordNotifier) send(m *discordWebhookMessage) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.httpClient.Post(n.webhookURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("Discord Webhook 호출이 실패하였습니다.(%s)", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package notification
+
+import (
+	"github.com/darkkaiser/notify-server/service/task"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestDiscordNotifier_NewWebhookMessage_Truncate(t *testing.T) {
+	n := &discordNotifier{commandTitles: make(map[string]string)}
+
+	// 최대 글자수를 초과하지 않는 메시지는 그대로 발송한다.
+	assert.Equal(t, "message", n.newWebhookMessage("message", nil).Content)
+
+	// 최대 글자수를 초과하는 메시지는 최대 글자수에 맞게 잘라낸다.
+	m := n.newWebhookMessage(strings.Repeat("가", discordContentMaxLength+1), nil)
+	assert.Equal(t, discordContentMaxLength, utf8.RuneCountInString(m.Content))
+	assert.True(t, strings.HasSuffix(m.Content, discordTruncatedSuffix))
+
+	m = n.newWebhookMessage(strings.Repeat("가", discordEmbedDescriptionMaxLength+1), task.NewContext().With(task.TaskCtxKeyTitle, strings.Repeat("제", discordEmbedTitleMaxLength+1)))
+	assert.Equal(t, 1, len(m.Embeds))
+	assert.Equal(t, discordEmbedTitleMaxLength, utf8.RuneCountInString(m.Embeds[0].Title))
+	assert.Equal(t, discordEmbedDescriptionMaxLength, utf8.RuneCountInString(m.Embeds[0].Description))
+}
@@ -0,0 +1,292 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github.com/darkkaiser/notify-server/g"
+	_log_ "github.com/darkkaiser/notify-server/log"
+	"github.com/darkkaiser/notify-server/service/task"
+	log "github.com/sirupsen/logrus"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// 메일 발송이 실패한 경우 재시도하는 최대 횟수
+	emailSendMaxRetries = 3
+
+	// 메일 발송을 재시도하기 전에 대기하는 시간(재시도할 때마다 2배씩 증가한다)
+	emailSendRetryDelay = 2 * time.Second
+
+	// SMTP 서버 연결 제한 시간
+	emailDialTimeout = 30 * time.Second
+
+	// 메일 1건을 발송(연결, 인증, 전송)하는 데 허용되는 최대 시간
+	emailSendTimeout = 60 * time.Second
+
+	// 여러 개의 알림메시지를 하나로 합칠 때 합쳐진 알림메시지의 최대 글자수
+	// 메일 본문의 길이는 제한이 없지만, 너무 긴 메일이 발송되지 않도록 제한한다.
+	emailBatchMaxLength = 20000
+)
+
+type emailNotifier struct {
+	notifier
+
+	host          string
+	port          int
+	username      string
+	password      string
+	from          string
+	to            []string
+	subjectPrefix string
+
+	// TaskID와 TaskCommandID로 알림메시지의 제목을 구하기 위한 맵
+	commandTitles map[string]string
+}
+
+func newEmailNotifier(id NotifierID, host string, port int, username, password, from string, to []string, subjectPrefix string, config *g.AppConfig) notifierHandler {
+	notifier := &emailNotifier{
+		notifier: notifier{
+			id: id,
+
+			supportHTMLMessage: true,
+
+			batchMaxLength: emailBatchMaxLength,
+
+			// 메일 발송이 지연되더라도 작업이 대기하지 않도록 메시지를 큐에 저장한다.
+			notificationSendC: make(chan *notificationSendData, 100),
+		},
+
+		host:          host,
+		port:          port,
+		username:      username,
+		password:      password,
+		from:          from,
+		to:            to,
+		subjectPrefix: subjectPrefix,
+
+		commandTitles: make(map[string]string),
+	}
+
+	for _, t := range config.Tasks {
+		for _, c := range t.Commands {
+			notifier.commandTitles[emailCommandTitleKey(task.TaskID(t.ID), task.TaskCommandID(c.ID))] = fmt.Sprintf("%s > %s", t.Title, c.Title)
+		}
+	}
+
+	return notifier
+}
+
+func emailCommandTitleKey(taskID task.TaskID, taskCommandID task.TaskCommandID) string {
+	return fmt.Sprintf("%s::%s", taskID, taskCommandID)
+}
+
+func (n *emailNotifier) Run(_ task.TaskRunner, notificationStopCtx context.Context, notificationStopWaiter *sync.WaitGroup) {
+	defer notificationStopWaiter.Done()
+
+	log.Debugf("'%s' Email Notifier의 작업이 시작됨", n.ID())
+
+	for {
+		select {
+		case notificationSendData := <-n.notificationSendC:
+			if err := n.waitRateLimit(notificationStopCtx); err != nil {
+				n.reportSendResult(notificationSendData, err)
+				continue
+			}
+
+			subject, body := n.newMail(notificationSendData.message, notificationSendData.taskCtx)
+
+			var err error
+			delay := emailSendRetryDelay
+		retryLoop:
+			for i := 0; i <= emailSendMaxRetries; i++ {
+				if i > 0 {
+					_log_.WithNotifierID(string(n.ID())).WithError(err).Warnf("메일 발송이 실패하여 %s 후에 다시 시도합니다.(%d/%d)", delay, i, emailSendMaxRetries)
+
+					select {
+					case <-time.After(delay):
+					case <-notificationStopCtx.Done():
+						// 서비스가 중지되는 중이므로 더 이상 재시도하지 않는다.
+						break retryLoop
+					}
+					delay *= 2
+				}
+
+				if err = n.send(notificationStopCtx, subject, body); err == nil {
+					break
+				}
+			}
+			if err != nil {
+				_log_.WithNotifierID(string(n.ID())).WithError(err).Error("알림메시지 발송이 실패하였습니다.")
+			}
+			n.reportSendResult(notificationSendData, err)
+
+		case <-notificationStopCtx.Done():
+			close(n.notificationSendC)
+
+			n.notificationSendC = nil
+
+			log.Debugf("'%s' Email Notifier의 작업이 중지됨", n.ID())
+
+			return
+		}
+	}
+}
+
+func (n *emailNotifier) newMail(message string, taskCtx task.TaskContext) (subject, body string) {
+	var title string
+	var errorOccurred bool
+	if taskCtx != nil {
+		if t, ok := taskCtx.Value(task.TaskCtxKeyTitle).(string); ok == true && len(t) > 0 {
+			title = t
+		} else {
+			taskID, ok1 := taskCtx.Value(task.TaskCtxKeyTaskID).(task.TaskID)
+			taskCommandID, ok2 := taskCtx.Value(task.TaskCtxKeyTaskCommandID).(task.TaskCommandID)
+			if ok1 == true && ok2 == true {
+				title = n.commandTitles[emailCommandTitleKey(taskID, taskCommandID)]
+			}
+		}
+
+		errorOccurred, _ = taskCtx.Value(task.TaskCtxKeyErrorOccurred).(bool)
+	}
+	if len(title) == 0 {
+		title = "알림메시지"
+	}
+
+	subject = title
+	if n.subjectPrefix != "" {
+		subject = fmt.Sprintf("%s %s", n.subjectPrefix, subject)
+	}
+	if errorOccurred == true {
+		subject = fmt.Sprintf("[ERROR] %s", subject)
+
+		message = fmt.Sprintf("%s\n\n*** 오류가 발생하였습니다. ***", message)
+	}
+
+	// 알림메시지는 HTML 태그가 포함된 텍스트이므로 줄바꿈만 HTML 태그로 변환한다.
+	body = fmt.Sprintf("<html><body>%s</body></html>", strings.ReplaceAll(message, "\n", "<br>\n"))
+
+	return subject, body
+}
+
+// dial SMTP 서버에 TLS로 연결하고 인증을 마친 클라이언트를 반환한다.
+// ctx에 만료 시간이 설정된 경우 연결 이후의 모든 SMTP 명령도 만료 시간이 지나면 실패하도록 연결에 데드라인을 설정한다.
+// noinspection GoUnhandledErrorResult
+func (n *emailNotifier) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(n.host, strconv.Itoa(n.port))
+	tlsConfig := &tls.Config{ServerName: n.host}
+	dialer := &net.Dialer{Timeout: emailDialTimeout}
+
+	var c *smtp.Client
+	if n.port == 465 {
+		// SMTPS(Implicit TLS)
+		conn, err := (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		if deadline, ok := ctx.Deadline(); ok == true {
+			conn.SetDeadline(deadline)
+		}
+		if c, err = smtp.NewClient(conn, n.host); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	} else {
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		if deadline, ok := ctx.Deadline(); ok == true {
+			conn.SetDeadline(deadline)
+		}
+		if c, err = smtp.NewClient(conn, n.host); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		if ok, _ := c.Extension("STARTTLS"); ok == false {
+			c.Close()
+			return nil, fmt.Errorf("SMTP 서버(%s)가 STARTTLS를 지원하지 않습니다", addr)
+		}
+		if err = c.StartTLS(tlsConfig); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+
+	if n.username != "" {
+		if err := c.Auth(smtp.PlainAuth("", n.username, n.password, n.host)); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Ping SMTP 서버에 연결하여 인증이 가능한지 확인한다.
+// noinspection GoUnhandledErrorResult
+func (n *emailNotifier) Ping(ctx context.Context) error {
+	c, err := n.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if err = c.Noop(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// noinspection GoUnhandledErrorResult
+func (n *emailNotifier) send(ctx context.Context, subject, body string) error {
+	ctx, cancel := context.WithTimeout(ctx, emailSendTimeout)
+	defer cancel()
+
+	c, err := n.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if err := c.Mail(n.from); err != nil {
+		return err
+	}
+	for _, to := range n.to {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+
+	var msg bytes.Buffer
+	msg.WriteString(fmt.Sprintf("From: %s\r\n", n.from))
+	msg.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(n.to, ", ")))
+	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", subject)))
+	msg.WriteString(fmt.Sprintf("Date: %s\r\n", time.Now().Format(time.RFC1123Z)))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(body)
+
+	if _, err = w.Write(msg.Bytes()); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
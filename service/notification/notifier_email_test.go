@@ -0,0 +1,36 @@
+package notification
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestEmailNotifier_Ping_Deadline(t *testing.T) {
+	// 연결은 수락하지만 SMTP 인사말을 보내지 않는 서버
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer ln.Close()
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	n := &emailNotifier{host: "127.0.0.1", port: ln.Addr().(*net.TCPAddr).Port}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	// 서버가 응답하지 않더라도 ctx의 만료 시간이 지나면 Ping()이 반환되어야 한다.
+	start := time.Now()
+	assert.Error(t, n.Ping(ctx))
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
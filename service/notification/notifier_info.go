@@ -0,0 +1,81 @@
+package notification
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	NotifierTypeTelegram = "telegram"
+	NotifierTypeDiscord  = "discord"
+	NotifierTypeEmail    = "email"
+)
+
+// Notifier의 상태 확인 제한 시간
+const notifierPingTimeout = 10 * time.Second
+
+// NotifierInfo 등록된 Notifier의 정보와 상태
+type NotifierInfo struct {
+	ID                 NotifierID
+	Type               string
+	SupportHTMLMessage bool
+
+	// Notifier의 상태 확인이 실패한 경우의 오류
+	Err error
+}
+
+func notifierTypeOf(h notifierHandler) string {
+	if d, ok := h.(*Deduplicator); ok == true {
+		h = d.notifierHandler
+	}
+
+	switch h.(type) {
+	case *telegramNotifier:
+		return NotifierTypeTelegram
+	case *discordNotifier:
+		return NotifierTypeDiscord
+	case *emailNotifier:
+		return NotifierTypeEmail
+	default:
+		return ""
+	}
+}
+
+func (s *NotificationService) ListNotifiers() []*NotifierInfo {
+	// 상태 확인이 지연되더라도 알림메시지 발송이 대기하지 않도록 잠금을 해제한 이후에 상태를 확인한다.
+	s.runningMu.Lock()
+	running := s.running
+	handlers := make([]notifierHandler, len(s.notifierHandlers))
+	copy(handlers, s.notifierHandlers)
+	s.runningMu.Unlock()
+
+	infos := make([]*NotifierInfo, 0, len(handlers))
+	for _, h := range handlers {
+		infos = append(infos, &NotifierInfo{
+			ID:                 h.ID(),
+			Type:               notifierTypeOf(h),
+			SupportHTMLMessage: h.SupportHTMLMessage(),
+		})
+	}
+
+	// 서비스가 중지된 경우에는 상태를 확인하지 않는다.
+	if running == false {
+		return infos
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), notifierPingTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i, h := range handlers {
+		wg.Add(1)
+		go func(info *NotifierInfo, h notifierHandler) {
+			defer wg.Done()
+			info.Err = h.Ping(ctx)
+		}(infos[i], h)
+	}
+	wg.Wait()
+
+	return infos
+}
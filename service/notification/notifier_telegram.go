@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"github.com/darkkaiser/notify-server/g"
+	_log_ "github.com/darkkaiser/notify-server/log"
 	"github.com/darkkaiser/notify-server/service/task"
 	"github.com/darkkaiser/notify-server/utils"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	log "github.com/sirupsen/logrus"
+	"html/template"
 	"strings"
 	"sync"
+	"time"
 )
 
 const (
@@ -20,6 +23,12 @@ const (
 	telegramBotCommandInitialCharacter = "/"
 )
 
+// 텔레그램 봇은 초당 최대 30개의 메시지를 발송할 수 있으므로 연속된 알림메시지 발송 사이에 최소 33ms의 간격을 둔다.
+const telegramRateLimit = 33 * time.Millisecond
+
+// 여러 개의 알림메시지를 하나로 합칠 때 합쳐진 알림메시지의 최대 글자수(텔레그램 메시지의 최대 글자수는 4096자이다)
+const telegramBatchMaxLength = 4000
+
 type telegramBotCommand struct {
 	command            string
 	commandTitle       string
@@ -37,6 +46,10 @@ type telegramNotifier struct {
 	bot *tgbotapi.BotAPI
 
 	botCommands []telegramBotCommand
+
+	// 봇의 업데이트 수신 작업이 진행중인지의 여부
+	receiving   bool
+	receivingMu sync.Mutex
 }
 
 func newTelegramNotifier(id NotifierID, botToken string, chatID int64, config *g.AppConfig) notifierHandler {
@@ -46,6 +59,10 @@ func newTelegramNotifier(id NotifierID, botToken string, chatID int64, config *g
 
 			supportHTMLMessage: true,
 
+			batchMaxLength: telegramBatchMaxLength,
+
+			rateLimit: telegramRateLimit,
+
 			notificationSendC: make(chan *notificationSendData, 10),
 		},
 
@@ -99,6 +116,10 @@ func (n *telegramNotifier) Run(taskRunner task.TaskRunner, notificationStopCtx c
 
 	updateC := n.bot.GetUpdatesChan(config)
 
+	n.receivingMu.Lock()
+	n.receiving = true
+	n.receivingMu.Unlock()
+
 	log.Debugf("'%s' Telegram Notifier의 작업이 시작됨(Authorized on account %s)", n.ID(), n.bot.Self.UserName)
 
 LOOP:
@@ -128,7 +149,7 @@ LOOP:
 					}
 
 					if _, err := n.bot.Send(tgbotapi.NewMessage(n.chatID, m)); err != nil {
-						log.Errorf("알림메시지 발송이 실패하였습니다.(error:%s)", err)
+						_log_.WithNotifierID(string(n.ID())).WithError(err).Error("알림메시지 발송이 실패하였습니다.")
 					}
 
 					continue
@@ -164,74 +185,39 @@ LOOP:
 
 			m := fmt.Sprintf("'%s'는 등록되지 않은 명령어입니다.\n명령어를 모르시면 '%s%s'을 입력하세요.", update.Message.Text, telegramBotCommandInitialCharacter, telegramBotCommandHelp)
 			if _, err := n.bot.Send(tgbotapi.NewMessage(n.chatID, m)); err != nil {
-				log.Errorf("알림메시지 발송이 실패하였습니다.(error:%s)", err)
+				_log_.WithNotifierID(string(n.ID())).WithError(err).Error("알림메시지 발송이 실패하였습니다.")
 			}
 
 		case notificationSendData := <-n.notificationSendC:
+			if err := n.waitRateLimit(notificationStopCtx); err != nil {
+				n.reportSendResult(notificationSendData, err)
+				continue
+			}
+
 			m := notificationSendData.message
 
 			if notificationSendData.taskCtx == nil {
-				if _, err := n.bot.Send(tgbotapi.NewMessage(n.chatID, m)); err != nil {
-					log.Errorf("알림메시지 발송이 실패하였습니다.(error:%s)", err)
+				_, err := n.bot.Send(tgbotapi.NewMessage(n.chatID, m))
+				if err != nil {
+					_log_.WithNotifierID(string(n.ID())).WithError(err).Error("알림메시지 발송이 실패하였습니다.")
 				}
+				n.reportSendResult(notificationSendData, err)
 			} else {
-				title, ok := notificationSendData.taskCtx.Value(task.TaskCtxKeyTitle).(string)
-				if ok == true && len(title) > 0 {
-					m = fmt.Sprintf("<b>【 %s 】</b>\n\n%s", title, m)
-				} else {
-					taskID, ok1 := notificationSendData.taskCtx.Value(task.TaskCtxKeyTaskID).(task.TaskID)
-					taskCommandID, ok2 := notificationSendData.taskCtx.Value(task.TaskCtxKeyTaskCommandID).(task.TaskCommandID)
-					if ok1 == true && ok2 == true {
-						for _, botCommand := range n.botCommands {
-							if botCommand.taskID == taskID && botCommand.taskCommandID == taskCommandID {
-								m = fmt.Sprintf("<b>【 %s 】</b>\n\n%s", botCommand.commandTitle, m)
-								break
-							}
-						}
-					}
-				}
-
-				// TaskInstanceID가 존재하는 경우 취소 명령어를 붙인다.
-				if taskInstanceID, ok := notificationSendData.taskCtx.Value(task.TaskCtxKeyTaskInstanceID).(task.TaskInstanceID); ok == true {
-					m += fmt.Sprintf("\n%s%s%s%s", telegramBotCommandInitialCharacter, telegramBotCommandCancel, telegramBotCommandSeparator, taskInstanceID)
-
-					// 작업 실행 후 경과시간(단위 : 초)
-					if elapsedTimeAfterRun, ok := notificationSendData.taskCtx.Value(task.TaskCtxKeyElapsedTimeAfterRun).(int64); ok == true && elapsedTimeAfterRun > 0 {
-						seconds := elapsedTimeAfterRun % 60
-						elapsedTimeAfterRun = elapsedTimeAfterRun / 60
-						minutes := elapsedTimeAfterRun % 60
-						hours := elapsedTimeAfterRun / 60
-
-						var elapsedTimeString string
-						if hours > 0 {
-							elapsedTimeString = fmt.Sprintf("%d시간 ", hours)
-						}
-						if minutes > 0 {
-							elapsedTimeString += fmt.Sprintf("%d분 ", minutes)
-						}
-						if seconds > 0 {
-							elapsedTimeString += fmt.Sprintf("%d초 ", seconds)
-						}
-
-						if len(elapsedTimeString) > 0 {
-							m += fmt.Sprintf(" (%s지남)", elapsedTimeString)
-						}
-					}
-				}
-
-				if errorOccurred, ok := notificationSendData.taskCtx.Value(task.TaskCtxKeyErrorOccurred).(bool); ok == true && errorOccurred == true {
-					m = fmt.Sprintf("%s\n\n*** 오류가 발생하였습니다. ***", m)
-				}
-
-				messageConfig := tgbotapi.NewMessage(n.chatID, m)
+				messageConfig := tgbotapi.NewMessage(n.chatID, n.formatMessage(notificationSendData.taskCtx, m))
 				messageConfig.ParseMode = tgbotapi.ModeHTML
 
-				if _, err := n.bot.Send(messageConfig); err != nil {
-					log.Errorf("알림메시지 발송이 실패하였습니다.(error:%s)", err)
+				_, err := n.bot.Send(messageConfig)
+				if err != nil {
+					_log_.WithNotifierID(string(n.ID())).WithError(err).Error("알림메시지 발송이 실패하였습니다.")
 				}
+				n.reportSendResult(notificationSendData, err)
 			}
 
 		case <-notificationStopCtx.Done():
+			n.receivingMu.Lock()
+			n.receiving = false
+			n.receivingMu.Unlock()
+
 			n.bot.StopReceivingUpdates()
 
 			close(n.notificationSendC)
@@ -245,3 +231,88 @@ LOOP:
 		}
 	}
 }
+
+// Ping 텔레그램 봇 API의 getMe를 호출하여 봇 토큰이 유효한지 확인한다.
+func (n *telegramNotifier) Ping(_ context.Context) error {
+	n.receivingMu.Lock()
+	receiving := n.receiving
+	n.receivingMu.Unlock()
+
+	if receiving == false {
+		return fmt.Errorf("'%s' Telegram Notifier의 작업이 실행중이 아닙니다", n.ID())
+	}
+
+	if _, err := n.bot.GetMe(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *telegramNotifier) Health() error {
+	n.receivingMu.Lock()
+	defer n.receivingMu.Unlock()
+
+	if n.receiving == false {
+		return fmt.Errorf("'%s' Telegram Notifier의 작업이 실행중이 아닙니다", n.ID())
+	}
+
+	return nil
+}
+
+// formatMessage 작업 컨텍스트의 정보를 HTML 형식으로 알림메시지에 덧붙인다.
+// 제목이 존재하는 경우 굵은 글씨로 알림메시지의 앞에 붙이며, 제목이 없는 경우 작업 커맨드의 제목을 사용한다.
+func (n *telegramNotifier) formatMessage(taskCtx task.TaskContext, message string) string {
+	m := message
+
+	title, ok := taskCtx.Value(task.TaskCtxKeyTitle).(string)
+	if ok == true && len(title) > 0 {
+		// 외부 프로그램에서 입력된 제목이 HTML 태그로 해석되지 않도록 한다.
+		m = fmt.Sprintf("<b>【 %s 】</b>\n\n%s", template.HTMLEscapeString(title), m)
+	} else {
+		taskID, ok1 := taskCtx.Value(task.TaskCtxKeyTaskID).(task.TaskID)
+		taskCommandID, ok2 := taskCtx.Value(task.TaskCtxKeyTaskCommandID).(task.TaskCommandID)
+		if ok1 == true && ok2 == true {
+			for _, botCommand := range n.botCommands {
+				if botCommand.taskID == taskID && botCommand.taskCommandID == taskCommandID {
+					m = fmt.Sprintf("<b>【 %s 】</b>\n\n%s", botCommand.commandTitle, m)
+					break
+				}
+			}
+		}
+	}
+
+	// TaskInstanceID가 존재하는 경우 취소 명령어를 붙인다.
+	if taskInstanceID, ok := taskCtx.Value(task.TaskCtxKeyTaskInstanceID).(task.TaskInstanceID); ok == true {
+		m += fmt.Sprintf("\n%s%s%s%s", telegramBotCommandInitialCharacter, telegramBotCommandCancel, telegramBotCommandSeparator, taskInstanceID)
+
+		// 작업 실행 후 경과시간(단위 : 초)
+		if elapsedTimeAfterRun, ok := taskCtx.Value(task.TaskCtxKeyElapsedTimeAfterRun).(int64); ok == true && elapsedTimeAfterRun > 0 {
+			seconds := elapsedTimeAfterRun % 60
+			elapsedTimeAfterRun = elapsedTimeAfterRun / 60
+			minutes := elapsedTimeAfterRun % 60
+			hours := elapsedTimeAfterRun / 60
+
+			var elapsedTimeString string
+			if hours > 0 {
+				elapsedTimeString = fmt.Sprintf("%d시간 ", hours)
+			}
+			if minutes > 0 {
+				elapsedTimeString += fmt.Sprintf("%d분 ", minutes)
+			}
+			if seconds > 0 {
+				elapsedTimeString += fmt.Sprintf("%d초 ", seconds)
+			}
+
+			if len(elapsedTimeString) > 0 {
+				m += fmt.Sprintf(" (%s지남)", elapsedTimeString)
+			}
+		}
+	}
+
+	if errorOccurred, ok := taskCtx.Value(task.TaskCtxKeyErrorOccurred).(bool); ok == true && errorOccurred == true {
+		m = fmt.Sprintf("%s\n\n*** 오류가 발생하였습니다. ***", m)
+	}
+
+	return m
+}
@@ -0,0 +1,89 @@
+package task
+
+import (
+	"context"
+	"github.com/darkkaiser/notify-server/g"
+	"github.com/fsnotify/fsnotify"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// 환경설정 파일이 연속으로 변경되는 경우 마지막 변경 이후 대기하는 시간
+const configReloadDebounceDuration = 1 * time.Second
+
+// watchConfig 환경설정 파일의 변경 또는 SIGHUP 시그널을 감지하여 reloadC 채널로 알린다.
+func watchConfig(ctx context.Context, reloadC chan<- struct{}) {
+	sighupC := make(chan os.Signal, 1)
+	signal.Notify(sighupC, syscall.SIGHUP)
+	defer signal.Stop(sighupC)
+
+	// 에디터 등에서 파일을 교체하는 방식으로 저장하는 경우에도 감지할 수 있도록 파일이 위치한 디렉토리를 감시한다.
+	var watcherEventsC <-chan fsnotify.Event
+	var watcherErrorsC <-chan error
+	watcher, err := fsnotify.NewWatcher()
+	if err == nil {
+		defer watcher.Close()
+
+		if err = watcher.Add(filepath.Dir(g.AppConfigFileName)); err == nil {
+			watcherEventsC = watcher.Events
+			watcherErrorsC = watcher.Errors
+		}
+	}
+	if err != nil {
+		log.Errorf("환경설정 파일의 변경 감시를 시작할 수 없습니다. SIGHUP 시그널로만 환경설정 정보를 다시 읽어들일 수 있습니다.(error:%s)", err)
+	}
+
+	notify := func() {
+		select {
+		case reloadC <- struct{}{}:
+		default:
+		}
+	}
+
+	debounceTimer := time.NewTimer(configReloadDebounceDuration)
+	debounceTimer.Stop()
+	defer debounceTimer.Stop()
+
+	for {
+		select {
+		case event, ok := <-watcherEventsC:
+			if ok == false {
+				watcherEventsC = nil
+				continue
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(g.AppConfigFileName) {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+
+			debounceTimer.Reset(configReloadDebounceDuration)
+
+		case err, ok := <-watcherErrorsC:
+			if ok == false {
+				watcherErrorsC = nil
+				continue
+			}
+
+			log.Errorf("환경설정 파일의 변경 감시 중에 오류가 발생하였습니다.(error:%s)", err)
+
+		case <-debounceTimer.C:
+			log.Infof("%s 파일의 변경이 감지되었습니다.", g.AppConfigFileName)
+
+			notify()
+
+		case <-sighupC:
+			log.Info("SIGHUP 시그널이 수신되었습니다.")
+
+			notify()
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
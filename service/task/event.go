@@ -0,0 +1,93 @@
+package task
+
+import (
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+type TaskEventType int
+
+const (
+	TaskEventSubmitted TaskEventType = iota
+	TaskEventStarted
+	TaskEventCompleted
+	TaskEventCancelled
+	TaskEventFailed
+)
+
+func (t TaskEventType) String() string {
+	switch t {
+	case TaskEventSubmitted:
+		return "submitted"
+	case TaskEventStarted:
+		return "started"
+	case TaskEventCompleted:
+		return "completed"
+	case TaskEventCancelled:
+		return "cancelled"
+	case TaskEventFailed:
+		return "failed"
+	}
+	return "unknown"
+}
+
+// TaskEvent 작업의 실행 요청이 접수되거나, 작업이 시작/완료/취소/실패될 때 구독자에게 전달되는 이벤트
+type TaskEvent struct {
+	Type       TaskEventType
+	TaskID     TaskID
+	CommandID  TaskCommandID
+	InstanceID TaskInstanceID
+	Timestamp  time.Time
+
+	// 작업이 실패한 경우의 오류, 그 외에는 nil이다.
+	Error error
+}
+
+// SubscribeToEvents 작업 이벤트를 전달받을 채널을 등록한다. 서비스를 시작하기 전에 호출되어야 한다.
+// 이벤트를 읽어가지 않아 채널이 가득 찬 경우 작업 실행이 지연되지 않도록 이벤트를 전달하지 않는다.
+func (s *TaskService) SubscribeToEvents(ch chan<- TaskEvent) {
+	s.eventSubscribersMu.Lock()
+	defer s.eventSubscribersMu.Unlock()
+
+	s.eventSubscribers = append(s.eventSubscribers, ch)
+}
+
+// UnsubscribeFromEvents 등록된 채널로 더 이상 작업 이벤트를 전달하지 않는다.
+// 채널은 닫지 않으므로 필요한 경우 호출한 곳에서 닫아야 한다.
+func (s *TaskService) UnsubscribeFromEvents(ch chan<- TaskEvent) {
+	s.eventSubscribersMu.Lock()
+	defer s.eventSubscribersMu.Unlock()
+
+	for i, subscriber := range s.eventSubscribers {
+		if subscriber == ch {
+			s.eventSubscribers = append(s.eventSubscribers[:i], s.eventSubscribers[i+1:]...)
+			break
+		}
+	}
+}
+
+// publishEvent 등록된 모든 채널로 작업 이벤트를 전달한다.
+func (s *TaskService) publishEvent(eventType TaskEventType, h taskHandler, err error) {
+	s.eventSubscribersMu.Lock()
+	defer s.eventSubscribersMu.Unlock()
+
+	if len(s.eventSubscribers) == 0 {
+		return
+	}
+
+	e := TaskEvent{
+		Type:       eventType,
+		TaskID:     h.ID(),
+		CommandID:  h.CommandID(),
+		InstanceID: h.InstanceID(),
+		Timestamp:  time.Now(),
+		Error:      err,
+	}
+	for _, ch := range s.eventSubscribers {
+		select {
+		case ch <- e:
+		default:
+			log.Warnf("작업 이벤트를 읽어가지 않는 구독자가 있어 이벤트를 전달하지 않습니다.(TaskInstanceID:%s, Event:%s)", e.InstanceID, e.Type)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package task
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTaskService_PublishEvent(t *testing.T) {
+	s := &TaskService{}
+	h := &task{id: "NAVER", commandID: "WatchNewPerformances", instanceID: "1"}
+
+	ch := make(chan TaskEvent, 1)
+	full := make(chan TaskEvent)
+	s.SubscribeToEvents(ch)
+	s.SubscribeToEvents(full)
+
+	// 채널이 가득 찬 구독자가 있더라도 다른 구독자에게는 이벤트가 전달된다.
+	err := errors.New("failed")
+	s.publishEvent(TaskEventFailed, h, err)
+
+	e := <-ch
+	assert.Equal(t, TaskEventFailed, e.Type)
+	assert.Equal(t, h.id, e.TaskID)
+	assert.Equal(t, h.commandID, e.CommandID)
+	assert.Equal(t, h.instanceID, e.InstanceID)
+	assert.Equal(t, err, e.Error)
+	assert.False(t, e.Timestamp.IsZero())
+
+	// 구독을 해지한 채널로는 이벤트가 전달되지 않는다.
+	s.UnsubscribeFromEvents(ch)
+	s.publishEvent(TaskEventCompleted, h, nil)
+	assert.Equal(t, 0, len(ch))
+	assert.Equal(t, 1, len(s.eventSubscribers))
+}
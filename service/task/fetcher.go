@@ -0,0 +1,341 @@
+package task
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"github.com/darkkaiser/notify-server/utils"
+	log "github.com/sirupsen/logrus"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("연속된 접근 실패로 인해 일시적으로 요청이 차단되었습니다")
+
+// Fetcher 외부 사이트로 HTTP 요청을 보낸다.
+type Fetcher interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// fetcherFunc 일반 함수를 Fetcher로 사용할 수 있도록 한다.
+type fetcherFunc func(req *http.Request) (*http.Response, error)
+
+func (f fetcherFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// fetcher 작업에서 외부 사이트에 접근할 때 사용하는 Fetcher
+var fetcher Fetcher = http.DefaultClient
+
+// HTTPError 외부 사이트가 2xx가 아닌 상태 코드로 응답한 경우 반환되는 에러
+type HTTPError struct {
+	StatusCode int
+
+	// 응답 본문, 최대 httpErrorBodyMaxBytes 크기까지만 보관한다.
+	Body string
+}
+
+// HTTPError에 보관하는 응답 본문의 최대 크기
+const httpErrorBodyMaxBytes = 4 * 1024
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+type FetcherConfig struct {
+	// 전체 호스트에 대해 유지하는 유휴 연결의 최대 갯수
+	MaxIdleConns int
+
+	// 호스트별로 유지하는 유휴 연결의 최대 갯수
+	MaxIdleConnsPerHost int
+
+	// 유휴 연결을 유지하는 시간
+	IdleConnTimeout time.Duration
+
+	// 요청을 보내고 응답을 모두 받을 때까지의 제한 시간
+	RequestTimeout time.Duration
+
+	// 연결 제한 시간
+	DialTimeout time.Duration
+
+	// 모든 요청에 사용할 프록시 서버의 URL, 입력되지 않은 경우 환경 변수(HTTP_PROXY 등)에 설정된 프록시를 사용한다.
+	ProxyURL string
+
+	// 서버 인증서를 검증할 때 시스템 인증서 저장소에 추가로 사용할 CA 인증서의 PEM 파일 경로
+	// TLS 검사 프록시를 사용하는 사내 환경 등에서 프록시의 CA 인증서를 등록할 때 사용한다.
+	CACertFile string
+
+	// 서버 인증서를 검증하지 않는다. 개발 환경에서만 사용하여야 한다.
+	InsecureSkipVerify bool
+}
+
+type proxyURLContextKey struct{}
+
+// withProxyURL 요청에 사용할 프록시 서버를 컨텍스트에 저장한다.
+// 컨텍스트에 저장된 프록시 서버는 FetcherConfig.ProxyURL보다 우선한다.
+func withProxyURL(ctx context.Context, proxyURL *url.URL) context.Context {
+	return context.WithValue(ctx, proxyURLContextKey{}, proxyURL)
+}
+
+// NewFetcher 연결 풀 크기와 제한 시간이 설정된 Fetcher를 생성한다.
+func NewFetcher(config FetcherConfig) (Fetcher, error) {
+	proxy := http.ProxyFromEnvironment
+	if config.ProxyURL != "" {
+		proxyURL, err := utils.ParseProxyURL(config.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+
+		// 프록시 인증 정보는 http.Transport에서 Proxy-Authorization 헤더로 전송되며, https 요청은 CONNECT 메소드로 터널링된다.
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		if proxyURL, ok := req.Context().Value(proxyURLContextKey{}).(*url.URL); ok == true {
+			return http.ProxyURL(proxyURL)(req)
+		}
+		return proxy(req)
+	}
+	if config.CACertFile != "" || config.InsecureSkipVerify == true {
+		tlsConfig := &tls.Config{}
+		if transport.TLSClientConfig != nil {
+			tlsConfig = transport.TLSClientConfig.Clone()
+		}
+
+		if config.CACertFile != "" {
+			pool, err := utils.LoadCACertPool(config.CACertFile)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.RootCAs = pool
+		}
+		if config.InsecureSkipVerify == true {
+			log.Warn("서버 인증서를 검증하지 않도록 설정되었습니다. 개발 환경이 아닌 경우 insecure_skip_verify 설정을 사용하지 마세요.")
+
+			tlsConfig.InsecureSkipVerify = true
+		}
+
+		transport.TLSClientConfig = tlsConfig
+	}
+	transport.MaxIdleConns = config.MaxIdleConns
+	transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	transport.IdleConnTimeout = config.IdleConnTimeout
+	transport.DialContext = (&net.Dialer{
+		Timeout:   config.DialTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   config.RequestTimeout,
+	}, nil
+}
+
+// userAgentRotator 요청마다 User-Agent 헤더를 등록된 목록에서 순서대로 돌아가며 설정한다.
+type userAgentRotator struct {
+	inner  Fetcher
+	agents []string
+
+	// 다음 요청에 사용할 User-Agent의 순번
+	next int64
+}
+
+// NewFetcherWithUserAgentRotation 요청마다 agents의 User-Agent를 순서대로 돌아가며 설정하는 Fetcher를 생성한다.
+// agents가 비어있는 경우 요청의 User-Agent를 변경하지 않는다.
+func NewFetcherWithUserAgentRotation(inner Fetcher, agents []string) Fetcher {
+	return &userAgentRotator{
+		inner:  inner,
+		agents: agents,
+	}
+}
+
+func (r *userAgentRotator) Do(req *http.Request) (*http.Response, error) {
+	if len(r.agents) == 0 {
+		return r.inner.Do(req)
+	}
+
+	n := atomic.AddInt64(&r.next, 1) - 1
+
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", r.agents[n%int64(len(r.agents))])
+
+	return r.inner.Do(req)
+}
+
+// NewMemoryCookieJar 쿠키를 메모리에 저장하는 CookieJar를 생성한다.
+func NewMemoryCookieJar() http.CookieJar {
+	// 옵션을 지정하지 않은 경우 오류가 반환되지 않는다.
+	jar, _ := cookiejar.New(nil)
+	return jar
+}
+
+// cookieJarFetcher 요청에 jar에 저장된 쿠키를 추가하고, 응답으로 받은 쿠키를 jar에 저장한다.
+// inner가 *http.Client인 경우에는 리다이렉트 응답으로 받은 쿠키(로그인 후 리다이렉트 등)도 저장되도록 http.Client.Jar를 사용한다.
+type cookieJarFetcher struct {
+	inner Fetcher
+	jar   http.CookieJar
+}
+
+// NewFetcherWithCookieJar 로그인 세션 등의 쿠키를 jar에 저장하여 이후의 요청에 함께 보내는 Fetcher를 생성한다.
+func NewFetcherWithCookieJar(inner Fetcher, jar http.CookieJar) Fetcher {
+	return &cookieJarFetcher{
+		inner: inner,
+		jar:   jar,
+	}
+}
+
+func (f *cookieJarFetcher) Do(req *http.Request) (*http.Response, error) {
+	if client, ok := f.inner.(*http.Client); ok == true {
+		c := *client
+		c.Jar = f.jar
+		return c.Do(req)
+	}
+
+	if cookies := f.jar.Cookies(req.URL); len(cookies) > 0 {
+		req = req.Clone(req.Context())
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+	}
+
+	resp, err := f.inner.Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if cookies := resp.Cookies(); len(cookies) > 0 {
+		f.jar.SetCookies(req.URL, cookies)
+	}
+
+	return resp, nil
+}
+
+type CircuitBreakerConfig struct {
+	// 회로가 열리는 연속 실패 횟수
+	FailureThreshold int
+
+	// 회로가 열린 후 다시 요청을 시도하기까지의 대기 시간
+	RecoveryTimeout time.Duration
+}
+
+type circuitState int
+
+const (
+	circuitStateClosed   circuitState = iota // 정상적으로 요청을 보낸다.
+	circuitStateOpen                         // 요청을 보내지 않고 바로 ErrCircuitOpen을 반환한다.
+	circuitStateHalfOpen                     // 복구 여부를 확인하기 위한 하나의 요청만 허용한다.
+)
+
+type circuit struct {
+	state               circuitState
+	consecutiveFailures int
+	openedTime          time.Time
+}
+
+// circuitBreaker 호스트별로 연속된 실패 횟수를 확인하여, 장애가 발생한 사이트로의 요청을 일정 시간 동안 차단한다.
+type circuitBreaker struct {
+	inner  Fetcher
+	config CircuitBreakerConfig
+
+	circuits   map[string]*circuit
+	circuitsMu sync.Mutex
+}
+
+func NewCircuitBreaker(inner Fetcher, config CircuitBreakerConfig) RetryFetcher {
+	return &circuitBreaker{
+		inner:  inner,
+		config: config,
+
+		circuits: make(map[string]*circuit),
+	}
+}
+
+func (cb *circuitBreaker) Do(req *http.Request) (*http.Response, error) {
+	return cb.do(req, cb.inner.Do)
+}
+
+// DoWithRetry inner가 RetryFetcher인 경우 inner의 재시도 설정으로 재시도하며, 재시도 횟수를 모두 소진한 요청만 실패 횟수에 포함된다.
+func (cb *circuitBreaker) DoWithRetry(req *http.Request, shouldRetry RetryPredicate) (*http.Response, error) {
+	return cb.do(req, func(req *http.Request) (*http.Response, error) {
+		return DoWithRetry(cb.inner, req, shouldRetry)
+	})
+}
+
+func (cb *circuitBreaker) do(req *http.Request, do func(req *http.Request) (*http.Response, error)) (*http.Response, error) {
+	host := req.URL.Host
+
+	if cb.allow(host) == false {
+		return nil, fmt.Errorf("%w(host:%s)", ErrCircuitOpen, host)
+	}
+
+	resp, err := do(req)
+
+	cb.record(host, err == nil && resp.StatusCode < http.StatusInternalServerError)
+
+	return resp, err
+}
+
+func (cb *circuitBreaker) allow(host string) bool {
+	cb.circuitsMu.Lock()
+	defer cb.circuitsMu.Unlock()
+
+	c, exists := cb.circuits[host]
+	if exists == false {
+		return true
+	}
+
+	switch c.state {
+	case circuitStateOpen:
+		if time.Since(c.openedTime) < cb.config.RecoveryTimeout {
+			return false
+		}
+
+		c.state = circuitStateHalfOpen
+
+		return true
+
+	case circuitStateHalfOpen:
+		// 복구 여부를 확인중인 요청이 끝날 때까지 다른 요청은 차단한다.
+		return false
+	}
+
+	return true
+}
+
+func (cb *circuitBreaker) record(host string, succeeded bool) {
+	cb.circuitsMu.Lock()
+	defer cb.circuitsMu.Unlock()
+
+	c, exists := cb.circuits[host]
+	if succeeded == true {
+		if exists == true {
+			if c.state != circuitStateClosed {
+				log.Infof("'%s' 호스트에 대한 요청 차단이 해제되었습니다.", host)
+			}
+			delete(cb.circuits, host)
+		}
+		return
+	}
+
+	if exists == false {
+		c = &circuit{state: circuitStateClosed}
+		cb.circuits[host] = c
+	}
+
+	c.consecutiveFailures++
+	if c.state == circuitStateHalfOpen || c.consecutiveFailures >= cb.config.FailureThreshold {
+		if c.state != circuitStateOpen {
+			log.Warnf("'%s' 호스트에 대한 요청이 %d회 연속 실패하여 %s 동안 요청을 차단합니다.", host, c.consecutiveFailures, cb.config.RecoveryTimeout)
+		}
+
+		c.state = circuitStateOpen
+		c.openedTime = time.Now()
+	}
+}
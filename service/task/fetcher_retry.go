@@ -0,0 +1,220 @@
+package task
+
+import (
+	"bytes"
+	_log_ "github.com/darkkaiser/notify-server/log"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type RetryConfig struct {
+	// 최대 재시도 횟수, 0인 경우 재시도하지 않는다.
+	MaxRetries int
+
+	// 첫 번째 재시도 전에 대기하는 시간(재시도할 때마다 2배씩 증가한다)
+	RetryDelay time.Duration
+
+	// 재시도 전에 대기하는 최대 시간, Retry-After 헤더로 전달된 시간도 이 시간을 넘지 않는다.
+	MaxRetryDelay time.Duration
+
+	// 재시도할 HTTP 응답 상태 코드 목록
+	RetryOnStatusCodes []int
+}
+
+// RetryPredicate 요청에 대한 응답 또는 오류를 확인하여 재시도 여부를 판단한다.
+// 네트워크 오류가 발생한 경우 resp는 nil이다.
+type RetryPredicate func(resp *http.Response, err error) bool
+
+// RetryFetcher 기본 재시도 조건 대신 요청마다 지정한 재시도 조건으로 재시도할 수 있는 Fetcher
+type RetryFetcher interface {
+	Fetcher
+
+	// DoWithRetry Do()와 같지만, 기본 재시도 조건을 만족하지 않더라도 shouldRetry를 만족하면 재시도한다.
+	// 재시도 횟수와 대기 시간은 Do()와 같으며, shouldRetry가 응답 본문을 읽을 수 있도록 응답 본문은 메모리에 저장된다.
+	// 반환되는 응답의 본문은 처음부터 다시 읽을 수 있다.
+	DoWithRetry(req *http.Request, shouldRetry RetryPredicate) (*http.Response, error)
+}
+
+// DoWithRetry f가 RetryFetcher인 경우 f.DoWithRetry()로 요청을 보내고, 그렇지 않은 경우 재시도하지 않고 f.Do()로 요청을 보낸다.
+func DoWithRetry(f Fetcher, req *http.Request, shouldRetry RetryPredicate) (*http.Response, error) {
+	if rf, ok := f.(RetryFetcher); ok == true {
+		return rf.DoWithRetry(req, shouldRetry)
+	}
+	return f.Do(req)
+}
+
+// bufferedBody 메모리에 저장된 응답 본문, 재시도 조건을 확인한 후 처음부터 다시 읽을 수 있도록 io.Seeker를 구현한다.
+type bufferedBody struct {
+	*bytes.Reader
+}
+
+func (b *bufferedBody) Close() error {
+	return nil
+}
+
+// bufferResponseBody 응답 본문을 모두 읽어서 메모리에 저장된 본문으로 교체한다.
+func bufferResponseBody(resp *http.Response) error {
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	resp.Body = &bufferedBody{Reader: bytes.NewReader(body)}
+
+	return nil
+}
+
+// retryFetcher 네트워크 오류가 발생하였거나 설정된 상태 코드로 응답한 요청을 재시도한다.
+type retryFetcher struct {
+	inner  Fetcher
+	config RetryConfig
+}
+
+func NewRetryFetcher(inner Fetcher, config RetryConfig) RetryFetcher {
+	return &retryFetcher{
+		inner:  inner,
+		config: config,
+	}
+}
+
+func (f *retryFetcher) Do(req *http.Request) (*http.Response, error) {
+	return f.do(req, nil)
+}
+
+func (f *retryFetcher) DoWithRetry(req *http.Request, shouldRetry RetryPredicate) (*http.Response, error) {
+	return f.do(req, shouldRetry)
+}
+
+// do 요청을 보내고 기본 재시도 조건 또는 shouldRetry를 만족하는 경우 재시도한다. shouldRetry는 nil일 수 있다.
+func (f *retryFetcher) do(req *http.Request, shouldRetry RetryPredicate) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			// 본문이 있는 요청은 본문을 다시 읽을 수 있는 경우에만 재시도한다.
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		resp, err := f.inner.Do(req)
+
+		if shouldRetry != nil && err == nil {
+			if err = bufferResponseBody(resp); err != nil {
+				resp = nil
+			}
+		}
+
+		if attempt >= f.config.MaxRetries || f.retryable(req, resp, err, shouldRetry) == false {
+			if resp != nil {
+				if body, ok := resp.Body.(*bufferedBody); ok == true {
+					_, _ = body.Seek(0, io.SeekStart)
+				}
+			}
+			return resp, err
+		}
+
+		delay := f.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok == true {
+				delay = retryAfter
+				if delay > f.config.MaxRetryDelay {
+					delay = f.config.MaxRetryDelay
+				}
+			}
+
+			// 재시도하기 전에 이전 응답의 연결을 재사용할 수 있도록 본문을 모두 읽고 닫는다.
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+			_ = resp.Body.Close()
+		}
+
+		entry := log.WithFields(log.Fields{
+			_log_.FieldRetryAttempt:      attempt + 1,
+			_log_.FieldRetryAfterSeconds: delay.Seconds(),
+		})
+		if err != nil {
+			entry.WithError(err).Warnf("'%s' 요청이 실패하여 재시도합니다.", req.URL.Host)
+		} else {
+			entry.Warnf("'%s' 요청에 대한 응답(%s)을 받아 재시도합니다.", req.URL.Host, resp.Status)
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+func (f *retryFetcher) retryable(req *http.Request, resp *http.Response, err error, shouldRetry RetryPredicate) bool {
+	// 요청이 취소되었거나 제한 시간이 지난 경우에는 재시도하지 않는다.
+	if req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if shouldRetry != nil && shouldRetry(resp, err) == true {
+		return true
+	}
+
+	if err != nil {
+		return true
+	}
+
+	for _, statusCode := range f.config.RetryOnStatusCodes {
+		if resp.StatusCode == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff 지수적으로 증가하는 대기 시간에 최대 50%의 무작위 시간(jitter)을 더한다.
+func (f *retryFetcher) backoff(attempt int) time.Duration {
+	delay := f.config.RetryDelay << uint(attempt)
+	if delay <= 0 || delay > f.config.MaxRetryDelay {
+		delay = f.config.MaxRetryDelay
+	}
+
+	if delay > 1 {
+		delay += time.Duration(rand.Int63n(int64(delay) / 2))
+	}
+	if delay > f.config.MaxRetryDelay {
+		delay = f.config.MaxRetryDelay
+	}
+
+	return delay
+}
+
+// parseRetryAfter Retry-After 헤더의 값(초 또는 HTTP 날짜)을 대기 시간으로 변환한다.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		delay := time.Until(t)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
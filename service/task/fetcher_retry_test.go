@@ -0,0 +1,122 @@
+package task
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// isErrorResponse 응답 본문에 오류가 포함되어 있는지 확인하는 테스트용 재시도 조건
+func isErrorResponse(resp *http.Response, err error) bool {
+	if err != nil {
+		return false
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return strings.Contains(string(body), `"error"`)
+}
+
+func TestRetryFetcher_DoWithRetry(t *testing.T) {
+	const url = "https://example.com/api"
+
+	// 처음 두 번의 요청은 본문에 오류를 담아 응답한다.
+	mock := NewMockHTTPFetcher()
+	mock.SetResponse(url, http.StatusOK, `{"error":"temporary"}`)
+	mock.SetResponse(url, http.StatusOK, `{"error":"temporary"}`)
+	mock.SetResponse(url, http.StatusOK, `{"html":"<ul></ul>"}`)
+
+	f := NewRetryFetcher(mock, RetryConfig{
+		MaxRetries:    3,
+		RetryDelay:    time.Millisecond,
+		MaxRetryDelay: time.Millisecond,
+	})
+
+	req, _ := http.NewRequest("GET", url, nil)
+	resp, err := DoWithRetry(f, req, isErrorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, mock.GetRequestCount(url))
+
+	// 재시도 조건을 확인하면서 읽은 본문을 처음부터 다시 읽을 수 있다.
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"html":"<ul></ul>"}`, string(body))
+
+	// 기본 재시도 조건에서는 200 응답을 재시도하지 않는다.
+	mock.SetResponse(url, http.StatusOK, `{"error":"temporary"}`)
+	req, _ = http.NewRequest("GET", url, nil)
+	resp, err = f.Do(req)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, 4, mock.GetRequestCount(url))
+
+	// shouldRetry를 만족하지 않더라도 기본 재시도 조건을 만족하면 재시도한다.
+	const errorURL = "https://example.com/error"
+	mock.SetError(errorURL, errors.New("connection reset"))
+	mock.SetResponse(errorURL, http.StatusOK, `{"html":"<ul></ul>"}`)
+	req, _ = http.NewRequest("GET", errorURL, nil)
+	resp, err = f.DoWithRetry(req, isErrorResponse)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, 2, mock.GetRequestCount(errorURL))
+}
+
+func TestDoWithRetry_DoesNotNestRetries(t *testing.T) {
+	const url = "https://example.com/api"
+
+	mock := NewMockHTTPFetcher()
+	mock.SetResponse(url, http.StatusOK, `{"error":"temporary"}`)
+
+	// 작업에서 사용하는 Fetcher와 같이 서킷 브레이커 안쪽의 retryFetcher 하나만 재시도하므로, 최대 1+MaxRetries번 요청한다.
+	f := NewTracingFetcher(NewCircuitBreaker(NewRetryFetcher(mock, RetryConfig{
+		MaxRetries:    2,
+		RetryDelay:    time.Millisecond,
+		MaxRetryDelay: time.Millisecond,
+	}), CircuitBreakerConfig{FailureThreshold: 1, RecoveryTimeout: time.Minute}))
+
+	req, _ := http.NewRequest("GET", url, nil)
+	resp, err := DoWithRetry(f, req, isErrorResponse)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, 3, mock.GetRequestCount(url))
+
+	// 재시도하지 않는 Fetcher는 한 번만 요청한다.
+	req, _ = http.NewRequest("GET", url, nil)
+	resp, err = DoWithRetry(mock, req, isErrorResponse)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, 4, mock.GetRequestCount(url))
+}
+
+func TestRetryFetcher_RetryOnStatusCodes(t *testing.T) {
+	const (
+		retryURL    = "https://example.com/retry"
+		notFoundURL = "https://example.com/not-found"
+	)
+
+	mock := NewMockHTTPFetcher()
+	mock.SetResponse(retryURL, http.StatusServiceUnavailable, "")
+	mock.SetResponse(retryURL, http.StatusOK, "ok")
+	mock.SetResponse(notFoundURL, http.StatusNotFound, "")
+
+	f := NewRetryFetcher(mock, RetryConfig{
+		MaxRetries:         2,
+		RetryDelay:         time.Millisecond,
+		MaxRetryDelay:      time.Millisecond,
+		RetryOnStatusCodes: []int{http.StatusServiceUnavailable},
+	})
+
+	for _, url := range []string{retryURL, notFoundURL} {
+		req, _ := http.NewRequest("GET", url, nil)
+		resp, err := f.Do(req)
+		assert.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	assert.Equal(t, 2, mock.GetRequestCount(retryURL))
+	assert.Equal(t, 1, mock.GetRequestCount(notFoundURL))
+	assert.Equal(t, []string{notFoundURL, retryURL}, mock.GetRequestedURLs())
+	assert.Equal(t, []string{retryURL, retryURL, notFoundURL}, mock.GetRequestedURLsOrdered())
+}
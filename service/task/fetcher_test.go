@@ -0,0 +1,55 @@
+package task
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetcherWithCookieJar(t *testing.T) {
+	// 세션 쿠키가 있는 요청에만 200으로 응답하는 서버
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s1", Path: "/"})
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/login-redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s1", Path: "/"})
+		http.Redirect(w, r, "/home", http.StatusFound)
+	})
+	mux.HandleFunc("/home", func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("session"); err != nil || c.Value != "s1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cases := map[string]struct {
+		inner    Fetcher
+		loginURL string
+	}{
+		"응답으로 받은 쿠키":       {inner: fetcherFunc(http.DefaultClient.Do), loginURL: "/login"},
+		"리다이렉트 응답으로 받은 쿠키": {inner: &http.Client{}, loginURL: "/login-redirect"},
+	}
+
+	for name, c := range cases {
+		f := NewFetcherWithCookieJar(c.inner, NewMemoryCookieJar())
+
+		req, _ := http.NewRequest(http.MethodGet, server.URL+c.loginURL, nil)
+		resp, err := f.Do(req)
+		assert.NoError(t, err, name)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, name)
+		resp.Body.Close()
+
+		// 이후의 요청에는 저장된 쿠키가 함께 전송된다.
+		req, _ = http.NewRequest(http.MethodGet, server.URL+"/home", nil)
+		resp, err = f.Do(req)
+		assert.NoError(t, err, name)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, name)
+		resp.Body.Close()
+	}
+}
@@ -0,0 +1,48 @@
+package task
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// IDGenerator 작업을 실행할 때마다 새로운 TaskInstanceID를 생성한다.
+type IDGenerator interface {
+	New() TaskInstanceID
+}
+
+// UUIDGenerator UUID(버전 4)로 TaskInstanceID를 생성한다.
+// TaskInstanceID는 텔레그램 봇 명령어('/cancel_{TaskInstanceID}')에도 사용되므로, 봇 명령어에 사용할 수 없는 '-' 문자는 제거한다.
+type UUIDGenerator struct{}
+
+func NewUUIDGenerator() *UUIDGenerator {
+	return &UUIDGenerator{}
+}
+
+func (g *UUIDGenerator) New() TaskInstanceID {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		// 난수 생성이 실패한 경우에는 현재 시각으로 생성하며, 중복된 ID는 Task 서비스에서 다시 생성된다.
+		return TaskInstanceID(strconv.FormatInt(time.Now().UnixNano(), 36))
+	}
+
+	return TaskInstanceID(strings.ReplaceAll(id.String(), "-", ""))
+}
+
+// SequentialIDGenerator 1부터 순서대로 증가하는 숫자로 TaskInstanceID를 생성한다.
+// 생성되는 TaskInstanceID를 예측할 수 있으므로 테스트에서만 사용하여야 한다.
+type SequentialIDGenerator struct {
+	prefix string
+	last   int64
+}
+
+func NewSequentialIDGenerator(prefix string) *SequentialIDGenerator {
+	return &SequentialIDGenerator{prefix: prefix}
+}
+
+func (g *SequentialIDGenerator) New() TaskInstanceID {
+	return TaskInstanceID(fmt.Sprintf("%s%d", g.prefix, atomic.AddInt64(&g.last, 1)))
+}
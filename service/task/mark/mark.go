@@ -0,0 +1,15 @@
+package mark
+
+import "fmt"
+
+// 알림메시지에서 항목의 변경 상태를 나타내는 표시
+const (
+	New      = " 🆕"
+	Modified = " 🔁"
+	Deleted  = " 🗑"
+)
+
+// WithCount 여러 개의 새로운 항목을 한 줄로 요약할 때 사용하는 표시를 반환한다.(예: ' 🆕 (3개)')
+func WithCount(n int) string {
+	return fmt.Sprintf("%s (%d개)", New, n)
+}
@@ -0,0 +1,28 @@
+package task
+
+import "time"
+
+// MetricsCollector Task 서비스의 내부 상태를 모니터링 시스템으로 전달한다.
+// 모든 메소드는 Task 서비스의 run0 고루틴에서 호출되므로 작업 실행이 지연되지 않도록 빠르게 반환되어야 한다.
+type MetricsCollector interface {
+	// TaskSubmitted 작업 실행 요청이 접수되었다.
+	TaskSubmitted(taskID, commandID string)
+	// TaskCompleted 작업 실행이 끝났다.
+	TaskCompleted(taskID, commandID string, duration time.Duration, success bool)
+	// TaskCancelled 작업이 취소되었다.
+	TaskCancelled(taskID, commandID string)
+	// QueueDepth 실행을 대기중인 작업의 수가 변경되었다.
+	QueueDepth(n int)
+}
+
+// NoopMetricsCollector 아무 일도 하지 않는 MetricsCollector로, MetricsCollector가 설정되지 않은 경우 사용된다.
+type NoopMetricsCollector struct{}
+
+func (NoopMetricsCollector) TaskSubmitted(taskID, commandID string) {}
+
+func (NoopMetricsCollector) TaskCompleted(taskID, commandID string, duration time.Duration, success bool) {
+}
+
+func (NoopMetricsCollector) TaskCancelled(taskID, commandID string) {}
+
+func (NoopMetricsCollector) QueueDepth(n int) {}
@@ -0,0 +1,137 @@
+package task
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type mockHTTPResponse struct {
+	statusCode int
+	body       string
+	err        error
+}
+
+// MockHTTPFetcher 등록된 응답을 반환하고, 요청된 URL을 기록하는 테스트용 Fetcher
+type MockHTTPFetcher struct {
+	mu sync.Mutex
+
+	// URL별로 등록된 응답, 등록된 순서대로 반환하며 마지막 응답은 계속 반환한다.
+	responses map[string][]mockHTTPResponse
+
+	// URL별로 응답하기 전에 대기하는 시간
+	delays map[string]time.Duration
+
+	requestedURLs []string
+	requestCounts map[string]int
+}
+
+func NewMockHTTPFetcher() *MockHTTPFetcher {
+	return &MockHTTPFetcher{
+		responses:     make(map[string][]mockHTTPResponse),
+		delays:        make(map[string]time.Duration),
+		requestCounts: make(map[string]int),
+	}
+}
+
+// SetResponse url의 요청에 반환할 응답을 추가한다.
+func (f *MockHTTPFetcher) SetResponse(url string, statusCode int, body string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.responses[url] = append(f.responses[url], mockHTTPResponse{statusCode: statusCode, body: body})
+}
+
+// SetError url의 요청에 반환할 오류를 추가한다.
+func (f *MockHTTPFetcher) SetError(url string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.responses[url] = append(f.responses[url], mockHTTPResponse{err: err})
+}
+
+// SetDelay url의 요청에 응답하기 전에 delay만큼 대기하도록 한다.
+// 대기하는 동안 요청의 컨텍스트가 취소되면 컨텍스트의 오류를 반환한다.
+func (f *MockHTTPFetcher) SetDelay(url string, delay time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.delays[url] = delay
+}
+
+func (f *MockHTTPFetcher) Do(req *http.Request) (*http.Response, error) {
+	url := req.URL.String()
+
+	f.mu.Lock()
+	f.requestedURLs = append(f.requestedURLs, url)
+	f.requestCounts[url]++
+	delay := f.delays[url]
+	f.mu.Unlock()
+
+	// 대기하는 동안 다른 요청이 처리될 수 있도록 잠금을 해제한 상태에서 대기한다.
+	if delay > 0 {
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	responses := f.responses[url]
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("등록된 응답이 없습니다.(URL:%s)", url)
+	}
+
+	r := responses[0]
+	if len(responses) > 1 {
+		f.responses[url] = responses[1:]
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", r.statusCode, http.StatusText(r.statusCode)),
+		StatusCode: r.statusCode,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(r.body)),
+		Request:    req,
+	}, nil
+}
+
+// GetRequestedURLs 요청된 URL을 중복없이 정렬하여 반환한다.
+func (f *MockHTTPFetcher) GetRequestedURLs() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	urls := make([]string, 0, len(f.requestCounts))
+	for url := range f.requestCounts {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+
+	return urls
+}
+
+// GetRequestedURLsOrdered 요청된 URL을 요청된 순서대로 중복을 포함하여 반환한다.
+func (f *MockHTTPFetcher) GetRequestedURLsOrdered() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.requestedURLs...)
+}
+
+// GetRequestCount url이 요청된 횟수를 반환한다.
+func (f *MockHTTPFetcher) GetRequestCount(url string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.requestCounts[url]
+}
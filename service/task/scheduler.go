@@ -1,15 +1,43 @@
 package task
 
 import (
+	"fmt"
 	"github.com/darkkaiser/notify-server/g"
+	"github.com/darkkaiser/notify-server/utils"
 	"github.com/robfig/cron/v3"
 	log "github.com/sirupsen/logrus"
+	"sort"
 	"sync"
+	"time"
 )
 
+type schedule struct {
+	taskID            TaskID
+	taskCommandID     TaskCommandID
+	timeSpec          string
+	defaultNotifierID string
+	entryID           cron.EntryID
+}
+
+// ScheduleInfo 스케쥴러에 등록된 스케쥴과 다음 실행 시간
+type ScheduleInfo struct {
+	TaskID    TaskID
+	CommandID TaskCommandID
+	TimeSpec  string
+
+	// 다음에 실행될 시간 목록, 실행 주기에 타임존이 지정된 경우 해당 타임존의 시간이다.
+	NextFireTimes []time.Time
+}
+
 type scheduler struct {
 	cron *cron.Cron
 
+	// 등록된 스케쥴 목록(키: TaskID::TaskCommandID)
+	schedules map[string]*schedule
+
+	taskRunner             TaskRunner
+	taskNotificationSender TaskNotificationSender
+
 	running   bool
 	runningMu sync.Mutex
 }
@@ -23,34 +51,31 @@ func (s *scheduler) Start(config *g.AppConfig, taskRunner TaskRunner, taskNotifi
 	}
 
 	s.cron = cron.New(cron.WithLogger(cron.VerbosePrintfLogger(log.StandardLogger())), cron.WithSeconds())
+	s.schedules = make(map[string]*schedule)
 
+	s.taskRunner = taskRunner
+	s.taskNotificationSender = taskNotificationSender
+
+	var timeSpecs []string
 	for _, t := range config.Tasks {
 		for _, c := range t.Commands {
 			if c.Scheduler.Runnable == false {
 				continue
 			}
 
-			// ※ 아래 구문을 func() 내부로 옮기게 되면 for 루프 마지막 항목의 값으로만 전달된다.
-			taskID := TaskID(t.ID)
-			taskCommandID := TaskCommandID(c.ID)
-			defaultNotifierID := c.DefaultNotifierID
-
-			_, err := s.cron.AddFunc(c.Scheduler.TimeSpec, func() {
-				if taskRunner.TaskRun(taskID, taskCommandID, defaultNotifierID, false, TaskRunByScheduler) == false {
-					m := "작업 스케쥴러에서의 작업 실행 요청이 실패하였습니다.😱"
-
-					log.Error(m)
-
-					taskNotificationSender.NotifyWithTaskContext(defaultNotifierID, m, NewContext().WithTask(taskID, taskCommandID).WithError())
-				}
-			})
-
-			if err != nil {
+			if err := s.addSchedule(TaskID(t.ID), TaskCommandID(c.ID), c.Scheduler.TimeSpec, c.DefaultNotifierID); err != nil {
 				log.Panic(err)
 			}
+
+			timeSpecs = append(timeSpecs, c.Scheduler.TimeSpec)
 		}
 	}
 
+	// 여러 작업이 동시에 실행되면 요청이 몰릴 수 있으므로 실행 시간이 겹치는 스케쥴을 알린다.
+	for _, conflict := range utils.ValidateCronConflict(timeSpecs) {
+		log.Warnf("실행 시간이 겹치는 Task 스케쥴이 있습니다.(TimeSpec:'%s', '%s', 실행 시간:%s)", conflict.First, conflict.Second, conflict.At.Format("2006-01-02 15:04:05"))
+	}
+
 	s.cron.Start()
 
 	s.running = true
@@ -73,3 +98,135 @@ func (s *scheduler) Stop() {
 
 	log.Debug("Task 스케쥴러 중지됨")
 }
+
+// Reload 변경된 환경설정 정보를 스케쥴러에 반영한다.
+// 실행 주기 또는 알림을 보낼 Notifier가 변경되었거나 새로 추가/삭제된 스케쥴만 갱신하며, 변경되지 않은 스케쥴은 그대로 유지한다.
+// 모든 스케쥴의 유효성을 먼저 확인하므로, 유효하지 않은 스케쥴이 있는 경우에는 기존 스케쥴을 변경하지 않는다.
+func (s *scheduler) Reload(config *g.AppConfig) error {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	if s.running == false {
+		return nil
+	}
+
+	type newSchedule struct {
+		taskID            TaskID
+		taskCommandID     TaskCommandID
+		timeSpec          string
+		defaultNotifierID string
+	}
+
+	newSchedules := make(map[string]*newSchedule)
+	for _, t := range config.Tasks {
+		for _, c := range t.Commands {
+			if c.Scheduler.Runnable == false {
+				continue
+			}
+
+			// 실행 주기의 유효성을 스케쥴러와 동일한 방식으로 미리 확인하여, 스케쥴을 변경하는 도중에 등록이 실패하지 않도록 한다.
+			if err := utils.ValidateCronExpressionWithTZ(c.Scheduler.TimeSpec); err != nil {
+				return fmt.Errorf("'%s::%s' Task의 %s", t.ID, c.ID, err)
+			}
+
+			key := scheduleKey(TaskID(t.ID), TaskCommandID(c.ID))
+			if _, exists := newSchedules[key]; exists == true {
+				return fmt.Errorf("'%s' Task 스케쥴이 중복되었습니다", key)
+			}
+
+			newSchedules[key] = &newSchedule{
+				taskID:            TaskID(t.ID),
+				taskCommandID:     TaskCommandID(c.ID),
+				timeSpec:          c.Scheduler.TimeSpec,
+				defaultNotifierID: c.DefaultNotifierID,
+			}
+		}
+	}
+
+	// 삭제되었거나 실행 주기 또는 Notifier가 변경된 스케쥴을 제거한다.
+	// 등록된 작업 함수는 Notifier를 캡쳐하고 있으므로 Notifier가 변경된 경우에도 다시 등록하여야 한다.
+	for key, sc := range s.schedules {
+		if ns, exists := newSchedules[key]; exists == false || ns.timeSpec != sc.timeSpec || ns.defaultNotifierID != sc.defaultNotifierID {
+			s.cron.Remove(sc.entryID)
+			delete(s.schedules, key)
+
+			log.Debugf("'%s' Task 스케쥴이 제거되었습니다.(TimeSpec:%s, NotifierID:%s)", key, sc.timeSpec, sc.defaultNotifierID)
+		}
+	}
+
+	// 새로 추가되었거나 실행 주기 또는 Notifier가 변경된 스케쥴을 등록한다.
+	for key, ns := range newSchedules {
+		if _, exists := s.schedules[key]; exists == true {
+			continue
+		}
+
+		if err := s.addSchedule(ns.taskID, ns.taskCommandID, ns.timeSpec, ns.defaultNotifierID); err != nil {
+			return err
+		}
+
+		log.Debugf("'%s' Task 스케쥴이 등록되었습니다.(TimeSpec:%s, NotifierID:%s)", key, ns.timeSpec, ns.defaultNotifierID)
+	}
+
+	return nil
+}
+
+func (s *scheduler) addSchedule(taskID TaskID, taskCommandID TaskCommandID, timeSpec, defaultNotifierID string) error {
+	entryID, err := s.cron.AddFunc(timeSpec, func() {
+		if s.taskRunner.TaskRun(taskID, taskCommandID, defaultNotifierID, false, TaskRunByScheduler) == false {
+			m := "작업 스케쥴러에서의 작업 실행 요청이 실패하였습니다.😱"
+
+			log.Error(m)
+
+			s.taskNotificationSender.NotifyWithTaskContext(defaultNotifierID, m, NewContext().WithTask(taskID, taskCommandID).WithError())
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	s.schedules[scheduleKey(taskID, taskCommandID)] = &schedule{
+		taskID:            taskID,
+		taskCommandID:     taskCommandID,
+		timeSpec:          timeSpec,
+		defaultNotifierID: defaultNotifierID,
+		entryID:           entryID,
+	}
+
+	return nil
+}
+
+// Schedules 등록된 스케쥴을 TaskID, TaskCommandID 순으로 정렬하여 각 스케쥴의 다음 실행 시간 n개와 함께 반환한다.
+func (s *scheduler) Schedules(n int) []*ScheduleInfo {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	now := time.Now()
+
+	infos := make([]*ScheduleInfo, 0, len(s.schedules))
+	for _, sc := range s.schedules {
+		nextFireTimes, err := utils.NextCronFireTimes(sc.timeSpec, now, n)
+		if err != nil {
+			log.Warnf("'%s::%s' Task 스케쥴의 다음 실행 시간을 구할 수 없습니다.(error:%s)", sc.taskID, sc.taskCommandID, err)
+		}
+
+		infos = append(infos, &ScheduleInfo{
+			TaskID:        sc.taskID,
+			CommandID:     sc.taskCommandID,
+			TimeSpec:      sc.timeSpec,
+			NextFireTimes: nextFireTimes,
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].TaskID != infos[j].TaskID {
+			return infos[i].TaskID < infos[j].TaskID
+		}
+		return infos[i].CommandID < infos[j].CommandID
+	})
+
+	return infos
+}
+
+func scheduleKey(taskID TaskID, taskCommandID TaskCommandID) string {
+	return fmt.Sprintf("%s::%s", taskID, taskCommandID)
+}
@@ -0,0 +1,114 @@
+package task
+
+import (
+	"encoding/json"
+	"github.com/darkkaiser/notify-server/g"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+// testSchedulerTaskRunner 스케쥴러가 요청한 작업 실행을 기록하는 테스트용 TaskRunner
+type testSchedulerTaskRunner struct {
+	TaskRunner
+
+	mu          sync.Mutex
+	notifierIDs []string
+}
+
+func (r *testSchedulerTaskRunner) TaskRun(_ TaskID, _ TaskCommandID, notifierID string, _ bool, _ TaskRunBy) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.notifierIDs = append(r.notifierIDs, notifierID)
+
+	return true
+}
+
+func newSchedulerTestConfig(t *testing.T, commands string) *g.AppConfig {
+	config := &g.AppConfig{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"tasks":[{"id":"T","commands":`+commands+`}]}`), config))
+	return config
+}
+
+// runScheduleJob 스케쥴러에 등록된 key의 작업 함수를 바로 실행한다.
+func runScheduleJob(s *scheduler, key string) {
+	s.cron.Entry(s.schedules[key].entryID).Job.Run()
+}
+
+func TestScheduler_Reload(t *testing.T) {
+	runner := &testSchedulerTaskRunner{}
+	s := &scheduler{}
+	s.Start(newSchedulerTestConfig(t, `[
+		{"id":"C1","scheduler":{"runnable":true,"time_spec":"0 0 9 * * *"},"default_notifier_id":"N1"},
+		{"id":"C2","scheduler":{"runnable":true,"time_spec":"0 0 10 * * *"},"default_notifier_id":"N1"},
+		{"id":"C3","scheduler":{"runnable":true,"time_spec":"0 0 11 * * *"},"default_notifier_id":"N1"}
+	]`), runner, &testTaskNotificationSender{})
+	defer s.Stop()
+
+	c1EntryID := s.schedules["T::C1"].entryID
+	c2EntryID := s.schedules["T::C2"].entryID
+	c3EntryID := s.schedules["T::C3"].entryID
+
+	// C1: 변경 없음, C2: 실행 주기 변경, C3: Notifier 변경, C4: 추가, C5: 실행하지 않는 스케쥴 추가
+	assert.NoError(t, s.Reload(newSchedulerTestConfig(t, `[
+		{"id":"C1","scheduler":{"runnable":true,"time_spec":"0 0 9 * * *"},"default_notifier_id":"N1"},
+		{"id":"C2","scheduler":{"runnable":true,"time_spec":"0 30 10 * * *"},"default_notifier_id":"N1"},
+		{"id":"C3","scheduler":{"runnable":true,"time_spec":"0 0 11 * * *"},"default_notifier_id":"N2"},
+		{"id":"C4","scheduler":{"runnable":true,"time_spec":"0 0 12 * * *"},"default_notifier_id":"N1"},
+		{"id":"C5","scheduler":{"runnable":false,"time_spec":"0 0 13 * * *"},"default_notifier_id":"N1"}
+	]`)))
+
+	assert.Len(t, s.schedules, 4)
+	assert.Len(t, s.cron.Entries(), 4)
+	assert.Equal(t, c1EntryID, s.schedules["T::C1"].entryID)
+	assert.NotEqual(t, c2EntryID, s.schedules["T::C2"].entryID)
+	assert.Equal(t, "0 30 10 * * *", s.schedules["T::C2"].timeSpec)
+	assert.NotEqual(t, c3EntryID, s.schedules["T::C3"].entryID)
+	assert.Equal(t, "N2", s.schedules["T::C3"].defaultNotifierID)
+	assert.Contains(t, s.schedules, "T::C4")
+	assert.NotContains(t, s.schedules, "T::C5")
+
+	// 다시 등록된 작업 함수는 변경된 Notifier로 작업을 실행한다.
+	runScheduleJob(s, "T::C3")
+	runScheduleJob(s, "T::C4")
+	assert.Equal(t, []string{"N2", "N1"}, runner.notifierIDs)
+
+	// C1, C3: 삭제
+	assert.NoError(t, s.Reload(newSchedulerTestConfig(t, `[
+		{"id":"C2","scheduler":{"runnable":true,"time_spec":"0 30 10 * * *"},"default_notifier_id":"N1"},
+		{"id":"C4","scheduler":{"runnable":true,"time_spec":"0 0 12 * * *"},"default_notifier_id":"N1"}
+	]`)))
+
+	assert.Len(t, s.schedules, 2)
+	assert.Len(t, s.cron.Entries(), 2)
+	assert.NotContains(t, s.schedules, "T::C1")
+	assert.NotContains(t, s.schedules, "T::C3")
+}
+
+func TestScheduler_Reload_InvalidConfig(t *testing.T) {
+	s := &scheduler{}
+	s.Start(newSchedulerTestConfig(t, `[
+		{"id":"C1","scheduler":{"runnable":true,"time_spec":"0 0 9 * * *"},"default_notifier_id":"N1"}
+	]`), &testSchedulerTaskRunner{}, &testTaskNotificationSender{})
+	defer s.Stop()
+
+	c1EntryID := s.schedules["T::C1"].entryID
+
+	// 유효하지 않은 스케쥴이 있으면 다른 스케쥴도 변경되지 않는다.
+	assert.Error(t, s.Reload(newSchedulerTestConfig(t, `[
+		{"id":"C1","scheduler":{"runnable":true,"time_spec":"0 0 10 * * *"},"default_notifier_id":"N2"},
+		{"id":"C2","scheduler":{"runnable":true,"time_spec":"0 0 12 * * *"},"default_notifier_id":"N1"},
+		{"id":"C3","scheduler":{"runnable":true,"time_spec":"invalid"},"default_notifier_id":"N1"}
+	]`)))
+	assert.Error(t, s.Reload(newSchedulerTestConfig(t, `[
+		{"id":"C2","scheduler":{"runnable":true,"time_spec":"0 0 12 * * *"},"default_notifier_id":"N1"},
+		{"id":"C2","scheduler":{"runnable":true,"time_spec":"0 0 13 * * *"},"default_notifier_id":"N1"}
+	]`)))
+
+	assert.Len(t, s.schedules, 1)
+	assert.Len(t, s.cron.Entries(), 1)
+	assert.Equal(t, c1EntryID, s.schedules["T::C1"].entryID)
+	assert.Equal(t, "0 0 9 * * *", s.schedules["T::C1"].timeSpec)
+	assert.Equal(t, "N1", s.schedules["T::C1"].defaultNotifierID)
+}
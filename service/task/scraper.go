@@ -0,0 +1,140 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/darkkaiser/notify-server/apperrors"
+	"golang.org/x/net/html/charset"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Scraper 외부 사이트의 HTML 페이지를 읽어들여 goquery.Document로 변환하거나, JSON 데이터를 읽어들인다.
+type Scraper interface {
+	// FetchHTMLDocument url의 페이지를 읽어들여 파싱한다. header는 요청에 추가할 HTTP 헤더이다.
+	FetchHTMLDocument(ctx context.Context, url string, header map[string]string) (*goquery.Document, error)
+
+	// FetchJSON url로 요청을 보내고 응답받은 JSON 데이터를 v로 변환한다.
+	// 'Accept'와 'Content-Type' 헤더는 기본으로 'application/json'이 설정되며, header로 덮어쓸 수 있다.
+	// 'Accept' 헤더는 작업에 설정된 HTTP 헤더에 포함되어 있는 경우에도 기본값을 설정하지 않는다.
+	// 2xx가 아닌 상태 코드로 응답한 경우 HTTPError를 포함한 에러를 반환한다.
+	FetchJSON(ctx context.Context, method, url string, body io.Reader, header map[string]string, v interface{}) error
+
+	// ParseHTML r에서 읽어들인 HTML을 파싱한다.
+	// contentType에 UTF-8이 아닌 문자셋이 지정된 경우 UTF-8로 변환하며, baseURL은 문서의 상대 경로 URL을 해석하는데 사용된다.
+	ParseHTML(ctx context.Context, r io.Reader, baseURL string, contentType string) (*goquery.Document, error)
+}
+
+// scraper fetcher로 페이지를 읽어들이고 goquery로 파싱하는 기본 Scraper
+type scraper struct {
+	fetcher Fetcher
+
+	// fetcher에서 요청에 추가되는 작업에 설정된 HTTP 헤더, FetchJSON()에서 설정하는 기본 헤더보다 우선한다.
+	taskHeader map[string]string
+}
+
+func NewScraper(fetcher Fetcher) Scraper {
+	return &scraper{fetcher: fetcher}
+}
+
+// noinspection GoUnhandledErrorResult
+func (s *scraper) FetchHTMLDocument(ctx context.Context, url string, header map[string]string) (*goquery.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, apperrors.New(ErrCodePageFetchFailed, fmt.Sprintf("페이지(%s) 접근이 실패하였습니다.", url), err)
+	}
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.fetcher.Do(req)
+	if err != nil {
+		return nil, apperrors.New(ErrCodePageFetchFailed, fmt.Sprintf("페이지(%s) 접근이 실패하였습니다.", url), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, apperrors.New(ErrCodePageFetchFailed, fmt.Sprintf("페이지(%s) 접근이 실패하였습니다.", url), errors.New(resp.Status))
+	}
+
+	return s.ParseHTML(ctx, resp.Body, resp.Request.URL.String(), resp.Header.Get("Content-Type"))
+}
+
+// noinspection GoUnhandledErrorResult
+func (s *scraper) FetchJSON(ctx context.Context, method, url string, body io.Reader, header map[string]string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return apperrors.New(ErrCodePageFetchFailed, fmt.Sprintf("페이지(%s) 접근이 실패하였습니다.", url), err)
+	}
+	if hasHeader(header, "Accept") == false && hasHeader(s.taskHeader, "Accept") == false {
+		req.Header.Set("Accept", "application/json")
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.fetcher.Do(req)
+	if err != nil {
+		return apperrors.New(ErrCodePageFetchFailed, fmt.Sprintf("페이지(%s) 접근이 실패하였습니다.", url), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, httpErrorBodyMaxBytes))
+		return apperrors.New(ErrCodePageFetchFailed, fmt.Sprintf("페이지(%s) 접근이 실패하였습니다.", url), &HTTPError{StatusCode: resp.StatusCode, Body: string(errBody)})
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return apperrors.New(ErrCodeResponseParseFailed, fmt.Sprintf("불러온 페이지(%s) 데이터를 읽을 수 없습니다.", url), err)
+	}
+
+	if err = json.Unmarshal(bodyBytes, v); err != nil {
+		return apperrors.New(ErrCodeResponseParseFailed, fmt.Sprintf("불러온 페이지(%s) 데이터의 JSON 변환이 실패하였습니다.", url), err)
+	}
+
+	return nil
+}
+
+// hasHeader header에 key 헤더가 포함되어 있는지 대소문자를 구분하지 않고 확인한다.
+func hasHeader(header map[string]string, key string) bool {
+	for k := range header {
+		if strings.EqualFold(k, key) == true {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *scraper) ParseHTML(_ context.Context, r io.Reader, baseURL string, contentType string) (*goquery.Document, error) {
+	// 문서에 지정된 문자셋을 추측하지 않고, Content-Type에 명시된 문자셋만 변환한다.
+	if _, params, err := mime.ParseMediaType(contentType); err == nil {
+		if cs := params["charset"]; cs != "" && strings.EqualFold(cs, "utf-8") == false {
+			if r, err = charset.NewReaderLabel(cs, r); err != nil {
+				return nil, fmt.Errorf("불러온 페이지(%s)의 문자셋(%s)을 변환할 수 없습니다.(error:%s)", baseURL, cs, err)
+			}
+		}
+	}
+
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, apperrors.New(ErrCodeResponseParseFailed, fmt.Sprintf("불러온 페이지(%s)의 데이터 파싱이 실패하였습니다.", baseURL), err)
+	}
+
+	if baseURL != "" {
+		if u, err := url.Parse(baseURL); err == nil {
+			doc.Url = u
+		}
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,81 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestScraper_FetchJSON(t *testing.T) {
+	const (
+		okURL       = "https://example.com/ok"
+		notFoundURL = "https://example.com/not-found"
+	)
+
+	mock := NewMockHTTPFetcher()
+	mock.SetResponse(okURL, http.StatusOK, `{"name":"notify-server"}`)
+	mock.SetResponse(notFoundURL, http.StatusNotFound, `{"message":"Not Found"}`)
+
+	var requestHeader http.Header
+	s := NewScraper(fetcherFunc(func(req *http.Request) (*http.Response, error) {
+		requestHeader = req.Header
+		return mock.Do(req)
+	}))
+
+	var v struct {
+		Name string `json:"name"`
+	}
+	err := s.FetchJSON(context.Background(), "POST", okURL, strings.NewReader(`{}`), map[string]string{"Accept": "application/vnd.github+json"}, &v)
+	assert.NoError(t, err)
+	assert.Equal(t, "notify-server", v.Name)
+	assert.Equal(t, "application/vnd.github+json", requestHeader.Get("Accept"))
+	assert.Equal(t, "application/json", requestHeader.Get("Content-Type"))
+
+	// 2xx가 아닌 상태 코드로 응답한 경우 HTTPError를 반환한다.
+	err = s.FetchJSON(context.Background(), "GET", notFoundURL, nil, nil, &v)
+	var httpErr *HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+	assert.Equal(t, `{"message":"Not Found"}`, httpErr.Body)
+	assert.Equal(t, "application/json", requestHeader.Get("Accept"))
+	assert.Equal(t, "", requestHeader.Get("Content-Type"))
+}
+
+func TestTask_FetchJSON_AcceptHeader(t *testing.T) {
+	const url = "https://example.com/ok"
+
+	mock := NewMockHTTPFetcher()
+	mock.SetResponse(url, http.StatusOK, `{}`)
+	useMockFetcher(t, mock)
+
+	// 작업에 설정된 HTTP 헤더가 추가된 이후의 요청 헤더를 확인한다.
+	var requestHeader http.Header
+	fetcher = fetcherFunc(func(req *http.Request) (*http.Response, error) {
+		requestHeader = req.Header
+		return mock.Do(req)
+	})
+
+	testCases := []struct {
+		name        string
+		taskHeaders map[string]string
+		header      map[string]string
+		expected    string
+	}{
+		{name: "기본값", expected: "application/json"},
+		{name: "작업에 설정된 헤더", taskHeaders: map[string]string{"accept": "application/xml"}, expected: "application/xml"},
+		{name: "요청 헤더가 작업에 설정된 헤더보다 우선", taskHeaders: map[string]string{"Accept": "application/xml"}, header: map[string]string{"Accept": "application/vnd.github+json"}, expected: "application/vnd.github+json"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tk := &task{headers: tc.taskHeaders}
+
+			var v struct{}
+			assert.NoError(t, tk.scraper().FetchJSON(context.Background(), "GET", url, nil, tc.header, &v))
+			assert.Equal(t, tc.expected, requestHeader.Get("Accept"))
+		})
+	}
+}
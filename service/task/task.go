@@ -2,13 +2,18 @@ package task
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/darkkaiser/notify-server/apperrors"
 	"github.com/darkkaiser/notify-server/g"
+	_log_ "github.com/darkkaiser/notify-server/log"
+	"github.com/darkkaiser/notify-server/metrics"
 	"github.com/darkkaiser/notify-server/utils"
 	log "github.com/sirupsen/logrus"
-	"os"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -27,6 +32,9 @@ const (
 	TaskCtxKeyTitle         = "Title"
 	TaskCtxKeyErrorOccurred = "ErrorOccurred"
 
+	// 작업 실행을 요청한 API 요청의 요청ID, 작업의 로그에 함께 출력된다.
+	TaskCtxKeyRequestID = "RequestID"
+
 	TaskCtxKeyTaskID              = "Task.TaskID"
 	TaskCtxKeyTaskCommandID       = "Task.TaskCommandID"
 	TaskCtxKeyTaskInstanceID      = "Task.TaskInstanceID"
@@ -36,51 +44,43 @@ const (
 const (
 	TaskRunByUser TaskRunBy = iota
 	TaskRunByScheduler
+	TaskRunByWebhook
 )
 
-var (
-	ErrNotSupportedTask               = errors.New("지원되지 않는 작업입니다")
-	ErrNotSupportedCommand            = errors.New("지원되지 않는 작업 커맨드입니다")
-	ErrNoImplementationForTaskCommand = errors.New("작업 커맨드에 대한 구현이 없습니다")
-)
-
-// taskInstanceIDGenerator
-type taskInstanceIDGenerator struct {
+func (r TaskRunBy) String() string {
+	switch r {
+	case TaskRunByUser:
+		return "user"
+	case TaskRunByScheduler:
+		return "scheduler"
+	case TaskRunByWebhook:
+		return "webhook"
+	}
+	return "unknown"
 }
 
-func (g *taskInstanceIDGenerator) New() TaskInstanceID {
-	return TaskInstanceID(g.toRadixNotation62String(time.Now().UnixNano()))
+// IsOnDemand 스케쥴러가 아닌 사용자나 외부 요청(Webhook)에 의해 실행된 작업인지를 반환한다.
+// 요청에 의해 실행된 작업은 변경된 내용이 없더라도 현재 상태를 알림메시지로 발송한다.
+func (r TaskRunBy) IsOnDemand() bool {
+	return r == TaskRunByUser || r == TaskRunByWebhook
 }
 
-func (g *taskInstanceIDGenerator) toRadixNotation62String(value int64) string {
-	if value < 0 {
-		return ""
-	}
-
-	var digits = []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9",
-		"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l", "m", "n", "o", "p", "q", "r", "s", "t", "u", "v", "w", "x", "y", "z",
-		"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L", "M", "N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z"}
-
-	var s []string
-	var radix = int64(len(digits))
-
-	for value > 0 {
-		s = append(s, digits[value%radix])
-		value = value / radix
-		if value == 0 {
-			break
-		}
-	}
-
-	return strings.Join(g.reverse(s), "")
-}
+// 작업 실행중 발생되는 apperrors.AppError의 오류 코드
+const (
+	ErrCodeSnapshotLoadFailed   = "SNAPSHOT_LOAD_FAILED"
+	ErrCodePageFetchFailed      = "PAGE_FETCH_FAILED"
+	ErrCodePageStructureChanged = "PAGE_STRUCTURE_CHANGED"
+	ErrCodeResponseParseFailed  = "RESPONSE_PARSE_FAILED"
+)
 
-func (g *taskInstanceIDGenerator) reverse(s []string) []string {
-	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
-		s[i], s[j] = s[j], s[i]
-	}
-	return s
-}
+var (
+	ErrNotSupportedTask               = errors.New("지원되지 않는 작업입니다")
+	ErrNotSupportedCommand            = errors.New("지원되지 않는 작업 커맨드입니다")
+	ErrNoImplementationForTaskCommand = errors.New("작업 커맨드에 대한 구현이 없습니다")
+	ErrTaskAlreadyRunning             = errors.New("요청하신 작업은 이미 진행중입니다")
+	ErrTaskCanceled                   = errors.New("사용자 요청에 의해 작업이 취소되었습니다")
+	ErrExecutionHistoryNotSupported   = errors.New("작업 실행 이력은 sqlite 저장소에서만 지원됩니다")
+)
 
 // supportedTasks
 type newTaskFunc func(TaskInstanceID, *taskRunData, *g.AppConfig) (taskHandler, error)
@@ -136,10 +136,46 @@ type task struct {
 
 	notifierID string
 
-	canceled bool
+	// 작업의 취소 여부, 작업을 실행하는 고루틴과 작업을 취소하는 고루틴에서 함께 접근하므로 canceledMu로 보호한다.
+	canceled   bool
+	canceledMu sync.Mutex
+
+	runBy TaskRunBy
 
-	runBy   TaskRunBy
-	runTime time.Time
+	// 작업 실행을 요청한 API 요청의 요청ID(API 요청으로 실행된 작업이 아닌 경우 빈 문자열이다)
+	requestID string
+
+	// 작업이 시작된 시각, 작업을 실행하는 고루틴을 시작하기 전에 설정되며 다른 고루틴에서도 읽으므로 runTimeMu로 보호한다.
+	runTime   time.Time
+	runTimeMu sync.Mutex
+
+	// 작업 실행이 실패한 경우의 오류, 작업이 끝난 후에 설정된다.
+	runErr error
+
+	// 작업에서 보내는 모든 HTTP 요청에 추가되는 헤더
+	headers map[string]string
+
+	// 작업의 실행 제한 시간, 0인 경우 제한하지 않는다.
+	timeout time.Duration
+
+	// 작업에서 보내는 모든 HTTP 요청에 사용할 프록시 서버, nil인 경우 전체 작업에 설정된 프록시 서버를 사용한다.
+	proxyURL *url.URL
+
+	// 작업 실행 스팬이 포함된 컨텍스트, 작업에서 보내는 HTTP 요청의 스팬은 이 스팬의 하위 스팬이 된다.
+	runCtx context.Context
+
+	// 작업 결과 메시지가 maxMessageLength 글자보다 긴 경우 여러 개의 메시지로 나누어 발송할지의 여부
+	// maxMessageLength가 0인 경우 defaultMaxMessageLength가 사용된다.
+	splitLongMessages bool
+	maxMessageLength  int
+
+	// 작업 결과 메시지와 별도로 발송할 메시지 목록
+	separateMessages []string
+
+	// 작업 실행을 요청한 곳의 컨텍스트, 컨텍스트가 취소되면 작업도 취소된다. nil인 경우 작업은 취소되지 않는다.
+	parentCtx context.Context
+	// 작업이 끝나면 작업 실행 결과를 전달받을 채널, 작업 실행 결과를 기다리지 않는 경우 nil이다.
+	resultC chan<- *TaskRunResult
 
 	runFn runFunc
 }
@@ -154,9 +190,67 @@ type taskHandler interface {
 	Cancel()
 	IsCanceled() bool
 
+	RunBy() TaskRunBy
+	RunTime() time.Time
 	ElapsedTimeAfterRun() int64
+	// RunErr 작업 실행이 실패한 경우 오류를 반환한다. 작업이 끝나기 전에는 nil을 반환한다.
+	RunErr() error
+
+	Run(taskResultStore TaskResultStore, taskNotificationSender TaskNotificationSender, taskStopWaiter *sync.WaitGroup, taskDoneC chan<- TaskInstanceID)
+
+	setRunTime(runTime time.Time)
+	setRequestID(requestID string)
+	setHeaders(headers map[string]string)
+	setTimeout(timeout time.Duration)
+	setProxyURL(proxyURL *url.URL)
+	setResultC(parentCtx context.Context, resultC chan<- *TaskRunResult)
+	sendResult(message string, err error)
+	isParentCtxDone() bool
+}
+
+func (t *task) setRunTime(runTime time.Time) {
+	t.runTimeMu.Lock()
+	defer t.runTimeMu.Unlock()
+
+	t.runTime = runTime
+}
+
+func (t *task) setRequestID(requestID string) {
+	t.requestID = requestID
+}
+
+func (t *task) setHeaders(headers map[string]string) {
+	t.headers = headers
+}
+
+func (t *task) setTimeout(timeout time.Duration) {
+	t.timeout = timeout
+}
+
+func (t *task) setProxyURL(proxyURL *url.URL) {
+	t.proxyURL = proxyURL
+}
+
+func (t *task) setResultC(parentCtx context.Context, resultC chan<- *TaskRunResult) {
+	t.parentCtx = parentCtx
+	t.resultC = resultC
+}
+
+// isParentCtxDone 작업 실행을 요청한 곳의 컨텍스트가 취소되었는지 확인한다.
+func (t *task) isParentCtxDone() bool {
+	return t.parentCtx != nil && t.parentCtx.Err() != nil
+}
+
+// sendResult 작업 실행 결과를 기다리는 곳이 있는 경우 작업 실행 결과를 전달한다.
+func (t *task) sendResult(message string, err error) {
+	if t.resultC == nil {
+		return
+	}
 
-	Run(taskNotificationSender TaskNotificationSender, taskStopWaiter *sync.WaitGroup, taskDoneC chan<- TaskInstanceID)
+	select {
+	case t.resultC <- &TaskRunResult{InstanceID: t.instanceID, Message: message, Err: err}:
+	default:
+	}
 }
 
 func (t *task) ID() TaskID {
@@ -176,18 +270,39 @@ func (t *task) NotifierID() string {
 }
 
 func (t *task) Cancel() {
+	t.canceledMu.Lock()
+	defer t.canceledMu.Unlock()
+
 	t.canceled = true
 }
 
 func (t *task) IsCanceled() bool {
+	t.canceledMu.Lock()
+	defer t.canceledMu.Unlock()
+
 	return t.canceled
 }
 
+func (t *task) RunBy() TaskRunBy {
+	return t.runBy
+}
+
+func (t *task) RunTime() time.Time {
+	t.runTimeMu.Lock()
+	defer t.runTimeMu.Unlock()
+
+	return t.runTime
+}
+
 func (t *task) ElapsedTimeAfterRun() int64 {
-	return int64(time.Now().Sub(t.runTime).Seconds())
+	return int64(time.Now().Sub(t.RunTime()).Seconds())
+}
+
+func (t *task) RunErr() error {
+	return t.runErr
 }
 
-func (t *task) Run(taskNotificationSender TaskNotificationSender, taskStopWaiter *sync.WaitGroup, taskDoneC chan<- TaskInstanceID) {
+func (t *task) Run(taskResultStore TaskResultStore, taskNotificationSender TaskNotificationSender, taskStopWaiter *sync.WaitGroup, taskDoneC chan<- TaskInstanceID) {
 	const errString = "작업 진행중 오류가 발생하여 작업이 실패하였습니다.😱"
 
 	defer taskStopWaiter.Done()
@@ -195,14 +310,67 @@ func (t *task) Run(taskNotificationSender TaskNotificationSender, taskStopWaiter
 		taskDoneC <- t.instanceID
 	}()
 
-	t.runTime = time.Now()
+	// 작업 실행 요청을 거치지 않고 바로 실행된 경우에는 작업이 시작된 시각이 설정되어 있지 않다.
+	if t.RunTime().IsZero() == true {
+		t.setRunTime(time.Now())
+	}
+
+	metrics.TasksRunning.Inc()
+	defer metrics.TasksRunning.Dec()
+
+	parentCtx := t.parentCtx
+	if parentCtx == nil {
+		parentCtx = context.Background()
+	}
+
+	var span trace.Span
+	t.runCtx, span = tracer.Start(parentCtx, "task.run", trace.WithAttributes(taskRunSpanAttributes(t)...))
+
+	// 실행 제한 시간이 지나면 작업을 취소한다.
+	// 작업에서 보내는 HTTP 요청에도 제한 시간이 적용되므로 진행중인 요청은 바로 실패한다.
+	if t.timeout > 0 {
+		var cancel context.CancelFunc
+		t.runCtx, cancel = context.WithTimeout(t.runCtx, t.timeout)
+		defer cancel()
+	}
+
+	// 실행 제한 시간이 지났거나 작업 실행을 요청한 곳의 컨텍스트가 취소되면 작업을 취소한다.
+	runDoneC := make(chan struct{})
+	defer close(runDoneC)
+	go func(ctx context.Context) {
+		select {
+		case <-ctx.Done():
+			t.Cancel()
+		case <-runDoneC:
+		}
+	}(t.runCtx)
+
+	// 작업이 끝나면 작업 실행 이력을 저장하고, 작업 실행 결과를 기다리는 곳에 결과를 전달한다.
+	var runErr error
+	var resultMessage string
+	var messageLength int
+	defer func() {
+		if runErr != nil {
+			span.RecordError(runErr)
+			span.SetStatus(codes.Error, runErr.Error())
+		}
+		span.End()
+
+		t.runErr = runErr
+
+		t.saveExecutionHistory(taskResultStore, runErr, messageLength)
+
+		t.sendResult(resultMessage, runErr)
+	}()
 
 	var taskCtx = NewContext().WithTask(t.ID(), t.CommandID())
 
 	if t.runFn == nil {
 		m := fmt.Sprintf("%s\n\n☑ runFn()이 초기화되지 않았습니다.", errString)
 
-		log.Error(m)
+		runErr = errors.New("runFn()이 초기화되지 않았습니다")
+
+		t.Log().Error(m)
 		t.notifyError(taskNotificationSender, m, taskCtx)
 
 		return
@@ -221,79 +389,173 @@ func (t *task) Run(taskNotificationSender TaskNotificationSender, taskStopWaiter
 	if taskResultData == nil {
 		m := fmt.Sprintf("%s\n\n☑ 작업결과데이터 생성이 실패하였습니다.", errString)
 
-		log.Error(m)
+		runErr = errors.New("작업결과데이터 생성이 실패하였습니다")
+
+		t.Log().Error(m)
 		t.notifyError(taskNotificationSender, m, taskCtx)
 
 		return
 	}
-	err := t.readTaskResultDataFromFile(taskResultData)
+	err := taskResultStore.Load(t.ID(), t.CommandID(), taskResultData)
 	if err != nil {
-		m := fmt.Sprintf("이전 작업결과데이터 로딩이 실패하였습니다.😱\n\n☑ %s\n\n빈 작업결과데이터를 이용하여 작업을 계속 진행합니다.", err)
+		appErr := apperrors.New(ErrCodeSnapshotLoadFailed, "이전 작업결과데이터를 읽을 수 없습니다.", err)
+		m := fmt.Sprintf("이전 작업결과데이터 로딩이 실패하였습니다.😱\n\n☑ %s\n\n빈 작업결과데이터를 이용하여 작업을 계속 진행합니다.", appErr.UserMessage)
 
-		log.Warn(m)
+		t.Log().WithError(appErr.InternalError).WithField("error_code", appErr.Code).Warn(m)
 		t.notify(taskNotificationSender, m, taskCtx)
 	}
 
 	if message, changedTaskResultData, err := t.runFn(taskResultData, taskNotificationSender.SupportHTMLMessage(t.notifierID)); t.IsCanceled() == false {
+		runErr = err
+		resultMessage = message
+		messageLength = len(message)
+
 		if err == nil {
 			if len(message) > 0 {
-				t.notify(taskNotificationSender, message, taskCtx)
+				t.notifyBatch(taskNotificationSender, t.splitMessage(message), taskCtx)
+			}
+			for _, separateMessage := range t.separateMessages {
+				t.notifyBatch(taskNotificationSender, t.splitMessage(separateMessage), taskCtx)
+
+				if resultMessage != "" {
+					resultMessage += "\n\n"
+				}
+				resultMessage += separateMessage
 			}
 
 			if changedTaskResultData != nil {
-				if err := t.writeTaskResultDataToFile(changedTaskResultData); err != nil {
+				if err := taskResultStore.Save(t.ID(), t.CommandID(), changedTaskResultData); err != nil {
 					m := fmt.Sprintf("작업이 끝난 작업결과데이터의 저장이 실패하였습니다.😱\n\n☑ %s", err)
 
-					log.Warn(m)
+					t.Log().Warn(m)
 					t.notifyError(taskNotificationSender, m, taskCtx)
 				}
 			}
 		} else {
+			// AppError인 경우 사용자에게는 UserMessage만 보여주고, 내부 오류는 로그에만 기록한다.
+			if appErr, ok := apperrors.As(err); ok == true {
+				m := fmt.Sprintf("%s\n\n☑ %s", errString, appErr.UserMessage)
+
+				t.Log().WithError(appErr.InternalError).WithField("error_code", appErr.Code).Error(m)
+				t.notifyError(taskNotificationSender, m, taskCtx)
+
+				return
+			}
+
 			m := fmt.Sprintf("%s\n\n☑ %s", errString, err)
 
-			log.Error(m)
+			t.Log().Error(m)
 			t.notifyError(taskNotificationSender, m, taskCtx)
 
 			return
 		}
+	} else if t.timeout > 0 && errors.Is(t.runCtx.Err(), context.DeadlineExceeded) == true && parentCtx.Err() == nil {
+		m := fmt.Sprintf("%s\n\n☑ 작업 실행 시간 초과(제한 시간:%s)", errString, t.timeout)
+
+		runErr = fmt.Errorf("작업 실행 시간 초과(제한 시간:%s)", t.timeout)
+
+		t.Log().Error(m)
+		t.notifyError(taskNotificationSender, m, taskCtx)
+	} else {
+		runErr = ErrTaskCanceled
 	}
 }
 
-func (t *task) notify(taskNotificationSender TaskNotificationSender, m string, taskCtx TaskContext) bool {
-	return taskNotificationSender.NotifyWithTaskContext(t.NotifierID(), m, taskCtx)
+// addSeparateMessage 작업 결과 메시지를 발송한 후에 별도의 메시지로 발송할 메시지를 추가한다.
+// 작업이 실패하거나 취소된 경우에는 발송되지 않는다.
+func (t *task) addSeparateMessage(message string) {
+	t.separateMessages = append(t.separateMessages, message)
 }
 
-func (t *task) notifyError(taskNotificationSender TaskNotificationSender, m string, taskCtx TaskContext) bool {
-	return taskNotificationSender.NotifyWithTaskContext(t.NotifierID(), m, taskCtx.WithError())
+// splitMessage 긴 메시지 나누기가 설정된 작업인 경우, 작업 결과 메시지를 항목의 경계에서 여러 개의 메시지로 나누고 '(1/N)' 형식의 순번을 붙인다.
+func (t *task) splitMessage(message string) []string {
+	if t.splitLongMessages == false {
+		return []string{message}
+	}
+
+	maxMessageLength := t.maxMessageLength
+	if maxMessageLength <= 0 {
+		maxMessageLength = defaultMaxMessageLength
+	}
+
+	// 순번 및 알림메시지의 제목이 추가될 수 있으므로 여유분을 제외하고 나눈다.
+	messages := utils.SplitMessage(message, maxMessageLength-messageLengthMargin)
+	if len(messages) > 1 {
+		for i := range messages {
+			messages[i] = fmt.Sprintf("(%d/%d)\n%s", i+1, len(messages), messages[i])
+		}
+	}
+
+	return messages
 }
 
-func (t *task) dataFileName() string {
-	filename := fmt.Sprintf("%s-task-%s-%s.json", g.AppName, utils.ToSnakeCase(string(t.ID())), utils.ToSnakeCase(string(t.CommandID())))
-	return strings.ReplaceAll(filename, "_", "-")
+// Log 작업의 TaskID, CommandID, InstanceID, RunBy 필드가 포함된 로그 Entry를 반환한다.
+// 작업에서 남기는 로그는 모두 이 Entry를 사용하여 작업별로 로그를 검색할 수 있도록 한다.
+func (t *task) Log() *log.Entry {
+	return _log_.WithTaskContext(_log_.TaskContext{
+		TaskID:     string(t.ID()),
+		CommandID:  string(t.CommandID()),
+		InstanceID: string(t.InstanceID()),
+		RunBy:      t.RunBy().String(),
+		RequestID:  t.requestID,
+	})
 }
 
-func (t *task) readTaskResultDataFromFile(v interface{}) error {
-	data, err := os.ReadFile(t.dataFileName())
-	if err != nil {
-		// 아직 데이터 파일이 생성되기 전이라면 nil을 반환한다.
-		var pathError *os.PathError
-		if errors.As(err, &pathError) == true {
-			return nil
-		}
+// saveExecutionHistory 작업결과데이터 저장소가 작업 실행 이력의 저장을 지원하는 경우, 작업 실행 이력을 저장한다.
+func (t *task) saveExecutionHistory(taskResultStore TaskResultStore, runErr error, messageLength int) {
+	historyStore, ok := taskResultStore.(TaskExecutionHistoryStore)
+	if ok == false {
+		return
+	}
 
-		return err
+	finishedAt := time.Now()
+	history := &TaskExecutionHistory{
+		TaskID:        t.ID(),
+		CommandID:     t.CommandID(),
+		InstanceID:    t.InstanceID(),
+		RunBy:         t.RunBy(),
+		StartedAt:     t.RunTime(),
+		FinishedAt:    finishedAt,
+		DurationMs:    finishedAt.Sub(t.RunTime()).Milliseconds(),
+		Success:       runErr == nil,
+		MessageLength: messageLength,
+	}
+	if runErr != nil {
+		history.Error = runErr.Error()
+	}
+
+	if err := historyStore.SaveExecutionHistory(history); err != nil {
+		t.Log().WithError(err).Warn("Task의 실행 이력 저장이 실패하였습니다.")
 	}
+}
 
-	return json.Unmarshal(data, v)
+func (t *task) notify(taskNotificationSender TaskNotificationSender, m string, taskCtx TaskContext) bool {
+	return taskNotificationSender.NotifyWithTaskContext(t.NotifierID(), m, taskCtx)
 }
 
-func (t *task) writeTaskResultDataToFile(v interface{}) error {
-	data, err := json.MarshalIndent(v, "", "\t")
-	if err != nil {
-		return err
+// notifyBatch 나누어진 작업 결과 메시지를 한 번에 발송한다.
+func (t *task) notifyBatch(taskNotificationSender TaskNotificationSender, messages []string, taskCtx TaskContext) {
+	// 나누어진 메시지가 Notifier에서 다시 합쳐지더라도 작업에 설정된 최대 글자수를 넘지 않도록 한다.
+	maxLength := 0
+	if t.splitLongMessages == true {
+		maxLength = t.maxMessageLength
+		if maxLength <= 0 {
+			maxLength = defaultMaxMessageLength
+		}
 	}
 
-	return os.WriteFile(t.dataFileName(), data, os.FileMode(0644))
+	notifications := make([]Notification, 0, len(messages))
+	for _, m := range messages {
+		notifications = append(notifications, Notification{NotifierID: t.NotifierID(), Message: m, TaskCtx: taskCtx, MaxLength: maxLength})
+	}
+
+	if err := taskNotificationSender.NotifyBatch(notifications); err != nil {
+		t.Log().WithError(err).Warn("작업 결과 메시지의 발송이 실패하였습니다.")
+	}
+}
+
+func (t *task) notifyError(taskNotificationSender TaskNotificationSender, m string, taskCtx TaskContext) bool {
+	return taskNotificationSender.NotifyWithTaskContext(t.NotifierID(), m, taskCtx.WithError())
 }
 
 // TaskContext
@@ -353,6 +615,45 @@ type taskRunData struct {
 	notifyResultOfTaskRunRequest bool
 
 	taskRunBy TaskRunBy
+
+	// 작업 실행 결과를 기다리는 경우 작업 실행을 요청한 곳의 컨텍스트와 작업 실행 결과를 전달받을 채널
+	ctx     context.Context
+	resultC chan<- *TaskRunResult
+}
+
+// requestID 작업 실행을 요청한 API 요청의 요청ID를 반환한다.
+// 작업 실행을 요청한 곳의 컨텍스트에 저장된 요청ID를 우선하며, 없는 경우 TaskContext에 저장된 요청ID를 반환한다.
+func (d *taskRunData) requestID() string {
+	if requestID := _log_.RequestIDFromContext(d.ctx); requestID != "" {
+		return requestID
+	}
+
+	if d.taskCtx != nil {
+		if requestID, ok := d.taskCtx.Value(TaskCtxKeyRequestID).(string); ok == true {
+			return requestID
+		}
+	}
+
+	return ""
+}
+
+// sendResult 작업이 실행되지 못한 경우, 작업 실행 결과를 기다리는 곳에 실패 사유를 전달한다.
+func (d *taskRunData) sendResult(err error) {
+	if d.resultC == nil {
+		return
+	}
+
+	select {
+	case d.resultC <- &TaskRunResult{Err: err}:
+	default:
+	}
+}
+
+// TaskRunResult 작업 실행 결과
+type TaskRunResult struct {
+	InstanceID TaskInstanceID
+	Message    string
+	Err        error
 }
 
 // TaskRunner
@@ -360,16 +661,99 @@ type TaskRunner interface {
 	TaskRun(taskID TaskID, taskCommandID TaskCommandID, notifierID string, notifyResultOfTaskRunRequest bool, taskRunBy TaskRunBy) (succeeded bool)
 	TaskRunWithContext(taskID TaskID, taskCommandID TaskCommandID, taskCtx TaskContext, notifierID string, notifyResultOfTaskRunRequest bool, taskRunBy TaskRunBy) (succeeded bool)
 	TaskCancel(taskInstanceID TaskInstanceID) (succeeded bool)
+
+	// TaskRunOnce 작업을 실행하고 작업이 끝날 때까지 기다린 후 작업 결과 메시지를 반환한다.
+	// ctx가 취소되거나 제한 시간이 지나면 실행중인 작업을 취소하고 ctx의 에러를 반환한다.
+	TaskRunOnce(ctx context.Context, taskID TaskID, taskCommandID TaskCommandID, notifierID string) (*TaskRunResult, error)
+
+	// TaskResultDataDelete 저장된 작업결과데이터를 삭제한다. 이후 실행되는 작업은 최초 실행과 동일하게 동작한다.
+	// 실행중(대기중 포함)인 작업이 있는 경우에는 작업이 완료되면서 작업결과데이터를 다시 저장하므로 삭제하지 않고 ErrTaskAlreadyRunning을 반환한다.
+	TaskResultDataDelete(taskID TaskID, taskCommandID TaskCommandID) error
+
+	// Pause 스케쥴러에 의한 작업 실행을 일시 중지한다. 사용자가 요청한 작업은 계속 실행된다.
+	Pause() error
+	// Resume 일시 중지된 스케쥴러에 의한 작업 실행을 재개한다.
+	Resume() error
+}
+
+// TaskMonitor
+type TaskMonitor interface {
+	RunningTasks() []*RunningTaskInfo
+	TaskInstanceStatus(taskInstanceID TaskInstanceID) TaskInstanceStatus
+	TaskExecutionHistories(taskID TaskID, limit int) ([]*TaskExecutionHistory, error)
+
+	// TaskResultData 저장된 작업결과데이터를 읽어들인다. 저장된 데이터가 없는 경우 빈 작업결과데이터를 반환한다.
+	TaskResultData(taskID TaskID, taskCommandID TaskCommandID) (interface{}, error)
+
+	// Health Task 서비스가 정상적으로 동작중인지 확인한다.
+	Health() error
+
+	// IsPaused 스케쥴러에 의한 작업 실행이 일시 중지된 상태인지 확인한다.
+	IsPaused() bool
+
+	// GetRunningTaskCount 현재 실행중인 작업의 갯수를 반환한다.
+	GetRunningTaskCount() int
+
+	// Schedules 스케쥴러에 등록된 스케쥴과 각 스케쥴의 다음 실행 시간 n개를 반환한다.
+	Schedules(n int) []*ScheduleInfo
+}
+
+type TaskInstanceStatus int
+
+const (
+	TaskInstanceStatusNotFound TaskInstanceStatus = iota
+	TaskInstanceStatusRunning
+	TaskInstanceStatusCompleted
+)
+
+// 작업이 완료된 TaskInstanceID를 보관하는 최대 갯수
+const maxCompletedTaskInstanceIDs = 100
+
+// RunningTaskInfo
+type RunningTaskInfo struct {
+	InstanceID TaskInstanceID
+	TaskID     TaskID
+	CommandID  TaskCommandID
+	RunBy      TaskRunBy
+	RunTime    time.Time
 }
 
 // TaskNotificationSender
+// Notification 여러 개의 알림메시지를 한 번에 발송할 때 사용하는 알림메시지
+type Notification struct {
+	NotifierID string
+	Message    string
+	TaskCtx    TaskContext
+
+	// 다른 알림메시지와 합쳐서 발송할 때 합쳐진 알림메시지의 최대 글자수(0인 경우 Notifier의 최대 글자수까지 합친다)
+	MaxLength int
+}
+
 type TaskNotificationSender interface {
 	NotifyToDefault(message string) bool
 	NotifyWithTaskContext(notifierID string, message string, taskCtx TaskContext) bool
+	NotifyBatch(notifications []Notification) error
 
 	SupportHTMLMessage(notifierID string) bool
 }
 
+const (
+	// 메시지 나누기가 설정된 작업에서 한 번에 발송하는 메시지의 기본 최대 글자수(텔레그램 메시지의 최대 글자수는 4096자이다)
+	defaultMaxMessageLength = 4000
+
+	// 메시지를 나눌 때 순번 및 알림메시지의 제목을 위해 남겨두는 글자수
+	messageLengthMargin = 200
+
+	// 알림메시지에 포함되는 상품명, 공연명 등의 최대 글자수
+	itemTitleMaxLength = 100
+)
+
+// 실행중인 작업의 갯수를 조회할 때 run0 고루틴의 응답을 기다리는 최대 시간
+const taskQueryTimeout = 3 * time.Second
+
+// ErrTaskQueueFull 동시에 실행할 수 있는 작업의 수를 초과하였고, 작업 대기열도 가득 찬 경우 반환된다.
+var ErrTaskQueueFull = errors.New("동시에 실행중인 작업이 많아 작업 대기열이 가득 찼습니다")
+
 // TaskService
 type TaskService struct {
 	config *g.AppConfig
@@ -377,22 +761,99 @@ type TaskService struct {
 	running   bool
 	runningMu sync.Mutex
 
+	// 유지보수 등을 위하여 스케쥴러에 의한 작업 실행이 일시 중지된 상태인지의 여부
+	paused bool
+
 	scheduler scheduler
 
 	taskHandlers map[TaskInstanceID]taskHandler
 
-	taskInstanceIDGenerator taskInstanceIDGenerator
+	// 다중 인스턴스의 생성이 허용되지 않는 작업 중에서 실행중(실행 대기중 포함)인 작업의 TaskInstanceID(runningTaskKey → TaskInstanceID)
+	// 동일한 작업이 동시에 실행되지 않도록 run0 고루틴에서만 접근하며, 작업이 완료되어 taskDoneC를 수신하면 삭제된다.
+	runningTaskKeys map[string]TaskInstanceID
+
+	// 최근에 작업이 완료(취소 포함)된 TaskInstanceID 목록
+	completedTaskInstanceIDs []TaskInstanceID
+
+	idGenerator IDGenerator
 
 	taskNotificationSender TaskNotificationSender
 
+	taskResultStore TaskResultStore
+
+	metricsCollector MetricsCollector
+
+	// 동시에 실행할 수 있는 작업의 수를 제한하는 세마포어, 제한하지 않는 경우 nil이다.
+	taskSemaphore chan struct{}
+	// 세마포어를 얻지 못하여 실행을 대기중인 작업 목록
+	taskQueue []taskHandler
+
+	tracerProvider *sdktrace.TracerProvider
+
 	taskRunC    chan *taskRunData
 	taskDoneC   chan TaskInstanceID
 	taskCancelC chan TaskInstanceID
 
+	// 실행중인 작업의 갯수를 run0 고루틴에게 요청하고, 응답받을 채널을 전달한다.
+	taskQueryC chan chan int
+
+	configReloadC chan struct{}
+
+	// 작업 이벤트를 전달받을 채널 목록
+	eventSubscribers   []chan<- TaskEvent
+	eventSubscribersMu sync.Mutex
+
 	taskStopWaiter *sync.WaitGroup
 }
 
-func NewService(config *g.AppConfig) *TaskService {
+// NewService Task 서비스를 생성한다. idGenerator가 nil인 경우 UUIDGenerator를 사용한다.
+func NewService(config *g.AppConfig, idGenerator IDGenerator) *TaskService {
+	if idGenerator == nil {
+		idGenerator = NewUUIDGenerator()
+	}
+
+	taskResultStore, err := newTaskResultStore(config)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	tracerProvider, err := newTracerProvider(config.Telemetry.OTLPEndpoint)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	// 장애가 발생한 사이트로의 요청이 작업을 지연시키지 않도록 서킷 브레이커를 적용한다.
+	httpClientConfig := config.Fetcher.HTTPClient
+	// 일시적인 오류는 재시도하며, 재시도 횟수를 모두 소진한 요청만 서킷 브레이커의 실패 횟수에 포함된다.
+	retryConfig := config.Fetcher.Retry
+	baseFetcher, err := NewFetcher(FetcherConfig{
+		MaxIdleConns:        httpClientConfig.MaxIdleConns,
+		MaxIdleConnsPerHost: httpClientConfig.MaxIdleConnsPerHost,
+		IdleConnTimeout:     time.Duration(httpClientConfig.IdleConnTimeoutSeconds) * time.Second,
+		RequestTimeout:      time.Duration(httpClientConfig.RequestTimeoutSeconds) * time.Second,
+		DialTimeout:         time.Duration(httpClientConfig.DialTimeoutSeconds) * time.Second,
+		ProxyURL:            httpClientConfig.ProxyURL,
+		CACertFile:          httpClientConfig.CACertFile,
+		InsecureSkipVerify:  httpClientConfig.InsecureSkipVerify,
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+	fetcher = NewTracingFetcher(NewCircuitBreaker(NewRetryFetcher(NewFetcherWithUserAgentRotation(baseFetcher, httpClientConfig.UserAgents), RetryConfig{
+		MaxRetries:         *retryConfig.MaxRetries,
+		RetryDelay:         time.Duration(retryConfig.RetryDelaySeconds) * time.Second,
+		MaxRetryDelay:      time.Duration(retryConfig.MaxRetryDelaySeconds) * time.Second,
+		RetryOnStatusCodes: retryConfig.RetryOnStatusCodes,
+	}), CircuitBreakerConfig{
+		FailureThreshold: config.Fetcher.CircuitBreaker.FailureThreshold,
+		RecoveryTimeout:  time.Duration(config.Fetcher.CircuitBreaker.RecoveryTimeoutSeconds) * time.Second,
+	}))
+
+	var taskSemaphore chan struct{}
+	if config.TaskService.MaxConcurrentTasks > 0 {
+		taskSemaphore = make(chan struct{}, config.TaskService.MaxConcurrentTasks)
+	}
+
 	return &TaskService{
 		config: config,
 
@@ -403,14 +864,29 @@ func NewService(config *g.AppConfig) *TaskService {
 
 		taskHandlers: make(map[TaskInstanceID]taskHandler),
 
-		taskInstanceIDGenerator: taskInstanceIDGenerator{},
+		runningTaskKeys: make(map[string]TaskInstanceID),
+
+		idGenerator: idGenerator,
 
 		taskNotificationSender: nil,
 
+		taskResultStore: taskResultStore,
+
+		metricsCollector: NoopMetricsCollector{},
+
+		taskSemaphore: taskSemaphore,
+
+		tracerProvider: tracerProvider,
+
 		taskRunC:    make(chan *taskRunData, 10),
 		taskDoneC:   make(chan TaskInstanceID, 10),
 		taskCancelC: make(chan TaskInstanceID, 10),
 
+		// 서비스가 중지되어 run0 고루틴이 응답하지 못하는 요청이 채널에 남지 않도록 버퍼를 두지 않는다.
+		taskQueryC: make(chan chan int),
+
+		configReloadC: make(chan struct{}, 1),
+
 		taskStopWaiter: &sync.WaitGroup{},
 	}
 }
@@ -436,6 +912,9 @@ func (s *TaskService) Run(serviceStopCtx context.Context, serviceStopWaiter *syn
 	// Task 스케쥴러를 시작한다.
 	s.scheduler.Start(s.config, s, s.taskNotificationSender)
 
+	// 환경설정 파일의 변경을 감시한다.
+	go watchConfig(serviceStopCtx, s.configReloadC)
+
 	go s.run0(serviceStopCtx, serviceStopWaiter)
 
 	s.running = true
@@ -449,11 +928,13 @@ func (s *TaskService) run0(serviceStopCtx context.Context, serviceStopWaiter *sy
 	for {
 		select {
 		case taskRunData := <-s.taskRunC:
-			log.Debugf("새로운 '%s::%s' Task 실행 요청 수신", taskRunData.taskID, taskRunData.taskCommandID)
-
 			if taskRunData.taskCtx == nil {
 				taskRunData.taskCtx = NewContext()
 			}
+			requestID := taskRunData.requestID()
+
+			_log_.WithTaskContext(_log_.TaskContext{RequestID: requestID}).Debugf("새로운 '%s::%s' Task 실행 요청 수신", taskRunData.taskID, taskRunData.taskCommandID)
+
 			taskRunData.taskCtx.WithTask(taskRunData.taskID, taskRunData.taskCommandID)
 
 			taskConfig, commandConfig, err := findConfigFromSupportedTask(taskRunData.taskID, taskRunData.taskCommandID)
@@ -463,6 +944,7 @@ func (s *TaskService) run0(serviceStopCtx context.Context, serviceStopWaiter *sy
 				log.Error(m)
 
 				s.taskNotificationSender.NotifyWithTaskContext(taskRunData.notifierID, m, taskRunData.taskCtx.WithError())
+				taskRunData.sendResult(err)
 
 				continue
 			}
@@ -471,27 +953,45 @@ func (s *TaskService) run0(serviceStopCtx context.Context, serviceStopWaiter *sy
 			if commandConfig.allowMultipleInstances == false {
 				var alreadyRunTaskHandler taskHandler
 
-				s.runningMu.Lock()
-				for _, handler := range s.taskHandlers {
-					if handler.ID() == taskRunData.taskID && handler.CommandID() == taskRunData.taskCommandID && handler.IsCanceled() == false {
-						alreadyRunTaskHandler = handler
-						break
-					}
+				if alreadyRunInstanceID, exists := s.runningTaskKeys[runningTaskKey(taskRunData.taskID, taskRunData.taskCommandID)]; exists == true {
+					s.runningMu.Lock()
+					alreadyRunTaskHandler = s.taskHandlers[alreadyRunInstanceID]
+					s.runningMu.Unlock()
 				}
-				s.runningMu.Unlock()
 
-				if alreadyRunTaskHandler != nil {
+				// 취소된 작업은 완료될 때까지 taskHandlers에 남아 있지만, 새로운 작업의 실행을 막지 않는다.
+				if alreadyRunTaskHandler != nil && alreadyRunTaskHandler.IsCanceled() == false {
+					log.Warnf("'%s::%s' Task가 이미 실행중이므로 실행 요청이 거부되었습니다.(TaskInstanceID:%s)", taskRunData.taskID, taskRunData.taskCommandID, alreadyRunTaskHandler.InstanceID())
+
 					taskRunData.taskCtx.WithInstanceID(alreadyRunTaskHandler.InstanceID(), alreadyRunTaskHandler.ElapsedTimeAfterRun())
 					s.taskNotificationSender.NotifyWithTaskContext(taskRunData.notifierID, "요청하신 작업은 이미 진행중입니다.\n이전 작업을 취소하시려면 아래 명령어를 클릭하여 주세요.", taskRunData.taskCtx)
+					taskRunData.sendResult(ErrTaskAlreadyRunning)
 					continue
 				}
 			}
 
+			// 작업 실행을 요청한 곳에서 더 이상 기다리지 않는 작업이 작업 대기열을 차지하지 않도록 먼저 삭제한다.
+			s.runningMu.Lock()
+			s.dropAbandonedQueuedTaskHandlers()
+			s.runningMu.Unlock()
+
+			// 작업을 바로 실행할 수 없고 작업 대기열도 가득 찬 경우, 작업 실행 요청을 거부한다.
+			if s.taskSemaphore != nil && len(s.taskSemaphore) == cap(s.taskSemaphore) && len(s.taskQueue) >= s.config.TaskService.QueueSize {
+				m := fmt.Sprintf("%s.😱\n잠시 후에 다시 시도하여 주세요.", ErrTaskQueueFull)
+
+				log.Warnf("'%s::%s' Task 실행 요청이 거부되었습니다.(error:%s)", taskRunData.taskID, taskRunData.taskCommandID, ErrTaskQueueFull)
+
+				s.taskNotificationSender.NotifyWithTaskContext(taskRunData.notifierID, m, taskRunData.taskCtx.WithError())
+				taskRunData.sendResult(ErrTaskQueueFull)
+
+				continue
+			}
+
 			var instanceID TaskInstanceID
 
 			s.runningMu.Lock()
 			for {
-				instanceID = s.taskInstanceIDGenerator.New()
+				instanceID = s.idGenerator.New()
 				if _, exists := s.taskHandlers[instanceID]; exists == false {
 					break
 				}
@@ -503,16 +1003,29 @@ func (s *TaskService) run0(serviceStopCtx context.Context, serviceStopWaiter *sy
 				log.Error(err)
 
 				s.taskNotificationSender.NotifyWithTaskContext(taskRunData.notifierID, err.Error(), taskRunData.taskCtx.WithError())
+				taskRunData.sendResult(err)
 
 				continue
 			}
 
+			h.setRequestID(requestID)
+			h.setHeaders(s.taskHeaders(taskRunData.taskID))
+			h.setTimeout(s.taskTimeout(taskRunData.taskID))
+			h.setProxyURL(s.taskProxyURL(taskRunData.taskID))
+			h.setResultC(taskRunData.ctx, taskRunData.resultC)
+
 			s.runningMu.Lock()
 			s.taskHandlers[instanceID] = h
 			s.runningMu.Unlock()
 
-			s.taskStopWaiter.Add(1)
-			go h.Run(s.taskNotificationSender, s.taskStopWaiter, s.taskDoneC)
+			if commandConfig.allowMultipleInstances == false {
+				s.runningTaskKeys[runningTaskKey(h.ID(), h.CommandID())] = instanceID
+			}
+
+			s.metricsCollector.TaskSubmitted(string(taskRunData.taskID), string(taskRunData.taskCommandID))
+			s.publishEvent(TaskEventSubmitted, h, nil)
+
+			s.runOrEnqueueTaskHandler(h)
 
 			if taskRunData.notifyResultOfTaskRunRequest == true {
 				s.taskNotificationSender.NotifyWithTaskContext(taskRunData.notifierID, "작업 진행중입니다. 잠시만 기다려 주세요.", taskRunData.taskCtx.WithInstanceID(instanceID, 0))
@@ -524,6 +1037,26 @@ func (s *TaskService) run0(serviceStopCtx context.Context, serviceStopWaiter *sy
 				log.Debugf("'%s::%s' Task의 작업이 완료되었습니다.(TaskInstanceID:%s)", taskHandler.ID(), taskHandler.CommandID(), instanceID)
 
 				delete(s.taskHandlers, instanceID)
+				s.deleteRunningTaskKey(taskHandler)
+
+				s.metricsCollector.TaskCompleted(string(taskHandler.ID()), string(taskHandler.CommandID()), time.Since(taskHandler.RunTime()), taskHandler.RunErr() == nil)
+
+				switch runErr := taskHandler.RunErr(); {
+				case runErr == nil:
+					s.publishEvent(TaskEventCompleted, taskHandler, nil)
+				case errors.Is(runErr, ErrTaskCanceled) == true:
+					s.publishEvent(TaskEventCancelled, taskHandler, nil)
+				default:
+					s.publishEvent(TaskEventFailed, taskHandler, runErr)
+				}
+
+				// 작업이 완료되어 반환된 세마포어로 대기중인 작업을 실행한다.
+				if s.taskSemaphore != nil {
+					<-s.taskSemaphore
+					s.runQueuedTaskHandler()
+				}
+
+				s.addCompletedTaskInstanceID(instanceID)
 			} else {
 				log.Warnf("등록되지 않은 Task에 대한 작업완료 메시지가 수신되었습니다.(TaskInstanceID:%s)", instanceID)
 			}
@@ -534,6 +1067,20 @@ func (s *TaskService) run0(serviceStopCtx context.Context, serviceStopWaiter *sy
 			if taskHandler, exists := s.taskHandlers[instanceID]; exists == true {
 				taskHandler.Cancel()
 
+				s.metricsCollector.TaskCancelled(string(taskHandler.ID()), string(taskHandler.CommandID()))
+
+				// 실행을 대기중인 작업은 실행되지 않으므로 바로 삭제한다.
+				if s.removeQueuedTaskHandler(instanceID) == true {
+					delete(s.taskHandlers, instanceID)
+					s.deleteRunningTaskKey(taskHandler)
+
+					taskHandler.sendResult("", ErrTaskCanceled)
+
+					s.publishEvent(TaskEventCancelled, taskHandler, nil)
+
+					s.addCompletedTaskInstanceID(instanceID)
+				}
+
 				log.Debugf("'%s::%s' Task의 작업이 취소되었습니다.(TaskInstanceID:%s)", taskHandler.ID(), taskHandler.CommandID(), instanceID)
 
 				s.taskNotificationSender.NotifyWithTaskContext(taskHandler.NotifierID(), "사용자 요청에 의해 작업이 취소되었습니다.", NewContext().WithTask(taskHandler.ID(), taskHandler.CommandID()))
@@ -544,6 +1091,14 @@ func (s *TaskService) run0(serviceStopCtx context.Context, serviceStopWaiter *sy
 			}
 			s.runningMu.Unlock()
 
+		case replyC := <-s.taskQueryC:
+			s.runningMu.Lock()
+			replyC <- len(s.taskHandlers)
+			s.runningMu.Unlock()
+
+		case <-s.configReloadC:
+			s.reloadConfig()
+
 		case <-serviceStopCtx.Done():
 			log.Debug("Task 서비스 중지중...")
 
@@ -565,9 +1120,25 @@ func (s *TaskService) run0(serviceStopCtx context.Context, serviceStopWaiter *sy
 
 			close(s.taskDoneC)
 
+			if err := s.taskResultStore.Close(); err != nil {
+				log.Errorf("작업결과데이터 저장소를 닫는 중에 오류가 발생하였습니다.(error:%s)", err)
+			}
+
+			// 아직 전송되지 않은 스팬을 모두 전송한다.
+			if s.tracerProvider != nil {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				if err := s.tracerProvider.Shutdown(ctx); err != nil {
+					log.Errorf("TracerProvider를 종료하는 중에 오류가 발생하였습니다.(error:%s)", err)
+				}
+				cancel()
+			}
+
 			s.runningMu.Lock()
 			s.running = false
 			s.taskHandlers = nil
+			s.runningTaskKeys = nil
+			s.taskQueue = nil
+			s.metricsCollector.QueueDepth(0)
 			s.taskNotificationSender = nil
 			s.runningMu.Unlock()
 
@@ -578,6 +1149,208 @@ func (s *TaskService) run0(serviceStopCtx context.Context, serviceStopWaiter *sy
 	}
 }
 
+// addCompletedTaskInstanceID 완료(취소 포함)된 작업의 TaskInstanceID를 최근 완료된 작업 목록에 추가한다.
+// 호출하는 곳에서 runningMu를 잠근 상태이어야 한다.
+func (s *TaskService) addCompletedTaskInstanceID(instanceID TaskInstanceID) {
+	s.completedTaskInstanceIDs = append(s.completedTaskInstanceIDs, instanceID)
+	if len(s.completedTaskInstanceIDs) > maxCompletedTaskInstanceIDs {
+		s.completedTaskInstanceIDs = s.completedTaskInstanceIDs[len(s.completedTaskInstanceIDs)-maxCompletedTaskInstanceIDs:]
+	}
+}
+
+// runningTaskKey 동일한 작업이 동시에 실행되는지 확인하기 위한 runningTaskKeys의 키를 반환한다.
+func runningTaskKey(taskID TaskID, taskCommandID TaskCommandID) string {
+	return string(taskID) + "|" + string(taskCommandID)
+}
+
+// deleteRunningTaskKey 작업이 완료(취소 포함)되어 동일한 작업을 다시 실행할 수 있도록 runningTaskKeys에서 삭제한다.
+// 다른 인스턴스의 키가 삭제되지 않도록 같은 TaskInstanceID인 경우에만 삭제한다.
+func (s *TaskService) deleteRunningTaskKey(h taskHandler) {
+	key := runningTaskKey(h.ID(), h.CommandID())
+	if instanceID, exists := s.runningTaskKeys[key]; exists == true && instanceID == h.InstanceID() {
+		delete(s.runningTaskKeys, key)
+	}
+}
+
+// runOrEnqueueTaskHandler 작업을 실행한다.
+// 동시에 실행할 수 있는 작업의 수를 초과한 경우에는 작업 대기열에 추가하고, 실행중인 작업이 완료되면 순서대로 실행한다.
+// 작업 대기열은 run0 고루틴에서만 접근하므로 run0 고루틴에서만 호출되어야 한다.
+func (s *TaskService) runOrEnqueueTaskHandler(h taskHandler) {
+	if s.taskSemaphore != nil {
+		select {
+		case s.taskSemaphore <- struct{}{}:
+		default:
+			s.taskQueue = append(s.taskQueue, h)
+			s.metricsCollector.QueueDepth(len(s.taskQueue))
+
+			log.Debugf("동시에 실행할 수 있는 작업의 수를 초과하여 '%s::%s' Task를 작업 대기열에 추가합니다.(TaskInstanceID:%s, 대기중인 작업 갯수:%d)", h.ID(), h.CommandID(), h.InstanceID(), len(s.taskQueue))
+
+			return
+		}
+	}
+
+	// 작업이 시작된 시각은 run0 고루틴과 API에서도 읽으므로 작업을 실행하는 고루틴을 시작하기 전에 설정한다.
+	h.setRunTime(time.Now())
+
+	s.publishEvent(TaskEventStarted, h, nil)
+
+	s.taskStopWaiter.Add(1)
+	go h.Run(s.taskResultStore, s.taskNotificationSender, s.taskStopWaiter, s.taskDoneC)
+}
+
+// runQueuedTaskHandler 작업 대기열에서 가장 먼저 추가된 작업을 꺼내어 실행한다.
+func (s *TaskService) runQueuedTaskHandler() {
+	s.dropAbandonedQueuedTaskHandlers()
+
+	if len(s.taskQueue) == 0 {
+		return
+	}
+
+	h := s.taskQueue[0]
+	s.taskQueue[0] = nil
+	s.taskQueue = s.taskQueue[1:]
+	s.metricsCollector.QueueDepth(len(s.taskQueue))
+
+	s.runOrEnqueueTaskHandler(h)
+}
+
+// dropAbandonedQueuedTaskHandlers 작업 대기열에서 작업 실행을 요청한 곳의 컨텍스트가 취소된 작업을 삭제한다.
+// 작업 실행 결과를 기다리는 곳이 없으므로 작업을 실행하지 않고 취소된 작업으로 처리한다.
+// runningMu를 잠근 상태에서 run0 고루틴에서만 호출되어야 한다.
+func (s *TaskService) dropAbandonedQueuedTaskHandlers() {
+	queue := s.taskQueue[:0]
+	for _, h := range s.taskQueue {
+		if h.isParentCtxDone() == false {
+			queue = append(queue, h)
+			continue
+		}
+
+		h.Cancel()
+
+		delete(s.taskHandlers, h.InstanceID())
+		s.deleteRunningTaskKey(h)
+
+		s.metricsCollector.TaskCancelled(string(h.ID()), string(h.CommandID()))
+
+		h.sendResult("", ErrTaskCanceled)
+
+		s.publishEvent(TaskEventCancelled, h, nil)
+
+		s.addCompletedTaskInstanceID(h.InstanceID())
+
+		log.Debugf("작업 실행을 요청한 곳의 컨텍스트가 취소되어 '%s::%s' Task를 작업 대기열에서 삭제합니다.(TaskInstanceID:%s)", h.ID(), h.CommandID(), h.InstanceID())
+	}
+	if len(queue) == len(s.taskQueue) {
+		return
+	}
+
+	for i := len(queue); i < len(s.taskQueue); i++ {
+		s.taskQueue[i] = nil
+	}
+	s.taskQueue = queue
+	s.metricsCollector.QueueDepth(len(s.taskQueue))
+}
+
+// removeQueuedTaskHandler 작업 대기열에서 작업을 삭제한다. 작업 대기열에 해당 작업이 없는 경우 false를 반환한다.
+func (s *TaskService) removeQueuedTaskHandler(instanceID TaskInstanceID) bool {
+	for i, h := range s.taskQueue {
+		if h.InstanceID() == instanceID {
+			s.taskQueue = append(s.taskQueue[:i], s.taskQueue[i+1:]...)
+			s.metricsCollector.QueueDepth(len(s.taskQueue))
+			return true
+		}
+	}
+
+	return false
+}
+
+// taskHeaders 환경설정 파일에 설정된 HTTP 헤더 중에서 작업에 적용할 헤더를 반환한다.
+// 작업별로 설정된 헤더는 전체 작업에 설정된 헤더보다 우선한다.
+func (s *TaskService) taskHeaders(taskID TaskID) map[string]string {
+	headers := make(map[string]string)
+	for key, value := range s.config.Fetcher.HTTPClient.Headers {
+		headers[key] = value
+	}
+	for _, t := range s.config.Tasks {
+		if taskID == TaskID(t.ID) {
+			for key, value := range t.Headers {
+				headers[key] = value
+			}
+			break
+		}
+	}
+
+	return headers
+}
+
+// taskTimeout 작업의 실행 제한 시간을 반환한다.
+// 작업별로 설정된 실행 제한 시간이 없는 경우 전체 작업에 설정된 기본 실행 제한 시간을 반환한다.
+func (s *TaskService) taskTimeout(taskID TaskID) time.Duration {
+	for _, t := range s.config.Tasks {
+		if taskID == TaskID(t.ID) && t.TimeoutSeconds > 0 {
+			return time.Duration(t.TimeoutSeconds) * time.Second
+		}
+	}
+
+	return time.Duration(s.config.TaskService.DefaultTimeoutSeconds) * time.Second
+}
+
+// taskProxyURL 작업별로 설정된 프록시 서버를 반환한다. 설정되지 않은 경우 nil을 반환한다.
+func (s *TaskService) taskProxyURL(taskID TaskID) *url.URL {
+	for _, t := range s.config.Tasks {
+		if taskID == TaskID(t.ID) && t.ProxyURL != "" {
+			// 환경설정 파일을 읽어들일 때 이미 유효성을 검사하였으므로 오류가 발생하지 않는다.
+			proxyURL, err := utils.ParseProxyURL(t.ProxyURL)
+			if err != nil {
+				log.Warn(err)
+				return nil
+			}
+			return proxyURL
+		}
+	}
+
+	return nil
+}
+
+// reloadConfig 환경설정 파일을 다시 읽어들여 Task 스케쥴 및 Task 설정 정보를 갱신한다.
+// 이미 실행중인 Task는 이전 설정 정보로 계속 실행되며, 변경된 설정 정보는 다음 실행부터 반영된다.
+func (s *TaskService) reloadConfig() {
+	config, err := g.LoadAppConfig()
+	if err == nil {
+		// 기본 Notifier는 Notification 서비스가 시작될 때 결정되므로 서버를 재시작하여야 변경할 수 있다.
+		// 변경된 기본 NotifierID가 일부만 반영되지 않도록 설정 정보를 다시 읽어들이지 않는다.
+		s.runningMu.Lock()
+		defaultNotifierID := s.config.Notifiers.DefaultNotifierID
+		s.runningMu.Unlock()
+
+		if config.Notifiers.DefaultNotifierID != defaultNotifierID {
+			err = fmt.Errorf("기본 NotifierID('%s' → '%s')는 서버를 재시작하여야 변경할 수 있습니다.", defaultNotifierID, config.Notifiers.DefaultNotifierID)
+		}
+	}
+	if err == nil {
+		err = s.scheduler.Reload(config)
+	}
+	if err != nil {
+		m := fmt.Sprintf("환경설정 정보를 다시 읽어들이는 중에 오류가 발생하여 이전 설정 정보를 유지합니다.😱\n\n%s", err)
+
+		log.Error(m)
+
+		s.taskNotificationSender.NotifyToDefault(m)
+
+		return
+	}
+
+	// Task 설정 정보만 갱신한다. 다른 서비스에서 사용하는 설정 정보는 서버를 재시작하여야 반영된다.
+	// 설정 정보는 변경하지 않고 새로운 설정 정보로 교체하므로 이전 설정 정보로 실행중인 Task에는 영향을 주지 않는다.
+	s.runningMu.Lock()
+	newConfig := *s.config
+	newConfig.Tasks = config.Tasks
+	s.config = &newConfig
+	s.runningMu.Unlock()
+
+	log.Info("환경설정 정보를 다시 읽어들여 Task 스케쥴 및 설정 정보를 갱신하였습니다.")
+}
+
 func (s *TaskService) TaskRun(taskID TaskID, taskCommandID TaskCommandID, notifierID string, notifyResultOfTaskRunRequest bool, taskRunBy TaskRunBy) (succeeded bool) {
 	return s.TaskRunWithContext(taskID, taskCommandID, nil, notifierID, notifyResultOfTaskRunRequest, taskRunBy)
 }
@@ -591,6 +1364,12 @@ func (s *TaskService) TaskRunWithContext(taskID TaskID, taskCommandID TaskComman
 		}
 	}()
 
+	// 일시 중지된 상태에서는 스케쥴러에 의한 작업 실행 요청을 무시한다.
+	if taskRunBy == TaskRunByScheduler && s.IsPaused() == true {
+		log.Debugf("Task 서비스가 일시 중지된 상태이므로 '%s::%s' Task의 실행 요청을 무시합니다.", taskID, taskCommandID)
+		return true
+	}
+
 	s.taskRunC <- &taskRunData{
 		taskID:        taskID,
 		taskCommandID: taskCommandID,
@@ -607,6 +1386,45 @@ func (s *TaskService) TaskRunWithContext(taskID TaskID, taskCommandID TaskComman
 	return true
 }
 
+func (s *TaskService) TaskRunOnce(ctx context.Context, taskID TaskID, taskCommandID TaskCommandID, notifierID string) (result *TaskRunResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("'%s::%s' Task 실행 요청중에 panic이 발생하였습니다.(panic:%s)", taskID, taskCommandID, r)
+
+			log.Error(err)
+		}
+	}()
+
+	resultC := make(chan *TaskRunResult, 1)
+
+	select {
+	case s.taskRunC <- &taskRunData{
+		taskID:        taskID,
+		taskCommandID: taskCommandID,
+
+		notifierID: notifierID,
+
+		notifyResultOfTaskRunRequest: false,
+
+		taskRunBy: TaskRunByUser,
+
+		ctx:     ctx,
+		resultC: resultC,
+	}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case result = <-resultC:
+		return result, result.Err
+	case <-ctx.Done():
+		// 실행중인 작업은 ctx가 취소되면 함께 취소되고, 작업 대기열에서 대기중인 작업은 실행되기 전에 삭제된다.
+		return nil, ctx.Err()
+	}
+}
+
 func (s *TaskService) TaskCancel(taskInstanceID TaskInstanceID) (succeeded bool) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -621,6 +1439,175 @@ func (s *TaskService) TaskCancel(taskInstanceID TaskInstanceID) (succeeded bool)
 	return true
 }
 
+func (s *TaskService) TaskResultDataDelete(taskID TaskID, taskCommandID TaskCommandID) error {
+	if _, _, err := findConfigFromSupportedTask(taskID, taskCommandID); err != nil {
+		return err
+	}
+
+	// 작업결과데이터를 삭제하는 동안 같은 작업이 새로 실행되지 않도록 runningMu를 잠근 상태에서 삭제한다.
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	for _, h := range s.taskHandlers {
+		if h.ID() == taskID && h.CommandID() == taskCommandID && h.IsCanceled() == false {
+			return ErrTaskAlreadyRunning
+		}
+	}
+
+	return s.taskResultStore.Delete(taskID, taskCommandID)
+}
+
+func (s *TaskService) TaskResultData(taskID TaskID, taskCommandID TaskCommandID) (interface{}, error) {
+	_, commandConfig, err := findConfigFromSupportedTask(taskID, taskCommandID)
+	if err != nil {
+		return nil, err
+	}
+
+	taskResultData := commandConfig.newTaskResultDataFn()
+	if err = s.taskResultStore.Load(taskID, taskCommandID, taskResultData); err != nil {
+		return nil, err
+	}
+
+	return taskResultData, nil
+}
+
+func (s *TaskService) RunningTasks() []*RunningTaskInfo {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	runningTasks := make([]*RunningTaskInfo, 0, len(s.taskHandlers))
+	for instanceID, handler := range s.taskHandlers {
+		if handler.IsCanceled() == true {
+			continue
+		}
+
+		runningTasks = append(runningTasks, &RunningTaskInfo{
+			InstanceID: instanceID,
+			TaskID:     handler.ID(),
+			CommandID:  handler.CommandID(),
+			RunBy:      handler.RunBy(),
+			RunTime:    handler.RunTime(),
+		})
+	}
+
+	return runningTasks
+}
+
+func (s *TaskService) TaskInstanceStatus(taskInstanceID TaskInstanceID) TaskInstanceStatus {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	if handler, exists := s.taskHandlers[taskInstanceID]; exists == true {
+		if handler.IsCanceled() == true {
+			return TaskInstanceStatusCompleted
+		}
+		return TaskInstanceStatusRunning
+	}
+
+	for _, id := range s.completedTaskInstanceIDs {
+		if id == taskInstanceID {
+			return TaskInstanceStatusCompleted
+		}
+	}
+
+	return TaskInstanceStatusNotFound
+}
+
+func (s *TaskService) TaskExecutionHistories(taskID TaskID, limit int) ([]*TaskExecutionHistory, error) {
+	historyStore, ok := s.taskResultStore.(TaskExecutionHistoryStore)
+	if ok == false {
+		return nil, ErrExecutionHistoryNotSupported
+	}
+
+	return historyStore.ExecutionHistories(taskID, limit)
+}
+
+func (s *TaskService) Health() error {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	if s.running == false {
+		return errors.New("Task 서비스가 실행중이 아닙니다")
+	}
+
+	return nil
+}
+
+func (s *TaskService) Pause() error {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	if s.running == false {
+		return errors.New("Task 서비스가 실행중이 아닙니다")
+	}
+
+	if s.paused == false {
+		s.paused = true
+
+		log.Info("Task 서비스의 스케쥴러에 의한 작업 실행이 일시 중지되었습니다.")
+	}
+
+	return nil
+}
+
+func (s *TaskService) Resume() error {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	if s.running == false {
+		return errors.New("Task 서비스가 실행중이 아닙니다")
+	}
+
+	if s.paused == true {
+		s.paused = false
+
+		log.Info("Task 서비스의 스케쥴러에 의한 작업 실행이 재개되었습니다.")
+	}
+
+	return nil
+}
+
+func (s *TaskService) IsPaused() bool {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	return s.paused
+}
+
+// GetRunningTaskCount 실행중인 작업의 갯수를 run0 고루틴에서 읽어서 반환한다.
+// 서비스가 실행중이 아니거나 제한 시간 내에 응답을 받지 못한 경우 0을 반환한다.
+func (s *TaskService) GetRunningTaskCount() int {
+	s.runningMu.Lock()
+	running := s.running
+	s.runningMu.Unlock()
+
+	if running == false {
+		return 0
+	}
+
+	replyC := make(chan int, 1)
+	select {
+	case s.taskQueryC <- replyC:
+		return <-replyC
+
+	case <-time.After(taskQueryTimeout):
+		log.Warn("실행중인 작업의 갯수 조회 요청에 대한 응답을 받지 못하였습니다.")
+		return 0
+	}
+}
+
+func (s *TaskService) Schedules(n int) []*ScheduleInfo {
+	return s.scheduler.Schedules(n)
+}
+
 func (s *TaskService) SetTaskNotificationSender(taskNotificiationSender TaskNotificationSender) {
 	s.taskNotificationSender = taskNotificiationSender
 }
+
+// SetMetricsCollector Task 서비스의 상태를 전달받을 MetricsCollector를 설정한다. 서비스를 시작하기 전에 호출되어야 한다.
+func (s *TaskService) SetMetricsCollector(metricsCollector MetricsCollector) {
+	if metricsCollector == nil {
+		metricsCollector = NoopMetricsCollector{}
+	}
+	s.metricsCollector = metricsCollector
+}
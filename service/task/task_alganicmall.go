@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/darkkaiser/notify-server/g"
+	"github.com/darkkaiser/notify-server/service/task/mark"
 	"github.com/darkkaiser/notify-server/utils"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/korean"
-	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 const (
@@ -49,9 +51,9 @@ type alganicmallProduct struct {
 
 func (p *alganicmallProduct) String(messageTypeHTML bool, mark string) string {
 	if messageTypeHTML == true {
-		return fmt.Sprintf("☞ <a href=\"%s\"><b>%s</b></a> %s원%s", p.Url, p.Name, utils.FormatCommas(p.Price), mark)
+		return fmt.Sprintf("☞ <a href=\"%s\"><b>%s</b></a> %s%s", p.Url, p.Name, utils.FormatKRW(p.Price), mark)
 	}
-	return strings.TrimSpace(fmt.Sprintf("☞ %s %s원%s\n%s", p.Name, utils.FormatCommas(p.Price), mark, p.Url))
+	return strings.TrimSpace(fmt.Sprintf("☞ %s %s%s\n%s", p.Name, utils.FormatKRW(p.Price), mark, p.Url))
 }
 
 type alganicmallWatchAtoCreamResultData struct {
@@ -124,8 +126,8 @@ func (t *alganicMallTask) runWatchNewEvents(taskResultData interface{}, messageT
 	var err0 error
 	var euckrDecoder = korean.EUCKR.NewDecoder()
 	var actualityTaskResultData = &alganicmallWatchNewEventsResultData{}
-	err = webScrape(fmt.Sprintf("%sboard/board.html?code=alganic_image1", alganicmallBaseUrl), "div.bbs-table-list > div.fixed-img-collist > ul > li > a", func(i int, s *goquery.Selection) bool {
-		name, _err_ := euckrDecoder.String(s.Text())
+	err = t.webScrape(fmt.Sprintf("%sboard/board.html?code=alganic_image1", alganicmallBaseUrl), "div.bbs-table-list > div.fixed-img-collist > ul > li > a", func(i int, s *goquery.Selection) bool {
+		name, _err_ := decodeEUCKR(euckrDecoder, s.Text())
 		if _err_ != nil {
 			err0 = fmt.Errorf("이벤트명의 문자열 변환(EUC-KR to UTF-8)이 실패하였습니다.(error:%s)", _err_)
 			return false
@@ -174,7 +176,7 @@ func (t *alganicMallTask) runWatchNewEvents(taskResultData interface{}, messageT
 		if m != "" {
 			m += lineSpacing
 		}
-		m += actualityEvent.String(messageTypeHTML, " 🆕")
+		m += actualityEvent.String(messageTypeHTML, mark.New)
 	})
 	if err != nil {
 		return "", nil, err
@@ -184,7 +186,7 @@ func (t *alganicMallTask) runWatchNewEvents(taskResultData interface{}, messageT
 		message = "새로운 이벤트가 등록되었습니다.\n\n" + m
 		changedTaskResultData = actualityTaskResultData
 	} else {
-		if t.runBy == TaskRunByUser {
+		if t.runBy.IsOnDemand() == true {
 			if len(actualityTaskResultData.Events) == 0 {
 				message = "등록된 이벤트가 존재하지 않습니다."
 			} else {
@@ -212,9 +214,8 @@ func (t *alganicMallTask) runWatchAtoCream(taskResultData interface{}, messageTy
 	// 제품 페이지를 읽어서 정보를 추출한다.
 	var err0 error
 	var euckrDecoder = korean.EUCKR.NewDecoder()
-	var priceReplacer = strings.NewReplacer(",", "", "원", "")
 	var actualityTaskResultData = &alganicmallWatchAtoCreamResultData{}
-	err = webScrape(fmt.Sprintf("%sshop/shopbrand.html?xcode=020&type=Y", alganicmallBaseUrl), "div.item-wrap > div.item-list > dl.item", func(i int, s *goquery.Selection) bool {
+	err = t.webScrape(fmt.Sprintf("%sshop/shopbrand.html?xcode=020&type=Y", alganicmallBaseUrl), "div.item-wrap > div.item-list > dl.item", func(i int, s *goquery.Selection) bool {
 		productSelection := s
 
 		// 제품명
@@ -223,7 +224,7 @@ func (t *alganicMallTask) runWatchAtoCream(taskResultData interface{}, messageTy
 			err0 = errors.New("제품명 추출이 실패하였습니다. CSS셀렉터를 확인하세요")
 			return false
 		}
-		name, _err_ := euckrDecoder.String(productNameSelection.Text())
+		name, _err_ := decodeEUCKR(euckrDecoder, productNameSelection.Text())
 		if _err_ != nil {
 			err0 = fmt.Errorf("제품명의 문자열 변환(EUC-KR to UTF-8)이 실패하였습니다.(error:%s)", _err_)
 			return false
@@ -257,12 +258,12 @@ func (t *alganicMallTask) runWatchAtoCream(taskResultData interface{}, messageTy
 			err0 = errors.New("제품 가격 추출이 실패하였습니다. CSS셀렉터를 확인하세요")
 			return false
 		}
-		productPriceString, _err_ := euckrDecoder.String(productPriceSelection.Text())
+		productPriceString, _err_ := decodeEUCKR(euckrDecoder, productPriceSelection.Text())
 		if _err_ != nil {
 			err0 = fmt.Errorf("제품 가격의 문자열 변환(EUC-KR to UTF-8)이 실패하였습니다.(error:%s)", _err_)
 			return false
 		}
-		price, _err_ := strconv.Atoi(utils.Trim(priceReplacer.Replace(productPriceString)))
+		price, _err_ := utils.ParseKRW(productPriceString)
 		if _err_ != nil {
 			err0 = fmt.Errorf("제품 가격의 숫자 변환이 실패하였습니다.(error:%s)", _err_)
 			return false
@@ -308,7 +309,7 @@ func (t *alganicMallTask) runWatchAtoCream(taskResultData interface{}, messageTy
 			if m != "" {
 				m += lineSpacing
 			}
-			m += originProduct.String(messageTypeHTML, fmt.Sprintf(" ⇒ %s원 🔁", utils.FormatCommas(actualityProduct.Price)))
+			m += originProduct.String(messageTypeHTML, fmt.Sprintf(" ⇒ %s 🔁", utils.FormatKRW(actualityProduct.Price)))
 		}
 	}, func(selem interface{}) {
 		actualityProduct := selem.(*alganicmallProduct)
@@ -316,7 +317,7 @@ func (t *alganicMallTask) runWatchAtoCream(taskResultData interface{}, messageTy
 		if m != "" {
 			m += lineSpacing
 		}
-		m += actualityProduct.String(messageTypeHTML, " 🆕")
+		m += actualityProduct.String(messageTypeHTML, mark.New)
 	})
 	if err != nil {
 		return "", nil, err
@@ -326,7 +327,7 @@ func (t *alganicMallTask) runWatchAtoCream(taskResultData interface{}, messageTy
 		message = "아토크림에 대한 정보가 변경되었습니다.\n\n" + m
 		changedTaskResultData = actualityTaskResultData
 	} else {
-		if t.runBy == TaskRunByUser {
+		if t.runBy.IsOnDemand() == true {
 			if len(actualityTaskResultData.Products) == 0 {
 				message = "아토크림에 대한 정보가 존재하지 않습니다."
 			} else {
@@ -344,3 +345,12 @@ func (t *alganicMallTask) runWatchAtoCream(taskResultData interface{}, messageTy
 
 	return message, changedTaskResultData, nil
 }
+
+// decodeEUCKR EUC-KR 문자열을 UTF-8 문자열로 변환한다.
+// 응답 헤더에 문자셋이 명시되어 Scraper에서 이미 UTF-8로 변환된 문자열은 그대로 반환한다.
+func decodeEUCKR(decoder *encoding.Decoder, s string) (string, error) {
+	if utf8.ValidString(s) == true {
+		return s, nil
+	}
+	return decoder.String(s)
+}
@@ -0,0 +1,305 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/darkkaiser/notify-server/g"
+	"github.com/darkkaiser/notify-server/service/task/mark"
+	"github.com/darkkaiser/notify-server/utils"
+	log "github.com/sirupsen/logrus"
+	"net/url"
+	"strings"
+)
+
+const (
+	coupangWatchPriceTaskCommandIDPrefix string = "WatchPrice_"
+
+	// TaskID
+	TidCoupang TaskID = "COUPANG" // 쿠팡(https://www.coupang.com/)
+
+	// TaskCommandID
+	TcidCoupangWatchPriceAny = TaskCommandID(coupangWatchPriceTaskCommandIDPrefix + taskCommandIDAnyString) // 쿠팡 가격 확인
+)
+
+const (
+	coupangBaseUrl = "https://www.coupang.com"
+)
+
+type coupangWatchPriceTaskCommandData struct {
+	Query     string `json:"query"`
+	ProductID string `json:"product_id"`
+	Filters   struct {
+		IncludedKeywords string `json:"included_keywords"`
+		ExcludedKeywords string `json:"excluded_keywords"`
+		PriceLessThan    int    `json:"price_less_than"`
+	} `json:"filters"`
+}
+
+func (d *coupangWatchPriceTaskCommandData) ApplyDefaults() {
+}
+
+func (d *coupangWatchPriceTaskCommandData) Validate() error {
+	if d.Query == "" {
+		return errors.New("query가 입력되지 않았습니다")
+	}
+	if d.Filters.PriceLessThan <= 0 {
+		return errors.New("price_less_than에 0 이하의 값이 입력되었습니다")
+	}
+	return nil
+}
+
+type coupangProduct struct {
+	ProductID string `json:"product_id"`
+	Title     string `json:"title"`
+	Link      string `json:"link"`
+	Price     int    `json:"price"`
+}
+
+func (p *coupangProduct) String(messageTypeHTML bool, mark string) string {
+	if messageTypeHTML == true {
+		return fmt.Sprintf("☞ <a href=\"%s\"><b>%s</b></a> %s%s", p.Link, p.Title, utils.FormatKRW(p.Price), mark)
+	}
+	return strings.TrimSpace(fmt.Sprintf("☞ %s %s%s\n%s", p.Title, utils.FormatKRW(p.Price), mark, p.Link))
+}
+
+// coupangWatchPriceSnapshot 조회 조건에 해당되는 상품 목록을 저장하는 작업결과데이터
+type coupangWatchPriceSnapshot struct {
+	Products []*coupangProduct `json:"products"`
+}
+
+// coupangProductDiff 이전 작업결과데이터와 비교하여 확인된 상품의 변경 정보, Origin이 nil이면 새로 검색된 상품이다.
+type coupangProductDiff struct {
+	Product *coupangProduct
+	Origin  *coupangProduct
+}
+
+func (d *coupangProductDiff) String(messageTypeHTML bool) string {
+	if d.Origin == nil {
+		return d.Product.String(messageTypeHTML, mark.New)
+	}
+	return d.Origin.String(messageTypeHTML, fmt.Sprintf(" ⇒ %s 🔁", utils.FormatKRW(d.Product.Price)))
+}
+
+// Compare origin과 비교하여 새로 검색되었거나 가격이 변경된 상품의 목록을 검색된 순서대로 반환한다.
+func (s *coupangWatchPriceSnapshot) Compare(origin *coupangWatchPriceSnapshot) []coupangProductDiff {
+	originProducts := make(map[string]*coupangProduct)
+	if origin != nil {
+		for _, p := range origin.Products {
+			originProducts[p.ProductID] = p
+		}
+	}
+
+	var diffs []coupangProductDiff
+	for _, p := range s.Products {
+		originProduct, exists := originProducts[p.ProductID]
+		if exists == false {
+			diffs = append(diffs, coupangProductDiff{Product: p})
+		} else if p.Price != originProduct.Price {
+			diffs = append(diffs, coupangProductDiff{Product: p, Origin: originProduct})
+		}
+	}
+
+	return diffs
+}
+
+func init() {
+	supportedTasks[TidCoupang] = &supportedTaskConfig{
+		commandConfigs: []*supportedTaskCommandConfig{{
+			taskCommandID: TcidCoupangWatchPriceAny,
+
+			allowMultipleInstances: true,
+
+			newTaskResultDataFn: func() interface{} { return &coupangWatchPriceSnapshot{} },
+		}},
+
+		newTaskFn: func(instanceID TaskInstanceID, taskRunData *taskRunData, config *g.AppConfig) (taskHandler, error) {
+			if taskRunData.taskID != TidCoupang {
+				return nil, errors.New("등록되지 않은 작업입니다.😱")
+			}
+
+			task := &coupangTask{
+				task: task{
+					id:         taskRunData.taskID,
+					commandID:  taskRunData.taskCommandID,
+					instanceID: instanceID,
+
+					notifierID: taskRunData.notifierID,
+
+					canceled: false,
+
+					runBy: taskRunData.taskRunBy,
+				},
+
+				config: config,
+			}
+
+			task.runFn = func(taskResultData interface{}, messageTypeHTML bool) (string, interface{}, error) {
+				// 'WatchPrice_'로 시작되는 명령인지 확인한다.
+				if strings.HasPrefix(string(task.CommandID()), coupangWatchPriceTaskCommandIDPrefix) == true {
+					for _, t := range task.config.Tasks {
+						if task.ID() == TaskID(t.ID) {
+							for _, c := range t.Commands {
+								if task.CommandID() == TaskCommandID(c.ID) {
+									taskCommandData := &coupangWatchPriceTaskCommandData{}
+									if err := fillTaskCommandDataFromMap(taskCommandData, c.Data); err != nil {
+										return "", nil, errors.New(fmt.Sprintf("작업 커맨드 데이터가 유효하지 않습니다.(error:%s)", err))
+									}
+
+									return task.runWatchPrice(taskCommandData, taskResultData, messageTypeHTML)
+								}
+							}
+							break
+						}
+					}
+				}
+
+				return "", nil, ErrNoImplementationForTaskCommand
+			}
+
+			return task, nil
+		},
+	}
+}
+
+type coupangTask struct {
+	task
+
+	config *g.AppConfig
+}
+
+func (t *coupangTask) runWatchPrice(taskCommandData *coupangWatchPriceTaskCommandData, taskResultData interface{}, messageTypeHTML bool) (message string, changedTaskResultData interface{}, err error) {
+	originTaskResultData, ok := taskResultData.(*coupangWatchPriceSnapshot)
+	if ok == false {
+		log.Panic("TaskResultData의 타입 변환이 실패하였습니다.")
+	}
+
+	//
+	// 상품에 대한 정보를 검색한다.
+	//
+	header := map[string]string{
+		"User-Agent":      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36",
+		"Accept-Language": "ko-KR,ko;q=0.9",
+	}
+	doc, err := t.newHTMLDocumentWithHeader(fmt.Sprintf("%s/np/search?q=%s&listSize=72&sorter=scoreDesc", coupangBaseUrl, url.QueryEscape(taskCommandData.Query)), header)
+	if err != nil {
+		return "", nil, err
+	}
+
+	//
+	// 검색된 상품 목록을 설정된 조건에 맞게 필터링한다.
+	//
+	var err0 error
+	actualityTaskResultData := &coupangWatchPriceSnapshot{}
+	keywordMatcher, err := utils.NewKeywordMatcherWithValidation(utils.SplitExceptEmptyItems(taskCommandData.Filters.IncludedKeywords, ","), utils.SplitExceptEmptyItems(taskCommandData.Filters.ExcludedKeywords, ","))
+	if err != nil {
+		return "", nil, err
+	}
+
+	doc.Find("ul#productList > li.search-product").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		// 상품ID
+		productID, exists := s.Attr("data-product-id")
+		if exists == false {
+			err0 = errors.New("상품 ID 추출이 실패하였습니다. CSS셀렉터를 확인하세요")
+			return false
+		}
+		if taskCommandData.ProductID != "" && taskCommandData.ProductID != productID {
+			return true
+		}
+
+		// 상품명
+		ps := s.Find("div.name")
+		if ps.Length() != 1 {
+			err0 = errors.New("상품명 추출이 실패하였습니다. CSS셀렉터를 확인하세요")
+			return false
+		}
+		title := utils.Trim(ps.Text())
+
+		// 상품URL
+		ps = s.Find("a.search-product-link")
+		if ps.Length() != 1 {
+			err0 = errors.New("상품 URL 추출이 실패하였습니다. CSS셀렉터를 확인하세요")
+			return false
+		}
+		link, exists := ps.Attr("href")
+		if exists == false {
+			err0 = errors.New("상품 URL 추출이 실패하였습니다. CSS셀렉터를 확인하세요")
+			return false
+		}
+		// 상품URL의 파라메터는 검색할 때마다 변경되기 때문에 제거한다.
+		if pos := strings.Index(link, "?"); pos != -1 {
+			link = link[:pos]
+		}
+
+		// 상품가격(품절 등의 이유로 가격 정보가 없는 상품은 제외한다)
+		ps = s.Find("strong.price-value")
+		if ps.Length() != 1 {
+			return true
+		}
+		price, _err_ := utils.ParseKRW(ps.Text())
+		if _err_ != nil {
+			err0 = fmt.Errorf("상품 가격의 숫자 변환이 실패하였습니다.(error:%s)", _err_)
+			return false
+		}
+
+		if keywordMatcher.Match(title) == false {
+			return true
+		}
+
+		if price > 0 && price < taskCommandData.Filters.PriceLessThan {
+			actualityTaskResultData.Products = append(actualityTaskResultData.Products, &coupangProduct{
+				ProductID: productID,
+				Title:     title,
+				Link:      fmt.Sprintf("%s%s", coupangBaseUrl, link),
+				Price:     price,
+			})
+		}
+
+		return true
+	})
+	if err0 != nil {
+		return "", nil, err0
+	}
+
+	//
+	// 필터링 된 상품 정보를 확인한다.
+	//
+	m := ""
+	lineSpacing := "\n\n"
+	if messageTypeHTML == true {
+		lineSpacing = "\n"
+	}
+	for _, diff := range actualityTaskResultData.Compare(originTaskResultData) {
+		if m != "" {
+			m += lineSpacing
+		}
+		m += diff.String(messageTypeHTML)
+	}
+
+	filtersDescription := fmt.Sprintf("조회 조건은 아래와 같습니다:\n• 검색 키워드 : %s\n• 상풍명 포함 키워드 : %s\n• 상품명 제외 키워드 : %s\n• %s 미만의 상품", taskCommandData.Query, taskCommandData.Filters.IncludedKeywords, taskCommandData.Filters.ExcludedKeywords, utils.FormatKRW(taskCommandData.Filters.PriceLessThan))
+	if taskCommandData.ProductID != "" {
+		filtersDescription += fmt.Sprintf("\n• 상품 ID : %s", taskCommandData.ProductID)
+	}
+
+	if m != "" {
+		message = fmt.Sprintf("조회 조건에 해당되는 상품의 정보가 변경되었습니다.\n\n%s\n\n%s", filtersDescription, m)
+		changedTaskResultData = actualityTaskResultData
+	} else {
+		if t.runBy.IsOnDemand() == true {
+			if len(actualityTaskResultData.Products) == 0 {
+				message = fmt.Sprintf("조회 조건에 해당되는 상품이 존재하지 않습니다.\n\n%s", filtersDescription)
+			} else {
+				for _, actualityProduct := range actualityTaskResultData.Products {
+					if m != "" {
+						m += lineSpacing
+					}
+					m += actualityProduct.String(messageTypeHTML, "")
+				}
+
+				message = fmt.Sprintf("조회 조건에 해당되는 상품의 변경된 정보가 없습니다.\n\n%s\n\n조회 조건에 해당되는 상품은 아래와 같습니다:\n\n%s", filtersDescription, m)
+			}
+		}
+	}
+
+	return message, changedTaskResultData, nil
+}
@@ -0,0 +1,107 @@
+package task
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+const coupangSearchTestHTML = `<html><body>
+<ul id="productList">
+<li class="search-product" data-product-id="1"><a class="search-product-link" href="/vp/products/1?itemId=11&src=1"><div class="name">무선 키보드</div><strong class="price-value">29,000</strong></a></li>
+<li class="search-product" data-product-id="2"><a class="search-product-link" href="/vp/products/2?itemId=22&src=1"><div class="name">무선 마우스</div><strong class="price-value">15,000</strong></a></li>
+<li class="search-product" data-product-id="3"><a class="search-product-link" href="/vp/products/3"><div class="name">무선 키보드 마우스 세트</div><strong class="price-value">80,000</strong></a></li>
+<li class="search-product" data-product-id="4"><a class="search-product-link" href="/vp/products/4"><div class="name">품절된 무선 키보드</div></a></li>
+<li class="search-product" data-product-id="5"><a class="search-product-link" href="/vp/products/5"><div class="name">유선 키보드</div><strong class="price-value">9,000</strong></a></li>
+</ul>
+</body></html>`
+
+func TestCoupangWatchPriceSnapshot_Compare(t *testing.T) {
+	origin := &coupangWatchPriceSnapshot{
+		Products: []*coupangProduct{
+			{ProductID: "1", Title: "상품1", Price: 10000},
+			{ProductID: "2", Title: "상품2", Price: 20000},
+			{ProductID: "3", Title: "상품3", Price: 30000},
+		},
+	}
+	actuality := &coupangWatchPriceSnapshot{
+		Products: []*coupangProduct{
+			{ProductID: "4", Title: "상품4", Price: 40000},
+			{ProductID: "2", Title: "상품2", Price: 20000},
+			{ProductID: "1", Title: "상품1", Price: 9000},
+		},
+	}
+
+	// 새로 검색된 상품과 가격이 변경된 상품만 검색된 순서대로 반환하고, 검색되지 않은 상품은 반환하지 않는다.
+	diffs := actuality.Compare(origin)
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "4", diffs[0].Product.ProductID)
+	assert.Nil(t, diffs[0].Origin)
+	assert.Equal(t, "1", diffs[1].Product.ProductID)
+	assert.Equal(t, 9000, diffs[1].Product.Price)
+	assert.Equal(t, 10000, diffs[1].Origin.Price)
+
+	// 이전 작업결과데이터가 없으면 모든 상품이 새로 검색된 상품이다.
+	assert.Len(t, actuality.Compare(nil), 3)
+	assert.Len(t, actuality.Compare(actuality), 0)
+}
+
+func TestCoupangTask_RunWatchPrice(t *testing.T) {
+	mock := NewMockHTTPFetcher()
+	useMockFetcher(t, mock)
+
+	taskCommandData := &coupangWatchPriceTaskCommandData{Query: "무선 키보드"}
+	taskCommandData.Filters.IncludedKeywords = "무선"
+	taskCommandData.Filters.PriceLessThan = 50000
+	assert.NoError(t, taskCommandData.Validate())
+
+	searchURL := fmt.Sprintf("%s/np/search?q=%s&listSize=72&sorter=scoreDesc", coupangBaseUrl, url.QueryEscape(taskCommandData.Query))
+	mock.SetResponse(searchURL, http.StatusOK, coupangSearchTestHTML)
+
+	originTaskResultData := &coupangWatchPriceSnapshot{
+		Products: []*coupangProduct{
+			{ProductID: "1", Title: "무선 키보드", Link: coupangBaseUrl + "/vp/products/1", Price: 32000},
+		},
+	}
+
+	ct := &coupangTask{task: task{runBy: TaskRunByScheduler}}
+	message, changedTaskResultData, err := ct.runWatchPrice(taskCommandData, originTaskResultData, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, mock.GetRequestCount(searchURL))
+
+	// 가격 조건, 포함 키워드를 만족하지 않거나 가격 정보가 없는 상품은 제외되고, URL의 파라메터는 제거된다.
+	assert.NotNil(t, changedTaskResultData)
+	products := changedTaskResultData.(*coupangWatchPriceSnapshot).Products
+	assert.Len(t, products, 2)
+	assert.Equal(t, &coupangProduct{ProductID: "1", Title: "무선 키보드", Link: coupangBaseUrl + "/vp/products/1", Price: 29000}, products[0])
+	assert.Equal(t, &coupangProduct{ProductID: "2", Title: "무선 마우스", Link: coupangBaseUrl + "/vp/products/2", Price: 15000}, products[1])
+
+	assert.Contains(t, message, "☞ 무선 키보드 32,000원 ⇒ 29,000원 🔁")
+	assert.Contains(t, message, "☞ 무선 마우스 15,000원 🆕")
+	assert.NotContains(t, message, "세트")
+	assert.NotContains(t, message, "품절")
+	assert.NotContains(t, message, "유선")
+
+	// 변경된 상품이 없으면 스케쥴러에 의해 실행된 경우 알리지 않는다.
+	message, changedTaskResultData, err = ct.runWatchPrice(taskCommandData, changedTaskResultData, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "", message)
+	assert.Nil(t, changedTaskResultData)
+}
+
+func TestCoupangTask_RunWatchPrice_PageStructureChanged(t *testing.T) {
+	mock := NewMockHTTPFetcher()
+	useMockFetcher(t, mock)
+
+	taskCommandData := &coupangWatchPriceTaskCommandData{Query: "키보드"}
+	taskCommandData.Filters.PriceLessThan = 50000
+
+	searchURL := fmt.Sprintf("%s/np/search?q=%s&listSize=72&sorter=scoreDesc", coupangBaseUrl, url.QueryEscape(taskCommandData.Query))
+	mock.SetResponse(searchURL, http.StatusOK, `<ul id="productList"><li class="search-product" data-product-id="1"><a class="search-product-link" href="/vp/products/1"><strong class="price-value">1,000</strong></a></li></ul>`)
+
+	ct := &coupangTask{task: task{runBy: TaskRunByScheduler}}
+	_, _, err := ct.runWatchPrice(taskCommandData, &coupangWatchPriceSnapshot{}, false)
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/darkkaiser/notify-server/g"
+	"github.com/darkkaiser/notify-server/service/task/mark"
 	log "github.com/sirupsen/logrus"
 	"strconv"
 	"strings"
@@ -176,9 +177,8 @@ func (t *covid19Task) runWatchResidualVaccine(taskResultData interface{}, messag
 	//
 	// 잔여백신이 남아있는 의료기관을 검색한다.
 	//
-	var header = map[string]string{"content-type": "application/json"}
 	var searchResultData = covid19WatchResidualVaccineSearchResultData{}
-	err = unmarshalFromResponseJSONData("POST", "https://api.place.naver.com/graphql", header, bytes.NewBufferString("[{\"operationName\":\"vaccineList\",\"variables\":{\"input\":{\"keyword\":\"코로나백신위탁의료기관\",\"x\":\"127.672066\",\"y\":\"34.7635133\"},\"businessesInput\":{\"start\":0,\"display\":100,\"deviceType\":\"mobile\",\"x\":\"127.672066\",\"y\":\"34.7635133\",\"bounds\":\"127.6034014;34.7392187;127.7407305;34.7878008\",\"sortingOrder\":\"distance\"},\"isNmap\":false,\"isBounds\":false},\"query\":\"query vaccineList($input: RestsInput, $businessesInput: RestsBusinessesInput, $isNmap: Boolean!, $isBounds: Boolean!) {\\n  rests(input: $input) {\\n    businesses(input: $businessesInput) {\\n      total\\n      vaccineLastSave\\n      isUpdateDelayed\\n      items {\\n        id\\n        name\\n        dbType\\n        phone\\n        virtualPhone\\n        hasBooking\\n        hasNPay\\n        bookingReviewCount\\n        description\\n        distance\\n        commonAddress\\n        roadAddress\\n        address\\n        imageUrl\\n        imageCount\\n        tags\\n        distance\\n        promotionTitle\\n        category\\n        routeUrl\\n        businessHours\\n        x\\n        y\\n        imageMarker @include(if: $isNmap) {\\n          marker\\n          markerSelected\\n          __typename\\n        }\\n        markerLabel @include(if: $isNmap) {\\n          text\\n          style\\n          __typename\\n        }\\n        isDelivery\\n        isTakeOut\\n        isPreOrder\\n        isTableOrder\\n        naverBookingCategory\\n        bookingDisplayName\\n        bookingBusinessId\\n        bookingVisitId\\n        bookingPickupId\\n        vaccineOpeningHour {\\n          isDayOff\\n          standardTime\\n          __typename\\n        }\\n        vaccineQuantity {\\n          totalQuantity\\n          totalQuantityStatus\\n          startTime\\n          endTime\\n          vaccineOrganizationCode\\n          list {\\n            quantity\\n            quantityStatus\\n            vaccineType\\n            __typename\\n          }\\n          __typename\\n        }\\n        __typename\\n      }\\n      optionsForMap @include(if: $isBounds) {\\n        maxZoom\\n        minZoom\\n        includeMyLocation\\n        maxIncludePoiCount\\n        center\\n        __typename\\n      }\\n      __typename\\n    }\\n    queryResult {\\n      keyword\\n      vaccineFilter\\n      categories\\n      region\\n      isBrandList\\n      filterBooking\\n      hasNearQuery\\n      isPublicMask\\n      __typename\\n    }\\n    __typename\\n  }\\n}\\n\"}]"), &searchResultData)
+	err = t.scraper().FetchJSON(t.context(), "POST", "https://api.place.naver.com/graphql", bytes.NewBufferString("[{\"operationName\":\"vaccineList\",\"variables\":{\"input\":{\"keyword\":\"코로나백신위탁의료기관\",\"x\":\"127.672066\",\"y\":\"34.7635133\"},\"businessesInput\":{\"start\":0,\"display\":100,\"deviceType\":\"mobile\",\"x\":\"127.672066\",\"y\":\"34.7635133\",\"bounds\":\"127.6034014;34.7392187;127.7407305;34.7878008\",\"sortingOrder\":\"distance\"},\"isNmap\":false,\"isBounds\":false},\"query\":\"query vaccineList($input: RestsInput, $businessesInput: RestsBusinessesInput, $isNmap: Boolean!, $isBounds: Boolean!) {\\n  rests(input: $input) {\\n    businesses(input: $businessesInput) {\\n      total\\n      vaccineLastSave\\n      isUpdateDelayed\\n      items {\\n        id\\n        name\\n        dbType\\n        phone\\n        virtualPhone\\n        hasBooking\\n        hasNPay\\n        bookingReviewCount\\n        description\\n        distance\\n        commonAddress\\n        roadAddress\\n        address\\n        imageUrl\\n        imageCount\\n        tags\\n        distance\\n        promotionTitle\\n        category\\n        routeUrl\\n        businessHours\\n        x\\n        y\\n        imageMarker @include(if: $isNmap) {\\n          marker\\n          markerSelected\\n          __typename\\n        }\\n        markerLabel @include(if: $isNmap) {\\n          text\\n          style\\n          __typename\\n        }\\n        isDelivery\\n        isTakeOut\\n        isPreOrder\\n        isTableOrder\\n        naverBookingCategory\\n        bookingDisplayName\\n        bookingBusinessId\\n        bookingVisitId\\n        bookingPickupId\\n        vaccineOpeningHour {\\n          isDayOff\\n          standardTime\\n          __typename\\n        }\\n        vaccineQuantity {\\n          totalQuantity\\n          totalQuantityStatus\\n          startTime\\n          endTime\\n          vaccineOrganizationCode\\n          list {\\n            quantity\\n            quantityStatus\\n            vaccineType\\n            __typename\\n          }\\n          __typename\\n        }\\n        __typename\\n      }\\n      optionsForMap @include(if: $isBounds) {\\n        maxZoom\\n        minZoom\\n        includeMyLocation\\n        maxIncludePoiCount\\n        center\\n        __typename\\n      }\\n      __typename\\n    }\\n    queryResult {\\n      keyword\\n      vaccineFilter\\n      categories\\n      region\\n      isBrandList\\n      filterBooking\\n      hasNearQuery\\n      isPublicMask\\n      __typename\\n    }\\n    __typename\\n  }\\n}\\n\"}]"), nil, &searchResultData)
 	if err != nil {
 		return "", nil, err
 	}
@@ -235,7 +235,7 @@ func (t *covid19Task) runWatchResidualVaccine(taskResultData interface{}, messag
 			m += lineSpacing
 		}
 		if actualityMedicalInstitution.VaccineQuantity != originMedicalInstitution.VaccineQuantity {
-			m += actualityMedicalInstitution.String(messageTypeHTML, " 🔁")
+			m += actualityMedicalInstitution.String(messageTypeHTML, mark.Modified)
 		} else {
 			m += actualityMedicalInstitution.String(messageTypeHTML, "")
 		}
@@ -245,7 +245,7 @@ func (t *covid19Task) runWatchResidualVaccine(taskResultData interface{}, messag
 		if m != "" {
 			m += lineSpacing
 		}
-		m += actualityMedicalInstitution.String(messageTypeHTML, " 🆕")
+		m += actualityMedicalInstitution.String(messageTypeHTML, mark.New)
 	})
 	if err != nil {
 		return "", nil, err
@@ -270,7 +270,7 @@ func (t *covid19Task) runWatchResidualVaccine(taskResultData interface{}, messag
 			m += lineSpacing
 		}
 		originMedicalInstitution.VaccineQuantity = "0"
-		m += originMedicalInstitution.String(messageTypeHTML, " 🔁")
+		m += originMedicalInstitution.String(messageTypeHTML, mark.Modified)
 	})
 	if err != nil {
 		return "", nil, err
@@ -280,7 +280,7 @@ func (t *covid19Task) runWatchResidualVaccine(taskResultData interface{}, messag
 		message = "코로나19 잔여백신에 대한 정보는 아래와 같습니다:\n\n" + m
 		changedTaskResultData = actualityTaskResultData
 	} else {
-		if t.runBy == TaskRunByUser {
+		if t.runBy.IsOnDemand() == true {
 			if len(actualityTaskResultData.MedicalInstitutions) == 0 {
 				message = fmt.Sprintf("코로나19 잔여백신이 없습니다.")
 			} else {
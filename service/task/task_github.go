@@ -0,0 +1,266 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"github.com/darkkaiser/notify-server/g"
+	"github.com/darkkaiser/notify-server/service/task/mark"
+	"github.com/darkkaiser/notify-server/utils"
+	log "github.com/sirupsen/logrus"
+	"html/template"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	githubWatchNewReleasesTaskCommandIDPrefix string = "WatchNewReleases_"
+
+	// TaskID
+	TidGitHub TaskID = "GITHUB" // GitHub(https://github.com/)
+
+	// TaskCommandID
+	TcidGitHubWatchNewReleasesAny = TaskCommandID(githubWatchNewReleasesTaskCommandIDPrefix + taskCommandIDAnyString) // GitHub 저장소 신규 릴리즈 확인
+)
+
+const (
+	// GitHub REST API URL
+	githubAPIBaseUrl = "https://api.github.com"
+
+	// 알림메시지에 포함되는 릴리즈 노트의 최대 글자수
+	githubReleaseBodyMaxLength = 500
+)
+
+// githubRepositoryNameRegexp GitHub 저장소 소유자 및 저장소 이름으로 사용할 수 있는 문자
+var githubRepositoryNameRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+type githubWatchNewReleasesTaskCommandData struct {
+	Owner             string `json:"owner"`
+	Repo              string `json:"repo"`
+	GitHubToken       string `json:"github_token"`
+	IncludePrerelease bool   `json:"include_prerelease"`
+}
+
+func (d *githubWatchNewReleasesTaskCommandData) ApplyDefaults() {
+}
+
+func (d *githubWatchNewReleasesTaskCommandData) Validate() error {
+	if d.Owner == "" {
+		return errors.New("owner가 입력되지 않았습니다")
+	}
+	if githubRepositoryNameRegexp.MatchString(d.Owner) == false {
+		return fmt.Errorf("owner(%s)가 유효하지 않습니다", d.Owner)
+	}
+	if d.Repo == "" {
+		return errors.New("repo가 입력되지 않았습니다")
+	}
+	if githubRepositoryNameRegexp.MatchString(d.Repo) == false {
+		return fmt.Errorf("repo(%s)가 유효하지 않습니다", d.Repo)
+	}
+	return nil
+}
+
+func (d *githubWatchNewReleasesTaskCommandData) repository() string {
+	return fmt.Sprintf("%s/%s", d.Owner, d.Repo)
+}
+
+// githubRelease GitHub REST API에서 반환되는 릴리즈 정보
+type githubRelease struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	HTMLURL     string    `json:"html_url"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+type githubWatchNewReleasesResultData struct {
+	TagName     string `json:"tag_name"`
+	Name        string `json:"name"`
+	Body        string `json:"body"`
+	Link        string `json:"link"`
+	Prerelease  bool   `json:"prerelease"`
+	PublishedAt string `json:"published_at"`
+}
+
+func (r *githubWatchNewReleasesResultData) String(messageTypeHTML bool, mark string) string {
+	name := r.Name
+	if name == "" {
+		name = r.TagName
+	}
+	if r.Prerelease == true {
+		name += " (Pre-release)"
+	}
+
+	// 릴리즈 노트는 최대 글자수까지만 포함한다.
+	body := utils.TruncateWithEllipsis(strings.TrimSpace(r.Body), githubReleaseBodyMaxLength)
+
+	var s string
+	if messageTypeHTML == true {
+		s = fmt.Sprintf("☞ <a href=\"%s\"><b>%s</b></a>%s\n      • 태그 : %s\n      • 배포일 : %s", r.Link, template.HTMLEscapeString(name), mark, template.HTMLEscapeString(r.TagName), r.PublishedAt)
+		if body != "" {
+			s += fmt.Sprintf("\n\n%s", template.HTMLEscapeString(body))
+		}
+	} else {
+		s = fmt.Sprintf("☞ %s%s\n      • 태그 : %s\n      • 배포일 : %s\n%s", name, mark, r.TagName, r.PublishedAt, r.Link)
+		if body != "" {
+			s += fmt.Sprintf("\n\n%s", body)
+		}
+	}
+
+	return s
+}
+
+func init() {
+	supportedTasks[TidGitHub] = &supportedTaskConfig{
+		commandConfigs: []*supportedTaskCommandConfig{{
+			taskCommandID: TcidGitHubWatchNewReleasesAny,
+
+			allowMultipleInstances: true,
+
+			newTaskResultDataFn: func() interface{} { return &githubWatchNewReleasesResultData{} },
+		}},
+
+		newTaskFn: func(instanceID TaskInstanceID, taskRunData *taskRunData, config *g.AppConfig) (taskHandler, error) {
+			if taskRunData.taskID != TidGitHub {
+				return nil, errors.New("등록되지 않은 작업입니다.😱")
+			}
+
+			task := &githubTask{
+				task: task{
+					id:         taskRunData.taskID,
+					commandID:  taskRunData.taskCommandID,
+					instanceID: instanceID,
+
+					notifierID: taskRunData.notifierID,
+
+					canceled: false,
+
+					runBy: taskRunData.taskRunBy,
+				},
+
+				config: config,
+			}
+
+			task.runFn = func(taskResultData interface{}, messageTypeHTML bool) (string, interface{}, error) {
+				// 'WatchNewReleases_'로 시작되는 명령인지 확인한다.
+				if strings.HasPrefix(string(task.CommandID()), githubWatchNewReleasesTaskCommandIDPrefix) == true {
+					for _, t := range task.config.Tasks {
+						if task.ID() == TaskID(t.ID) {
+							for _, c := range t.Commands {
+								if task.CommandID() == TaskCommandID(c.ID) {
+									taskCommandData := &githubWatchNewReleasesTaskCommandData{}
+									if err := fillTaskCommandDataFromMap(taskCommandData, c.Data); err != nil {
+										return "", nil, errors.New(fmt.Sprintf("작업 커맨드 데이터가 유효하지 않습니다.(error:%s)", err))
+									}
+
+									return task.runWatchNewReleases(taskCommandData, taskResultData, messageTypeHTML)
+								}
+							}
+							break
+						}
+					}
+				}
+
+				return "", nil, ErrNoImplementationForTaskCommand
+			}
+
+			return task, nil
+		},
+	}
+}
+
+type githubTask struct {
+	task
+
+	config *g.AppConfig
+}
+
+// fetchLatestRelease 저장소의 가장 최근 릴리즈를 반환한다.
+// Pre-release를 포함하지 않는 경우 '/releases/latest'를 사용하고, 포함하는 경우 릴리즈 목록에서 초안이 아닌 가장 최근 릴리즈를 찾는다.
+// 릴리즈가 존재하지 않는 경우 nil을 반환한다.
+func (t *githubTask) fetchLatestRelease(taskCommandData *githubWatchNewReleasesTaskCommandData) (*githubRelease, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", githubAPIBaseUrl, taskCommandData.repository())
+	if taskCommandData.IncludePrerelease == true {
+		url = fmt.Sprintf("%s/repos/%s/releases?per_page=20", githubAPIBaseUrl, taskCommandData.repository())
+	}
+
+	header := map[string]string{
+		"Accept":               "application/vnd.github+json",
+		"X-GitHub-Api-Version": "2022-11-28",
+	}
+	if taskCommandData.GitHubToken != "" {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", taskCommandData.GitHubToken)
+	}
+
+	if taskCommandData.IncludePrerelease == false {
+		release := &githubRelease{}
+		if err := t.scraper().FetchJSON(t.context(), "GET", url, nil, header, release); err != nil {
+			// 릴리즈가 하나도 없는 저장소는 '/releases/latest'에서 404를 반환한다.
+			var httpErr *HTTPError
+			if errors.As(err, &httpErr) == true && httpErr.StatusCode == http.StatusNotFound {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return release, nil
+	}
+
+	var releases []*githubRelease
+	if err := t.scraper().FetchJSON(t.context(), "GET", url, nil, header, &releases); err != nil {
+		return nil, err
+	}
+	for _, release := range releases {
+		if release.Draft == false {
+			return release, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (t *githubTask) runWatchNewReleases(taskCommandData *githubWatchNewReleasesTaskCommandData, taskResultData interface{}, messageTypeHTML bool) (message string, changedTaskResultData interface{}, err error) {
+	originTaskResultData, ok := taskResultData.(*githubWatchNewReleasesResultData)
+	if ok == false {
+		log.Panic("TaskResultData의 타입 변환이 실패하였습니다.")
+	}
+
+	release, err := t.fetchLatestRelease(taskCommandData)
+	if err != nil {
+		return "", nil, err
+	}
+
+	repository := taskCommandData.repository()
+
+	if release == nil {
+		if t.runBy.IsOnDemand() == true {
+			message = fmt.Sprintf("'%s' 저장소에 등록된 릴리즈가 존재하지 않습니다.", repository)
+		}
+		return message, nil, nil
+	}
+
+	actualityTaskResultData := &githubWatchNewReleasesResultData{
+		TagName:     utils.Trim(release.TagName),
+		Name:        utils.Trim(release.Name),
+		Body:        release.Body,
+		Link:        release.HTMLURL,
+		Prerelease:  release.Prerelease,
+		PublishedAt: release.PublishedAt.Local().Format("2006-01-02 15:04"),
+	}
+
+	//
+	// 태그 이름이 변경된 경우 새로운 릴리즈가 등록된 것으로 판단한다.
+	//
+	if actualityTaskResultData.TagName != originTaskResultData.TagName {
+		message = fmt.Sprintf("'%s' 저장소에 새로운 릴리즈가 등록되었습니다.\n\n%s", repository, actualityTaskResultData.String(messageTypeHTML, mark.New))
+		changedTaskResultData = actualityTaskResultData
+	} else {
+		if t.runBy.IsOnDemand() == true {
+			message = fmt.Sprintf("'%s' 저장소에 새로 등록된 릴리즈가 없습니다.\n\n가장 최근에 등록된 릴리즈는 아래와 같습니다:\n\n%s", repository, actualityTaskResultData.String(messageTypeHTML, ""))
+		}
+	}
+
+	return message, changedTaskResultData, nil
+}
@@ -0,0 +1,125 @@
+package task
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestGitHubTask_RunWatchNewReleases(t *testing.T) {
+	const (
+		latestURL   = githubAPIBaseUrl + "/repos/darkkaiser/notify-server/releases/latest"
+		releasesURL = githubAPIBaseUrl + "/repos/darkkaiser/notify-server/releases?per_page=20"
+	)
+
+	cases := []struct {
+		name              string
+		url               string
+		statusCode        int
+		body              string
+		includePrerelease bool
+		runBy             TaskRunBy
+		originTagName     string
+
+		expectedError    bool
+		expectedMessage  string
+		expectedTagName  string
+		expectedNoChange bool
+	}{
+		{
+			name:            "새로운 릴리즈",
+			url:             latestURL,
+			statusCode:      http.StatusOK,
+			body:            `{"tag_name":"v1.1.0","name":"1.1.0","html_url":"https://github.com/darkkaiser/notify-server/releases/tag/v1.1.0"}`,
+			runBy:           TaskRunByScheduler,
+			originTagName:   "v1.0.0",
+			expectedMessage: "새로운 릴리즈가 등록되었습니다",
+			expectedTagName: "v1.1.0",
+		},
+		{
+			name:             "변경되지 않은 릴리즈",
+			url:              latestURL,
+			statusCode:       http.StatusOK,
+			body:             `{"tag_name":"v1.0.0","name":"1.0.0"}`,
+			runBy:            TaskRunByScheduler,
+			originTagName:    "v1.0.0",
+			expectedNoChange: true,
+		},
+		{
+			name:             "변경되지 않은 릴리즈(사용자 요청)",
+			url:              latestURL,
+			statusCode:       http.StatusOK,
+			body:             `{"tag_name":"v1.0.0","name":"1.0.0"}`,
+			runBy:            TaskRunByUser,
+			originTagName:    "v1.0.0",
+			expectedMessage:  "새로 등록된 릴리즈가 없습니다",
+			expectedNoChange: true,
+		},
+		{
+			name:             "릴리즈가 없는 저장소(404)",
+			url:              latestURL,
+			statusCode:       http.StatusNotFound,
+			body:             `{"message":"Not Found"}`,
+			runBy:            TaskRunByUser,
+			expectedMessage:  "등록된 릴리즈가 존재하지 않습니다",
+			expectedNoChange: true,
+		},
+		{
+			name:          "서버 오류",
+			url:           latestURL,
+			statusCode:    http.StatusInternalServerError,
+			body:          `{"message":"Server Error"}`,
+			runBy:         TaskRunByScheduler,
+			expectedError: true,
+		},
+		{
+			name:              "Pre-release 포함",
+			url:               releasesURL,
+			statusCode:        http.StatusOK,
+			body:              `[{"tag_name":"v2.0.0","draft":true},{"tag_name":"v2.0.0-rc1","prerelease":true},{"tag_name":"v1.1.0"}]`,
+			includePrerelease: true,
+			runBy:             TaskRunByScheduler,
+			originTagName:     "v1.1.0",
+			expectedMessage:   "(Pre-release)",
+			expectedTagName:   "v2.0.0-rc1",
+		},
+		{
+			name:              "초안만 있는 저장소",
+			url:               releasesURL,
+			statusCode:        http.StatusOK,
+			body:              `[{"tag_name":"v2.0.0","draft":true}]`,
+			includePrerelease: true,
+			runBy:             TaskRunByScheduler,
+			originTagName:     "v1.1.0",
+			expectedNoChange:  true,
+		},
+	}
+
+	for _, c := range cases {
+		mock := NewMockHTTPFetcher()
+		useMockFetcher(t, mock)
+		mock.SetResponse(c.url, c.statusCode, c.body)
+
+		taskCommandData := &githubWatchNewReleasesTaskCommandData{Owner: "darkkaiser", Repo: "notify-server", IncludePrerelease: c.includePrerelease}
+		gt := &githubTask{task: task{runBy: c.runBy}}
+
+		message, changedTaskResultData, err := gt.runWatchNewReleases(taskCommandData, &githubWatchNewReleasesResultData{TagName: c.originTagName}, false)
+		if c.expectedError == true {
+			assert.Error(t, err, c.name)
+			continue
+		}
+		assert.NoError(t, err, c.name)
+
+		if c.expectedMessage == "" {
+			assert.Equal(t, "", message, c.name)
+		} else {
+			assert.Contains(t, message, c.expectedMessage, c.name)
+		}
+
+		if c.expectedNoChange == true {
+			assert.Nil(t, changedTaskResultData, c.name)
+		} else if assert.NotNil(t, changedTaskResultData, c.name) == true {
+			assert.Equal(t, c.expectedTagName, changedTaskResultData.(*githubWatchNewReleasesResultData).TagName, c.name)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/darkkaiser/notify-server/g"
+	"github.com/darkkaiser/notify-server/utils"
+	log "github.com/sirupsen/logrus"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	interparkWatchTicketTaskCommandIDPrefix string = "WatchTicket_"
+
+	// TaskID
+	TidInterpark TaskID = "INTERPARK" // 인터파크 티켓(https://tickets.interpark.com/)
+
+	// TaskCommandID
+	TcidInterparkWatchTicketAny = TaskCommandID(interparkWatchTicketTaskCommandIDPrefix + taskCommandIDAnyString) // 인터파크 티켓 예매 가능 여부 확인
+)
+
+type interparkWatchTicketTaskCommandData struct {
+	ProductURL string `json:"product_url"`
+	Filters    struct {
+		Seat struct {
+			IncludedKeywords string `json:"included_keywords"`
+			ExcludedKeywords string `json:"excluded_keywords"`
+		} `json:"seat"`
+	} `json:"filters"`
+}
+
+func (d *interparkWatchTicketTaskCommandData) ApplyDefaults() {
+}
+
+func (d *interparkWatchTicketTaskCommandData) Validate() error {
+	if d.ProductURL == "" {
+		return errors.New("product_url이 입력되지 않았습니다")
+	}
+	if u, err := url.Parse(d.ProductURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("product_url(%s)이 유효하지 않습니다", d.ProductURL)
+	}
+	return nil
+}
+
+type interparkSeat struct {
+	Grade  string `json:"grade"`
+	Remain int    `json:"remain"`
+}
+
+func (s *interparkSeat) String() string {
+	if s.Remain > 0 {
+		return fmt.Sprintf("      • %s : %s석", s.Grade, utils.FormatCommas(s.Remain))
+	}
+	return fmt.Sprintf("      • %s : 매진", s.Grade)
+}
+
+type interparkWatchTicketResultData struct {
+	Title     string           `json:"title"`
+	Available bool             `json:"available"`
+	Seats     []*interparkSeat `json:"seats"`
+}
+
+// availableSeat 예매 가능한 좌석이 있는지 확인한다.
+func (d *interparkWatchTicketResultData) availableSeat() bool {
+	if d.Available == false {
+		return false
+	}
+
+	// 좌석 정보가 제공되지 않는 상품은 예매 버튼의 활성화 여부로만 판단한다.
+	if len(d.Seats) == 0 {
+		return true
+	}
+
+	for _, seat := range d.Seats {
+		if seat.Remain > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (d *interparkWatchTicketResultData) String(messageTypeHTML bool, productURL string) string {
+	var m string
+	if messageTypeHTML == true {
+		m = fmt.Sprintf("☞ <a href=\"%s\"><b>%s</b></a>", productURL, d.Title)
+	} else {
+		m = fmt.Sprintf("☞ %s\n%s", d.Title, productURL)
+	}
+
+	for _, seat := range d.Seats {
+		m += fmt.Sprintf("\n%s", seat.String())
+	}
+
+	return m
+}
+
+func init() {
+	supportedTasks[TidInterpark] = &supportedTaskConfig{
+		commandConfigs: []*supportedTaskCommandConfig{{
+			taskCommandID: TcidInterparkWatchTicketAny,
+
+			allowMultipleInstances: true,
+
+			newTaskResultDataFn: func() interface{} { return &interparkWatchTicketResultData{} },
+		}},
+
+		newTaskFn: func(instanceID TaskInstanceID, taskRunData *taskRunData, config *g.AppConfig) (taskHandler, error) {
+			if taskRunData.taskID != TidInterpark {
+				return nil, errors.New("등록되지 않은 작업입니다.😱")
+			}
+
+			task := &interparkTask{
+				task: task{
+					id:         taskRunData.taskID,
+					commandID:  taskRunData.taskCommandID,
+					instanceID: instanceID,
+
+					notifierID: taskRunData.notifierID,
+
+					canceled: false,
+
+					runBy: taskRunData.taskRunBy,
+				},
+
+				config: config,
+			}
+
+			task.runFn = func(taskResultData interface{}, messageTypeHTML bool) (string, interface{}, error) {
+				// 'WatchTicket_'로 시작되는 명령인지 확인한다.
+				if strings.HasPrefix(string(task.CommandID()), interparkWatchTicketTaskCommandIDPrefix) == true {
+					for _, t := range task.config.Tasks {
+						if task.ID() == TaskID(t.ID) {
+							for _, c := range t.Commands {
+								if task.CommandID() == TaskCommandID(c.ID) {
+									taskCommandData := &interparkWatchTicketTaskCommandData{}
+									if err := fillTaskCommandDataFromMap(taskCommandData, c.Data); err != nil {
+										return "", nil, errors.New(fmt.Sprintf("작업 커맨드 데이터가 유효하지 않습니다.(error:%s)", err))
+									}
+
+									return task.runWatchTicket(taskCommandData, taskResultData, messageTypeHTML)
+								}
+							}
+							break
+						}
+					}
+				}
+
+				return "", nil, ErrNoImplementationForTaskCommand
+			}
+
+			return task, nil
+		},
+	}
+}
+
+type interparkTask struct {
+	task
+
+	config *g.AppConfig
+}
+
+func (t *interparkTask) runWatchTicket(taskCommandData *interparkWatchTicketTaskCommandData, taskResultData interface{}, messageTypeHTML bool) (message string, changedTaskResultData interface{}, err error) {
+	originTaskResultData, ok := taskResultData.(*interparkWatchTicketResultData)
+	if ok == false {
+		log.Panic("TaskResultData의 타입 변환이 실패하였습니다.")
+	}
+
+	//
+	// 상품 페이지를 불러온다.
+	//
+	header := map[string]string{
+		"User-Agent":      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36",
+		"Accept-Language": "ko-KR,ko;q=0.9",
+	}
+	doc, err := t.newHTMLDocumentWithHeader(taskCommandData.ProductURL, header)
+	if err != nil {
+		return "", nil, err
+	}
+
+	actualityTaskResultData := &interparkWatchTicketResultData{}
+
+	// 상품명
+	title, exists := doc.Find("meta[property='og:title']").Attr("content")
+	if exists == false || utils.Trim(title) == "" {
+		return "", nil, errors.New("상품명 추출이 실패하였습니다. CSS셀렉터를 확인하세요")
+	}
+	actualityTaskResultData.Title = utils.Trim(title)
+
+	// 예매 버튼의 활성화 여부
+	ps := doc.Find("a.sideBtn.is-primary")
+	actualityTaskResultData.Available = ps.Length() > 0 && ps.HasClass("is-disabled") == false && strings.Contains(ps.Text(), "예매하기") == true
+
+	// 좌석 등급별 잔여석
+	var err0 error
+	keywordMatcher, err := utils.NewKeywordMatcherWithValidation(utils.SplitExceptEmptyItems(taskCommandData.Filters.Seat.IncludedKeywords, ","), utils.SplitExceptEmptyItems(taskCommandData.Filters.Seat.ExcludedKeywords, ","))
+	if err != nil {
+		return "", nil, err
+	}
+	var remainReplacer = strings.NewReplacer(",", "", "석", "")
+	doc.Find("ul.seatTableList > li.seatTableItem").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		ss := s.Find("span.seatTableName")
+		if ss.Length() != 1 {
+			err0 = errors.New("좌석 등급 추출이 실패하였습니다. CSS셀렉터를 확인하세요")
+			return false
+		}
+		grade := utils.Trim(ss.Text())
+
+		if keywordMatcher.Match(grade) == false {
+			return true
+		}
+
+		ss = s.Find("span.seatTableStatus")
+		if ss.Length() != 1 {
+			err0 = errors.New("좌석 잔여석 추출이 실패하였습니다. CSS셀렉터를 확인하세요")
+			return false
+		}
+		remainText := utils.Trim(ss.Text())
+
+		var remain = 0
+		if strings.Contains(remainText, "매진") == false {
+			remain, err = strconv.Atoi(utils.Trim(remainReplacer.Replace(remainText)))
+			if err != nil {
+				err0 = fmt.Errorf("좌석 잔여석의 숫자 변환이 실패하였습니다.(error:%s)", err)
+				return false
+			}
+		}
+
+		actualityTaskResultData.Seats = append(actualityTaskResultData.Seats, &interparkSeat{
+			Grade:  grade,
+			Remain: remain,
+		})
+
+		return true
+	})
+	if err0 != nil {
+		return "", nil, err0
+	}
+
+	//
+	// 예매 가능 여부의 변경 사항을 확인한다.
+	//
+	actualityAvailable := actualityTaskResultData.availableSeat()
+	originAvailable := originTaskResultData.availableSeat()
+
+	if actualityAvailable != originAvailable {
+		if actualityAvailable == true {
+			message = fmt.Sprintf("🚨🚨 티켓 예매가 가능해졌습니다. 서둘러 예매하세요!!! 🚨🚨\n\n%s", actualityTaskResultData.String(messageTypeHTML, taskCommandData.ProductURL))
+		} else {
+			message = fmt.Sprintf("티켓이 매진되었습니다.\n\n%s", actualityTaskResultData.String(messageTypeHTML, taskCommandData.ProductURL))
+		}
+		changedTaskResultData = actualityTaskResultData
+	} else {
+		if t.runBy.IsOnDemand() == true {
+			if actualityAvailable == true {
+				message = fmt.Sprintf("티켓 예매가 가능합니다.\n\n%s", actualityTaskResultData.String(messageTypeHTML, taskCommandData.ProductURL))
+			} else {
+				message = fmt.Sprintf("티켓이 매진된 상태입니다.\n\n%s", actualityTaskResultData.String(messageTypeHTML, taskCommandData.ProductURL))
+			}
+		}
+	}
+
+	return message, changedTaskResultData, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/darkkaiser/notify-server/g"
+	"github.com/darkkaiser/notify-server/service/task/mark"
 	"github.com/darkkaiser/notify-server/utils"
 	log "github.com/sirupsen/logrus"
 	"strings"
@@ -129,7 +130,7 @@ func (t *jdcTask) runWatchNewOnlineEducation(taskResultData interface{}, message
 		if m != "" {
 			m += lineSpacing
 		}
-		m += actualityEducationCourse.String(messageTypeHTML, " 🆕")
+		m += actualityEducationCourse.String(messageTypeHTML, mark.New)
 	})
 	if err != nil {
 		return "", nil, err
@@ -139,7 +140,7 @@ func (t *jdcTask) runWatchNewOnlineEducation(taskResultData interface{}, message
 		message = "새로운 온라인교육 강의가 등록되었습니다.\n\n" + m
 		changedTaskResultData = actualityTaskResultData
 	} else {
-		if t.runBy == TaskRunByUser {
+		if t.runBy.IsOnDemand() == true {
 			if len(actualityTaskResultData.OnlineEducationCourses) == 0 {
 				message = "등록된 온라인교육 강의가 존재하지 않습니다."
 			} else {
@@ -162,7 +163,7 @@ func (t *jdcTask) scrapeOnlineEducationCourses(url string) ([]*jdcOnlineEducatio
 	// 온라인교육 강의 목록페이지 URL 정보를 추출한다.
 	var err, err0 error
 	var courseURLs = make([]string, 0)
-	err = webScrape(url, "#content > ul.prdt-list2 > li > a.link", func(i int, s *goquery.Selection) bool {
+	err = t.webScrape(url, "#content > ul.prdt-list2 > li > a.link", func(i int, s *goquery.Selection) bool {
 		courseURL, exists := s.Attr("href")
 		if exists == false {
 			err0 = errors.New("강의 목록페이지 URL 추출이 실패하였습니다. CSS셀렉터를 확인하세요")
@@ -175,7 +176,7 @@ func (t *jdcTask) scrapeOnlineEducationCourses(url string) ([]*jdcOnlineEducatio
 	})
 	if err != nil {
 		// 온라인교육 강의 데이터가 없는지 확인한다.
-		if sel, _ := newHTMLDocumentSelection(url, "#content > div.no-data2"); sel != nil {
+		if sel, _ := t.newHTMLDocumentSelection(url, "#content > div.no-data2"); sel != nil {
 			return nil, nil
 		}
 
@@ -212,7 +213,7 @@ func (t *jdcTask) scrapeOnlineEducationCourseCurriculums(url string, curriculumW
 	var err0 error
 	var onlineEducationCourseCurriculums = make([]*jdcOnlineEducationCourse, 0)
 
-	err := webScrape(fmt.Sprintf("%sproduct/%s", jdcBaseUrl, url), "table.prdt-tbl > tbody > tr", func(i int, s *goquery.Selection) bool {
+	err := t.webScrape(fmt.Sprintf("%sproduct/%s", jdcBaseUrl, url), "table.prdt-tbl > tbody > tr", func(i int, s *goquery.Selection) bool {
 		// 강의목록 컬럼 개수를 확인한다.
 		as := s.Find("td")
 		if as.Length() != 3 {
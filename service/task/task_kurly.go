@@ -0,0 +1,246 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/darkkaiser/notify-server/g"
+	"github.com/darkkaiser/notify-server/utils"
+	log "github.com/sirupsen/logrus"
+	"strings"
+)
+
+const (
+	kurlyWatchAvailabilityTaskCommandIDPrefix string = "WatchAvailability_"
+
+	// TaskID
+	TidKurly TaskID = "KURLY" // 마켓컬리(https://www.kurly.com/)
+
+	// TaskCommandID
+	TcidKurlyWatchAvailabilityAny = TaskCommandID(kurlyWatchAvailabilityTaskCommandIDPrefix + taskCommandIDAnyString) // 마켓컬리 상품 재고 확인
+)
+
+const (
+	kurlyBaseUrl = "https://www.kurly.com"
+)
+
+const (
+	// 재고 변경 이벤트
+	kurlyEventTypeRestocked = "restocked" // 품절 → 입고
+	kurlyEventTypeSoldOut   = "sold_out"  // 입고 → 품절
+)
+
+type kurlyWatchAvailabilityTaskCommandData struct {
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Filters     struct {
+		EventTypes string `json:"event_types"`
+	} `json:"filters"`
+}
+
+func (d *kurlyWatchAvailabilityTaskCommandData) ApplyDefaults() {
+	// 알림을 받을 재고 변경 이벤트가 입력되지 않은 경우 입고 이벤트만 알린다.
+	if len(utils.SplitExceptEmptyItems(d.Filters.EventTypes, ",")) == 0 {
+		d.Filters.EventTypes = kurlyEventTypeRestocked
+	}
+}
+
+func (d *kurlyWatchAvailabilityTaskCommandData) Validate() error {
+	if d.ProductID == "" {
+		return errors.New("product_id가 입력되지 않았습니다")
+	}
+	for _, eventType := range d.eventTypes() {
+		if eventType != kurlyEventTypeRestocked && eventType != kurlyEventTypeSoldOut {
+			return fmt.Errorf("event_types에 지원하지 않는 이벤트(%s)가 입력되었습니다", eventType)
+		}
+	}
+	return nil
+}
+
+// eventTypes 알림을 받을 재고 변경 이벤트 목록을 반환한다.
+func (d *kurlyWatchAvailabilityTaskCommandData) eventTypes() []string {
+	return utils.SplitExceptEmptyItems(d.Filters.EventTypes, ",")
+}
+
+type kurlyProduct struct {
+	ProductID string `json:"product_id"`
+	Name      string `json:"name"`
+	SoldOut   bool   `json:"sold_out"`
+}
+
+func (p *kurlyProduct) String(messageTypeHTML bool, name string) string {
+	link := fmt.Sprintf("%s/goods/%s", kurlyBaseUrl, p.ProductID)
+
+	status := "구매 가능"
+	if p.SoldOut == true {
+		status = "품절"
+	}
+
+	if messageTypeHTML == true {
+		return fmt.Sprintf("☞ <a href=\"%s\"><b>%s</b></a>\n      • 상태 : %s", link, name, status)
+	}
+	return strings.TrimSpace(fmt.Sprintf("☞ %s\n      • 상태 : %s\n%s", name, status, link))
+}
+
+type kurlyWatchAvailabilityResultData struct {
+	Product *kurlyProduct `json:"product"`
+}
+
+func init() {
+	supportedTasks[TidKurly] = &supportedTaskConfig{
+		commandConfigs: []*supportedTaskCommandConfig{{
+			taskCommandID: TcidKurlyWatchAvailabilityAny,
+
+			allowMultipleInstances: true,
+
+			newTaskResultDataFn: func() interface{} { return &kurlyWatchAvailabilityResultData{} },
+		}},
+
+		newTaskFn: func(instanceID TaskInstanceID, taskRunData *taskRunData, config *g.AppConfig) (taskHandler, error) {
+			if taskRunData.taskID != TidKurly {
+				return nil, errors.New("등록되지 않은 작업입니다.😱")
+			}
+
+			task := &kurlyTask{
+				task: task{
+					id:         taskRunData.taskID,
+					commandID:  taskRunData.taskCommandID,
+					instanceID: instanceID,
+
+					notifierID: taskRunData.notifierID,
+
+					canceled: false,
+
+					runBy: taskRunData.taskRunBy,
+				},
+
+				config: config,
+			}
+
+			task.runFn = func(taskResultData interface{}, messageTypeHTML bool) (string, interface{}, error) {
+				// 'WatchAvailability_'로 시작되는 명령인지 확인한다.
+				if strings.HasPrefix(string(task.CommandID()), kurlyWatchAvailabilityTaskCommandIDPrefix) == true {
+					for _, t := range task.config.Tasks {
+						if task.ID() == TaskID(t.ID) {
+							for _, c := range t.Commands {
+								if task.CommandID() == TaskCommandID(c.ID) {
+									taskCommandData := &kurlyWatchAvailabilityTaskCommandData{}
+									if err := fillTaskCommandDataFromMap(taskCommandData, c.Data); err != nil {
+										return "", nil, errors.New(fmt.Sprintf("작업 커맨드 데이터가 유효하지 않습니다.(error:%s)", err))
+									}
+
+									return task.runWatchAvailability(taskCommandData, taskResultData, messageTypeHTML)
+								}
+							}
+							break
+						}
+					}
+				}
+
+				return "", nil, ErrNoImplementationForTaskCommand
+			}
+
+			return task, nil
+		},
+	}
+}
+
+type kurlyTask struct {
+	task
+
+	config *g.AppConfig
+}
+
+func (t *kurlyTask) runWatchAvailability(taskCommandData *kurlyWatchAvailabilityTaskCommandData, taskResultData interface{}, messageTypeHTML bool) (message string, changedTaskResultData interface{}, err error) {
+	originTaskResultData, ok := taskResultData.(*kurlyWatchAvailabilityResultData)
+	if ok == false {
+		log.Panic("TaskResultData의 타입 변환이 실패하였습니다.")
+	}
+
+	//
+	// 상품 페이지에 포함된 상품 정보(JSON)를 읽어들인다.
+	//
+	header := map[string]string{
+		"User-Agent":      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36",
+		"Accept-Language": "ko-KR,ko;q=0.9",
+	}
+	doc, err := t.newHTMLDocumentWithHeader(fmt.Sprintf("%s/goods/%s", kurlyBaseUrl, taskCommandData.ProductID), header)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ps := doc.Find("script#__NEXT_DATA__")
+	if ps.Length() != 1 {
+		return "", nil, errors.New("상품 정보 추출이 실패하였습니다. CSS셀렉터를 확인하세요")
+	}
+
+	var nextData struct {
+		Props struct {
+			PageProps struct {
+				Product *struct {
+					Name      string `json:"name"`
+					IsSoldOut bool   `json:"isSoldOut"`
+				} `json:"product"`
+			} `json:"pageProps"`
+		} `json:"props"`
+	}
+	if err = json.Unmarshal([]byte(ps.Text()), &nextData); err != nil {
+		return "", nil, fmt.Errorf("상품 정보의 JSON 변환이 실패하였습니다.(error:%s)", err)
+	}
+	if nextData.Props.PageProps.Product == nil {
+		return "", nil, fmt.Errorf("상품(%s) 정보를 찾을 수 없습니다", taskCommandData.ProductID)
+	}
+
+	actualityTaskResultData := &kurlyWatchAvailabilityResultData{
+		Product: &kurlyProduct{
+			ProductID: taskCommandData.ProductID,
+			Name:      utils.Trim(nextData.Props.PageProps.Product.Name),
+			SoldOut:   nextData.Props.PageProps.Product.IsSoldOut,
+		},
+	}
+
+	productName := taskCommandData.ProductName
+	if productName == "" {
+		productName = actualityTaskResultData.Product.Name
+	}
+
+	//
+	// 재고 상태의 변경 여부를 확인한다.
+	//
+	originProduct := originTaskResultData.Product
+	actualityProduct := actualityTaskResultData.Product
+
+	var eventType string
+	if originProduct != nil && originProduct.SoldOut != actualityProduct.SoldOut {
+		if actualityProduct.SoldOut == true {
+			eventType = kurlyEventTypeSoldOut
+		} else {
+			eventType = kurlyEventTypeRestocked
+		}
+	}
+
+	if eventType != "" && utils.Contains(taskCommandData.eventTypes(), eventType) == true {
+		switch eventType {
+		case kurlyEventTypeRestocked:
+			message = fmt.Sprintf("품절되었던 상품이 입고되었습니다. 🛒\n\n%s", actualityProduct.String(messageTypeHTML, productName))
+		case kurlyEventTypeSoldOut:
+			message = fmt.Sprintf("상품이 품절되었습니다.\n\n%s", actualityProduct.String(messageTypeHTML, productName))
+		}
+	} else {
+		if t.runBy.IsOnDemand() == true {
+			if eventType != "" {
+				// 재고 상태가 변경되었지만 알림 대상 이벤트가 아닌 경우
+				message = fmt.Sprintf("상품의 재고 상태가 변경되었지만 알림 대상 이벤트(%s)가 아닙니다.\n\n%s", eventType, actualityProduct.String(messageTypeHTML, productName))
+			} else {
+				message = fmt.Sprintf("상품의 재고 상태가 변경되지 않았습니다.\n\n%s", actualityProduct.String(messageTypeHTML, productName))
+			}
+		}
+	}
+
+	// 알림 대상이 아닌 이벤트라도 재고 상태가 변경되었다면 작업결과데이터를 갱신한다.
+	if originProduct == nil || eventType != "" || originProduct.Name != actualityProduct.Name {
+		changedTaskResultData = actualityTaskResultData
+	}
+
+	return message, changedTaskResultData, nil
+}
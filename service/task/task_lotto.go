@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
@@ -19,15 +20,232 @@ const (
 	TidLotto TaskID = "LOTTO"
 
 	// TaskCommandID
-	TcidLottoPrediction TaskCommandID = "Prediction" // 로또 번호 예측
+	TcidLottoPrediction      TaskCommandID = "Prediction"      // 로또 번호 예측
+	TcidLottoWatchDrawResult TaskCommandID = "WatchDrawResult" // 로또 당첨번호 확인
+
+	// 로또 당첨번호 조회 URL
+	lottoDrawResultUrl = "https://www.dhlottery.co.kr/common.do?method=getLottoNumber&drwNo=%d"
+
+	// 작업결과데이터에 보관하는 최근 당첨번호 회차의 최대 갯수
+	lottoMaxDrawHistoryCount = 10
+
+	// 번호별 출현 빈도를 분석하는 최근 회차의 기본 갯수와 최대 갯수
+	lottoDefaultAnalyzeHistoryCount = 50
+	lottoMaxAnalyzeHistoryCount     = 200
 )
 
+// 로또 1회차 추첨일
+var lottoFirstDrawDate = time.Date(2002, 12, 7, 21, 0, 0, 0, time.FixedZone("KST", 9*60*60))
+
 type lottoTaskData struct {
 	AppPath string `json:"app_path"`
 }
 
+func (d *lottoTaskData) ApplyDefaults() {
+	d.AppPath = strings.Trim(d.AppPath, " ")
+}
+
+func (d *lottoTaskData) Validate() error {
+	if d.AppPath == "" {
+		return errors.New("app_path가 입력되지 않았습니다")
+	}
+	return nil
+}
+
 type lottoPredictionResultData struct{}
 
+type lottoWatchDrawResultTaskCommandData struct {
+	// 당첨 여부를 확인할 번호 목록(각 항목은 6개의 번호로 구성된다)
+	WatchMyNumbers [][]int `json:"watch_my_numbers"`
+
+	// 최근 HistoryCount개 회차의 번호별 출현 빈도를 분석하여 별도의 메시지로 발송할지의 여부
+	AnalyzeHistory bool `json:"analyze_history"`
+	HistoryCount   int  `json:"history_count"`
+}
+
+func (d *lottoWatchDrawResultTaskCommandData) ApplyDefaults() {
+	if d.HistoryCount == 0 {
+		d.HistoryCount = lottoDefaultAnalyzeHistoryCount
+	}
+}
+
+func (d *lottoWatchDrawResultTaskCommandData) Validate() error {
+	if d.HistoryCount < 1 || d.HistoryCount > lottoMaxAnalyzeHistoryCount {
+		return fmt.Errorf("history_count에 1~%d 범위를 벗어난 값이 입력되었습니다", lottoMaxAnalyzeHistoryCount)
+	}
+	for i, numbers := range d.WatchMyNumbers {
+		if len(numbers) != 6 {
+			return fmt.Errorf("watch_my_numbers의 %d번째 항목은 6개의 번호로 구성되어야 합니다", i+1)
+		}
+
+		used := make(map[int]bool)
+		for _, n := range numbers {
+			if n < 1 || n > 45 {
+				return fmt.Errorf("watch_my_numbers의 %d번째 항목에 1~45 범위를 벗어난 번호(%d)가 입력되었습니다", i+1, n)
+			}
+			if used[n] == true {
+				return fmt.Errorf("watch_my_numbers의 %d번째 항목에 중복된 번호(%d)가 입력되었습니다", i+1, n)
+			}
+			used[n] = true
+		}
+	}
+	return nil
+}
+
+type lottoDrawResultSearchResultData struct {
+	ReturnValue string `json:"returnValue"`
+	DrawNo      int    `json:"drwNo"`
+	DrawDate    string `json:"drwNoDate"`
+	No1         int    `json:"drwtNo1"`
+	No2         int    `json:"drwtNo2"`
+	No3         int    `json:"drwtNo3"`
+	No4         int    `json:"drwtNo4"`
+	No5         int    `json:"drwtNo5"`
+	No6         int    `json:"drwtNo6"`
+	BonusNo     int    `json:"bnusNo"`
+}
+
+type lottoDraw struct {
+	DrawNo   int    `json:"draw_no"`
+	DrawDate string `json:"draw_date"`
+	Numbers  []int  `json:"numbers"`
+	BonusNo  int    `json:"bonus_no"`
+}
+
+func (d *lottoDraw) String() string {
+	return fmt.Sprintf("☞ 제%d회 (%s)\n      • 당첨번호 : %s + %02d", d.DrawNo, d.DrawDate, lottoNumbersString(d.Numbers), d.BonusNo)
+}
+
+// rank 번호의 일치 갯수와 당첨 등수를 반환한다. 낙첨인 경우 등수는 0이다.
+func (d *lottoDraw) rank(numbers []int) (matchCount int, rank int) {
+	bonusMatched := false
+	for _, n := range numbers {
+		matched := false
+		for _, drawNumber := range d.Numbers {
+			if n == drawNumber {
+				matched = true
+				break
+			}
+		}
+
+		if matched == true {
+			matchCount++
+		} else if n == d.BonusNo {
+			bonusMatched = true
+		}
+	}
+
+	switch {
+	case matchCount == 6:
+		rank = 1
+	case matchCount == 5 && bonusMatched == true:
+		rank = 2
+	case matchCount == 5:
+		rank = 3
+	case matchCount == 4:
+		rank = 4
+	case matchCount == 3:
+		rank = 5
+	}
+
+	return matchCount, rank
+}
+
+func lottoNumbersString(numbers []int) string {
+	s := make([]string, 0, len(numbers))
+	for _, n := range numbers {
+		s = append(s, fmt.Sprintf("%02d", n))
+	}
+	return strings.Join(s, " ")
+}
+
+// lottoNumberFrequency FromDrawNo~ToDrawNo 회차의 당첨번호(보너스 번호 제외)에서 번호별 출현 횟수
+type lottoNumberFrequency struct {
+	HistoryCount int `json:"history_count"`
+	FromDrawNo   int `json:"from_draw_no"`
+	ToDrawNo     int `json:"to_draw_no"`
+
+	// 1~45번 번호의 출현 횟수(0번 인덱스가 1번 번호의 출현 횟수이다)
+	Counts []int `json:"counts"`
+}
+
+func (f *lottoNumberFrequency) add(d *lottoDraw, delta int) {
+	for _, n := range d.Numbers {
+		if n >= 1 && n <= 45 {
+			f.Counts[n-1] += delta
+		}
+	}
+}
+
+// lottoFrequencyBlocks 출현 빈도가 낮은 구간부터 높은 구간의 순서로 표시하는 문자
+var lottoFrequencyBlocks = []string{"░", "▒", "▓", "█"}
+
+// String 번호별 출현 빈도를 블록 문자를 이용한 히트맵으로 표시한다.
+func (f *lottoNumberFrequency) String() string {
+	minCount, maxCount := f.Counts[0], f.Counts[0]
+	for _, c := range f.Counts {
+		if c < minCount {
+			minCount = c
+		}
+		if c > maxCount {
+			maxCount = c
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("최근 %d개 회차(제%d회~제%d회)의 번호별 출현 빈도는 아래와 같습니다:\n", f.ToDrawNo-f.FromDrawNo+1, f.FromDrawNo, f.ToDrawNo))
+	for i, c := range f.Counts {
+		if i%9 == 0 {
+			sb.WriteString("\n")
+		} else {
+			sb.WriteString(" ")
+		}
+
+		level := len(lottoFrequencyBlocks) / 2
+		if maxCount > minCount {
+			level = (c - minCount) * (len(lottoFrequencyBlocks) - 1) / (maxCount - minCount)
+		}
+		sb.WriteString(fmt.Sprintf("%02d%s", i+1, lottoFrequencyBlocks[level]))
+	}
+	sb.WriteString(fmt.Sprintf("\n\n(%s 많음 ~ %s 적음)", lottoFrequencyBlocks[len(lottoFrequencyBlocks)-1], lottoFrequencyBlocks[0]))
+
+	// 출현 횟수가 많은 순서로 정렬하며, 출현 횟수가 같은 경우 번호 순서로 정렬한다.
+	numbers := make([]int, 45)
+	for i := range numbers {
+		numbers[i] = i + 1
+	}
+	sort.SliceStable(numbers, func(i, j int) bool {
+		return f.Counts[numbers[i]-1] > f.Counts[numbers[j]-1]
+	})
+
+	frequencyString := func(numbers []int) string {
+		s := make([]string, 0, len(numbers))
+		for _, n := range numbers {
+			s = append(s, fmt.Sprintf("%02d(%d회)", n, f.Counts[n-1]))
+		}
+		return strings.Join(s, ", ")
+	}
+	sb.WriteString(fmt.Sprintf("\n\n• 최다 출현 : %s", frequencyString(numbers[:5])))
+	sb.WriteString(fmt.Sprintf("\n• 최소 출현 : %s", frequencyString(numbers[len(numbers)-5:])))
+
+	return sb.String()
+}
+
+type lottoWatchDrawResultResultData struct {
+	// 최근 회차부터 정렬된 당첨번호 목록
+	Draws []*lottoDraw `json:"draws"`
+
+	// 최근 회차의 번호별 출현 빈도, 출현 빈도를 분석하지 않는 경우 nil이다.
+	Frequency *lottoNumberFrequency `json:"frequency,omitempty"`
+}
+
+func (d *lottoWatchDrawResultResultData) latestDraw() *lottoDraw {
+	if len(d.Draws) == 0 {
+		return nil
+	}
+	return d.Draws[0]
+}
+
 func init() {
 	supportedTasks[TidLotto] = &supportedTaskConfig{
 		commandConfigs: []*supportedTaskCommandConfig{{
@@ -36,6 +254,12 @@ func init() {
 			allowMultipleInstances: false,
 
 			newTaskResultDataFn: func() interface{} { return &lottoPredictionResultData{} },
+		}, {
+			taskCommandID: TcidLottoWatchDrawResult,
+
+			allowMultipleInstances: false,
+
+			newTaskResultDataFn: func() interface{} { return &lottoWatchDrawResultResultData{} },
 		}},
 
 		newTaskFn: func(instanceID TaskInstanceID, taskRunData *taskRunData, config *g.AppConfig) (taskHandler, error) {
@@ -43,20 +267,6 @@ func init() {
 				return nil, errors.New("등록되지 않은 작업입니다.😱")
 			}
 
-			var appPath string
-			for _, t := range config.Tasks {
-				if taskRunData.taskID == TaskID(t.ID) {
-					taskData := &lottoTaskData{}
-					if err := fillTaskDataFromMap(taskData, t.Data); err != nil {
-						return nil, errors.New(fmt.Sprintf("작업 데이터가 유효하지 않습니다.(error:%s)", err))
-					}
-
-					appPath = strings.Trim(taskData.AppPath, " ")
-
-					break
-				}
-			}
-
 			task := &lottoTask{
 				task: task{
 					id:         taskRunData.taskID,
@@ -70,13 +280,39 @@ func init() {
 					runBy: taskRunData.taskRunBy,
 				},
 
-				appPath: appPath,
+				config: config,
 			}
 
 			task.runFn = func(taskResultData interface{}, _ bool) (string, interface{}, error) {
 				switch task.CommandID() {
 				case TcidLottoPrediction:
-					return task.runPrediction()
+					for _, t := range task.config.Tasks {
+						if task.ID() == TaskID(t.ID) {
+							taskData := &lottoTaskData{}
+							if err := fillTaskDataFromMap(taskData, t.Data); err != nil {
+								return "", nil, errors.New(fmt.Sprintf("작업 데이터가 유효하지 않습니다.(error:%s)", err))
+							}
+
+							return task.runPrediction(taskData.AppPath)
+						}
+					}
+
+				case TcidLottoWatchDrawResult:
+					for _, t := range task.config.Tasks {
+						if task.ID() == TaskID(t.ID) {
+							for _, c := range t.Commands {
+								if task.CommandID() == TaskCommandID(c.ID) {
+									taskCommandData := &lottoWatchDrawResultTaskCommandData{}
+									if err := fillTaskCommandDataFromMap(taskCommandData, c.Data); err != nil {
+										return "", nil, errors.New(fmt.Sprintf("작업 커맨드 데이터가 유효하지 않습니다.(error:%s)", err))
+									}
+
+									return task.runWatchDrawResult(taskCommandData, taskResultData)
+								}
+							}
+							break
+						}
+					}
 				}
 
 				return "", nil, ErrNoImplementationForTaskCommand
@@ -90,11 +326,11 @@ func init() {
 type lottoTask struct {
 	task
 
-	appPath string
+	config *g.AppConfig
 }
 
-func (t *lottoTask) runPrediction() (message string, changedTaskResultData interface{}, err error) {
-	cmd := exec.Command("java", "-Dfile.encoding=UTF-8", fmt.Sprintf("-Duser.dir=%s", t.appPath), "-jar", fmt.Sprintf("%s%slottoprediction-1.0.0.jar", t.appPath, string(os.PathSeparator)))
+func (t *lottoTask) runPrediction(appPath string) (message string, changedTaskResultData interface{}, err error) {
+	cmd := exec.Command("java", "-Dfile.encoding=UTF-8", fmt.Sprintf("-Duser.dir=%s", appPath), "-jar", fmt.Sprintf("%s%slottoprediction-1.0.0.jar", appPath, string(os.PathSeparator)))
 
 	var cmdOutBuffer bytes.Buffer
 	cmd.Stdout = &cmdOutBuffer
@@ -117,7 +353,7 @@ func (t *lottoTask) runPrediction() (message string, changedTaskResultData inter
 					ticker.Stop()
 					err0 := cmd.Process.Signal(os.Kill)
 					if err0 != nil {
-						log.Errorf("사용자 요청으로 작업을 취소하는 중에 실행중인 외부 프로그램의 종료가 실패하였습니다.(error:%s)", err0)
+						t.Log().Errorf("사용자 요청으로 작업을 취소하는 중에 실행중인 외부 프로그램의 종료가 실패하였습니다.(error:%s)", err0)
 					}
 					return
 				}
@@ -176,3 +412,170 @@ func (t *lottoTask) runPrediction() (message string, changedTaskResultData inter
 
 	return message, nil, nil
 }
+
+func (t *lottoTask) runWatchDrawResult(taskCommandData *lottoWatchDrawResultTaskCommandData, taskResultData interface{}) (message string, changedTaskResultData interface{}, err error) {
+	originTaskResultData, ok := taskResultData.(*lottoWatchDrawResultResultData)
+	if ok == false {
+		log.Panic("TaskResultData의 타입 변환이 실패하였습니다.")
+	}
+
+	//
+	// 최근 회차의 당첨번호를 조회한다.
+	//
+	// 추첨일로부터 계산된 회차의 추첨 결과가 아직 등록되지 않은 경우, 이전 회차의 당첨번호를 조회한다.
+	drawNo := int(time.Since(lottoFirstDrawDate).Hours()/24/7) + 1
+	var latestDraw *lottoDraw
+	for i := 0; i < 2 && latestDraw == nil; i++ {
+		if latestDraw, err = t.fetchDraw(drawNo - i); err != nil {
+			return "", nil, err
+		}
+	}
+	if latestDraw == nil {
+		return "", nil, errors.New("로또 당첨번호 조회가 실패하였습니다")
+	}
+
+	//
+	// 새로운 회차의 당첨번호가 등록되었는지 확인한다.
+	//
+	originLatestDraw := originTaskResultData.latestDraw()
+	newDrawDetected := originLatestDraw == nil || latestDraw.DrawNo > originLatestDraw.DrawNo
+
+	if newDrawDetected == false && t.runBy.IsOnDemand() == false {
+		return "", nil, nil
+	}
+
+	m := latestDraw.String()
+	for _, numbers := range taskCommandData.WatchMyNumbers {
+		matchCount, rank := latestDraw.rank(numbers)
+		if rank > 0 {
+			m += fmt.Sprintf("\n      • %s : %d개 일치 (%d등) 🎉", lottoNumbersString(numbers), matchCount, rank)
+		} else {
+			m += fmt.Sprintf("\n      • %s : %d개 일치", lottoNumbersString(numbers), matchCount)
+		}
+	}
+
+	actualityTaskResultData := &lottoWatchDrawResultResultData{
+		Draws:     originTaskResultData.Draws,
+		Frequency: originTaskResultData.Frequency,
+	}
+
+	if newDrawDetected == true {
+		message = fmt.Sprintf("새로운 회차의 로또 당첨번호가 발표되었습니다.\n\n%s", m)
+
+		actualityTaskResultData.Draws = append([]*lottoDraw{latestDraw}, originTaskResultData.Draws...)
+		if len(actualityTaskResultData.Draws) > lottoMaxDrawHistoryCount {
+			actualityTaskResultData.Draws = actualityTaskResultData.Draws[:lottoMaxDrawHistoryCount]
+		}
+		changedTaskResultData = actualityTaskResultData
+	} else {
+		message = fmt.Sprintf("새로 발표된 로또 당첨번호가 없습니다.\n\n최근 회차의 당첨번호는 아래와 같습니다:\n\n%s", m)
+	}
+
+	//
+	// 최근 회차의 번호별 출현 빈도를 분석한다.
+	// 당첨번호 메시지가 길어지지 않도록 분석 결과는 별도의 메시지로 발송한다.
+	//
+	if taskCommandData.AnalyzeHistory == true {
+		frequency, changed, err := t.analyzeFrequency(originTaskResultData.Frequency, latestDraw, taskCommandData.HistoryCount)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if changed == true {
+			actualityTaskResultData.Frequency = frequency
+			changedTaskResultData = actualityTaskResultData
+		}
+
+		t.addSeparateMessage(frequency.String())
+	}
+
+	return message, changedTaskResultData, nil
+}
+
+// fetchDraw 해당 회차의 당첨번호를 조회한다. 아직 추첨 결과가 등록되지 않은 회차인 경우 nil을 반환한다.
+func (t *lottoTask) fetchDraw(drawNo int) (*lottoDraw, error) {
+	searchResultData := &lottoDrawResultSearchResultData{}
+	if err := t.scraper().FetchJSON(t.context(), "GET", fmt.Sprintf(lottoDrawResultUrl, drawNo), nil, nil, searchResultData); err != nil {
+		return nil, err
+	}
+	if searchResultData.ReturnValue != "success" {
+		return nil, nil
+	}
+
+	return &lottoDraw{
+		DrawNo:   searchResultData.DrawNo,
+		DrawDate: searchResultData.DrawDate,
+		Numbers:  []int{searchResultData.No1, searchResultData.No2, searchResultData.No3, searchResultData.No4, searchResultData.No5, searchResultData.No6},
+		BonusNo:  searchResultData.BonusNo,
+	}, nil
+}
+
+// analyzeFrequency latestDraw 회차까지 최근 historyCount개 회차의 번호별 출현 빈도를 구한다.
+// 이전에 구한 출현 빈도가 있는 경우, 새로 추가된 회차와 분석 범위에서 벗어난 회차만 조회하여 출현 빈도를 갱신한다.
+func (t *lottoTask) analyzeFrequency(origin *lottoNumberFrequency, latestDraw *lottoDraw, historyCount int) (frequency *lottoNumberFrequency, changed bool, err error) {
+	if origin != nil && origin.HistoryCount == historyCount && origin.ToDrawNo == latestDraw.DrawNo && len(origin.Counts) == 45 {
+		return origin, false, nil
+	}
+
+	fetchDraw := func(drawNo int) (*lottoDraw, error) {
+		if t.IsCanceled() == true {
+			return nil, errors.New("사용자 요청에 의해 작업이 취소되었습니다")
+		}
+		if drawNo == latestDraw.DrawNo {
+			return latestDraw, nil
+		}
+
+		d, err := t.fetchDraw(drawNo)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			return nil, fmt.Errorf("제%d회 로또 당첨번호 조회가 실패하였습니다", drawNo)
+		}
+		return d, nil
+	}
+
+	fromDrawNo := latestDraw.DrawNo - historyCount + 1
+	if fromDrawNo < 1 {
+		fromDrawNo = 1
+	}
+
+	frequency = &lottoNumberFrequency{
+		HistoryCount: historyCount,
+		FromDrawNo:   fromDrawNo,
+		ToDrawNo:     latestDraw.DrawNo,
+		Counts:       make([]int, 45),
+	}
+
+	// 분석 범위가 일부 겹치는 경우, 겹치지 않는 회차만 조회하여 이전의 출현 빈도를 갱신한다.
+	if origin != nil && origin.HistoryCount == historyCount && len(origin.Counts) == 45 && origin.ToDrawNo < latestDraw.DrawNo && origin.ToDrawNo >= fromDrawNo {
+		copy(frequency.Counts, origin.Counts)
+
+		for drawNo := origin.FromDrawNo; drawNo < fromDrawNo; drawNo++ {
+			d, err := fetchDraw(drawNo)
+			if err != nil {
+				return nil, false, err
+			}
+			frequency.add(d, -1)
+		}
+		for drawNo := origin.ToDrawNo + 1; drawNo <= latestDraw.DrawNo; drawNo++ {
+			d, err := fetchDraw(drawNo)
+			if err != nil {
+				return nil, false, err
+			}
+			frequency.add(d, 1)
+		}
+
+		return frequency, true, nil
+	}
+
+	for drawNo := fromDrawNo; drawNo <= latestDraw.DrawNo; drawNo++ {
+		d, err := fetchDraw(drawNo)
+		if err != nil {
+			return nil, false, err
+		}
+		frequency.add(d, 1)
+	}
+
+	return frequency, true, nil
+}
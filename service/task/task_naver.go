@@ -1,15 +1,22 @@
 package task
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/darkkaiser/notify-server/g"
+	"github.com/darkkaiser/notify-server/service/task/mark"
 	"github.com/darkkaiser/notify-server/utils"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/text/unicode/norm"
 	"html/template"
+	"net/http"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -19,14 +26,27 @@ const (
 
 	// TaskCommandID
 	TcidNaverWatchNewPerformances TaskCommandID = "WatchNewPerformances" // 네이버 신규 공연정보 확인
+	TcidNaverWatchNewBlogPosts    TaskCommandID = "WatchNewBlogPosts"    // 네이버 블로그 신규 글 확인
 )
 
 type naverWatchNewPerformancesSearchResultData struct {
 	Html string `json:"html"`
 }
 
+const (
+	// 공연정보 페이지를 읽어들이는 사이의 대기 시간(동시에 여러 페이지를 읽어들이는 경우에는 각 묶음 사이의 대기 시간)
+	naverPerformancePageFetchDelay = 100 * time.Millisecond
+
+	// 동시에 읽어들일 수 있는 공연정보 페이지의 최대 갯수
+	naverPerformanceMaxConcurrentPages = 10
+)
+
+// 공연정보 검색시 사용할 수 있는 장르
+var naverPerformanceGenres = []string{"all", "musical", "concert", "play", "classical", "dance"}
+
 type naverWatchNewPerformancesTaskCommandData struct {
 	Query   string `json:"query"`
+	Genre   string `json:"genre"`
 	Filters struct {
 		Title struct {
 			IncludedKeywords string `json:"included_keywords"`
@@ -36,27 +56,174 @@ type naverWatchNewPerformancesTaskCommandData struct {
 			IncludedKeywords string `json:"included_keywords"`
 			ExcludedKeywords string `json:"excluded_keywords"`
 		} `json:"place"`
+
+		// 공연 시작일이 이 시간 이후인 공연정보만 확인한다.(RFC3339 형식, 빈 값: 제한 없음)
+		StartAfter string `json:"start_after"`
+		// 공연 종료일이 이 시간 이전인 공연정보만 확인한다.(RFC3339 형식, 빈 값: 제한 없음)
+		EndBefore string `json:"end_before"`
 	} `json:"filters"`
+
+	// 삭제된 공연정보를 알릴 때, 등록된 지 MaxAgeDays 일이 지난 공연정보는 스케쥴러에 의해 실행된 경우 알리지 않는다.(0: 제한 없음)
+	MaxAgeDays int `json:"max_age_days"`
+
+	// 동시에 읽어들일 공연정보 페이지의 갯수(1: 한 페이지씩 순서대로 읽어들인다)
+	ConcurrentPages int `json:"concurrent_pages"`
+}
+
+func (d *naverWatchNewPerformancesTaskCommandData) ApplyDefaults() {
+	d.Genre = strings.ToLower(utils.Trim(d.Genre))
+	if d.Genre == "" {
+		d.Genre = "all"
+	}
+	if d.ConcurrentPages == 0 {
+		d.ConcurrentPages = 1
+	}
 }
 
-func (d *naverWatchNewPerformancesTaskCommandData) validate() error {
+func (d *naverWatchNewPerformancesTaskCommandData) Validate() error {
 	if d.Query == "" {
 		return errors.New("query가 입력되지 않았습니다")
 	}
+	if d.MaxAgeDays < 0 {
+		return errors.New("max_age_days에 음수가 입력되었습니다")
+	}
+	if d.ConcurrentPages < 1 || d.ConcurrentPages > naverPerformanceMaxConcurrentPages {
+		return fmt.Errorf("concurrent_pages에 1~%d 범위를 벗어난 값이 입력되었습니다", naverPerformanceMaxConcurrentPages)
+	}
+	if utils.Contains(naverPerformanceGenres, d.Genre) == false {
+		return fmt.Errorf("genre(%s)가 유효하지 않습니다.(%s 중 하나를 입력하세요)", d.Genre, strings.Join(naverPerformanceGenres, ", "))
+	}
+	if _, _, err := d.dateRange(); err != nil {
+		return err
+	}
 	return nil
 }
 
+// dateRange 공연 기간 필터의 시작/종료 시간을 반환한다. 입력되지 않은 필터는 Zero 값을 반환한다.
+func (d *naverWatchNewPerformancesTaskCommandData) dateRange() (startAfter, endBefore time.Time, err error) {
+	if d.Filters.StartAfter != "" {
+		if startAfter, err = time.Parse(time.RFC3339, d.Filters.StartAfter); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("filters.start_after(%s)가 RFC3339 형식이 아닙니다", d.Filters.StartAfter)
+		}
+	}
+	if d.Filters.EndBefore != "" {
+		if endBefore, err = time.Parse(time.RFC3339, d.Filters.EndBefore); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("filters.end_before(%s)가 RFC3339 형식이 아닙니다", d.Filters.EndBefore)
+		}
+	}
+	return startAfter, endBefore, nil
+}
+
+// matchDateRange 공연정보가 공연 기간 필터의 조건에 맞는지 확인한다.
+// 공연 기간을 확인할 수 없는 공연정보는 조건에 맞는 것으로 판단한다.
+func (d *naverWatchNewPerformancesTaskCommandData) matchDateRange(p *naverPerformance) bool {
+	startAfter, endBefore, err := d.dateRange()
+	if err != nil {
+		return true
+	}
+
+	if startAfter.IsZero() == false && p.StartDate.IsZero() == false && p.StartDate.Before(startAfter) == true {
+		return false
+	}
+	if endBefore.IsZero() == false && p.EndDate.IsZero() == false && p.EndDate.After(endBefore) == true {
+		return false
+	}
+
+	return true
+}
+
+// buildPerformanceSearchURL 공연정보 검색 URL을 생성한다.
+func buildPerformanceSearchURL(query, genre string, pageIndex int) string {
+	return fmt.Sprintf("https://m.search.naver.com/p/csearch/content/nqapirender.nhn?key=kbList&pkid=269&where=nexearch&u7=%d&u8=all&u3=&u1=%s&u2=%s&u4=ingplan&u6=N&u5=date", pageIndex, url.QueryEscape(query), url.QueryEscape(genre))
+}
+
+// performanceEvent 이전 작업결과데이터와 비교하여 확인된 공연정보의 변경 유형
+type performanceEvent int
+
+const (
+	performanceEventNew     performanceEvent = iota // 새로 등록된 공연정보
+	performanceEventRemoved                         // 삭제된 공연정보
+)
+
+func (e performanceEvent) Mark() string {
+	switch e {
+	case performanceEventNew:
+		return mark.New
+	case performanceEventRemoved:
+		return mark.Deleted
+	}
+	return ""
+}
+
 type naverPerformance struct {
-	Title     string `json:"title"`
-	Place     string `json:"place"`
-	Thumbnail string `json:"thumbnail"`
+	Title        string    `json:"title"`
+	Place        string    `json:"place"`
+	Thumbnail    string    `json:"thumbnail"`
+	StartDate    time.Time `json:"start_date"`
+	EndDate      time.Time `json:"end_date"`
+	RegisteredAt time.Time `json:"registered_at"`
+}
+
+// Key 공연정보를 구분하기 위한 키
+// 제목, 장소의 앞뒤 공백이나 유니코드 정규화 형식(NFC/NFD)이 다르더라도 같은 공연정보로 인식되도록 정규화한 후 연결한다.
+// 공연 기간이 변경되더라도 같은 공연이므로 공연 기간은 키에 포함하지 않는다.
+func (p *naverPerformance) Key() string {
+	return norm.NFC.String(strings.TrimSpace(p.Title)) + "|" + norm.NFC.String(strings.TrimSpace(p.Place))
+}
+
+// DateRange 공연 기간을 '2006.01.02 ~ 2006.01.02' 형식으로 반환한다. 공연 기간을 확인할 수 없는 경우 빈 문자열을 반환한다.
+func (p *naverPerformance) DateRange() string {
+	if p.StartDate.IsZero() == true {
+		return ""
+	}
+	if p.EndDate.IsZero() == true || p.EndDate.Equal(p.StartDate) == true {
+		return p.StartDate.Format("2006.01.02")
+	}
+	return fmt.Sprintf("%s ~ %s", p.StartDate.Format("2006.01.02"), p.EndDate.Format("2006.01.02"))
 }
 
 func (p *naverPerformance) String(messageTypeHTML bool, mark string) string {
+	title := utils.TruncateWithEllipsis(p.Title, itemTitleMaxLength)
+
+	var dateRange string
+	if s := p.DateRange(); s != "" {
+		dateRange = fmt.Sprintf("\n      • 기간 : %s", s)
+	}
+
 	if messageTypeHTML == true {
-		return fmt.Sprintf("☞ <a href=\"https://search.naver.com/search.naver?query=%s\"><b>%s</b></a>%s\n      • 장소 : %s", url.QueryEscape(p.Title), template.HTMLEscapeString(p.Title), mark, p.Place)
+		return fmt.Sprintf("☞ <a href=\"https://search.naver.com/search.naver?query=%s\"><b>%s</b></a>%s\n      • 장소 : %s%s", url.QueryEscape(p.Title), template.HTMLEscapeString(title), mark, p.Place, dateRange)
 	}
-	return strings.TrimSpace(fmt.Sprintf("☞ %s%s\n      • 장소 : %s", template.HTMLEscapeString(p.Title), mark, p.Place))
+	return strings.TrimSpace(fmt.Sprintf("☞ %s%s\n      • 장소 : %s%s", template.HTMLEscapeString(title), mark, p.Place, dateRange))
+}
+
+// naverPerformanceDateRegexp '2024.01.05.' 또는 '24.01.05.' 형식의 날짜
+var naverPerformanceDateRegexp = regexp.MustCompile(`(\d{2,4})\.(\d{1,2})\.(\d{1,2})`)
+
+// 공연 기간은 한국 시간 기준으로 표시된다.
+var naverPerformanceLocation = time.FixedZone("KST", 9*60*60)
+
+// parseNaverPerformanceDateRange '2024.01.05.~2024.02.28.'와 같은 공연 기간에서 시작일과 종료일을 추출한다.
+// 날짜가 하나만 있는 경우 시작일과 종료일이 같으며, 날짜가 없는 경우 Zero 값을 반환한다.
+func parseNaverPerformanceDateRange(s string) (startDate, endDate time.Time) {
+	var dates []time.Time
+	for _, m := range naverPerformanceDateRegexp.FindAllStringSubmatch(s, 2) {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		day, _ := strconv.Atoi(m[3])
+		if year < 100 {
+			year += 2000
+		}
+
+		dates = append(dates, time.Date(year, time.Month(month), day, 0, 0, 0, 0, naverPerformanceLocation))
+	}
+
+	switch len(dates) {
+	case 0:
+		return time.Time{}, time.Time{}
+	case 1:
+		return dates[0], dates[0]
+	}
+	return dates[0], dates[1]
 }
 
 type naverWatchNewPerformancesResultData struct {
@@ -71,6 +238,12 @@ func init() {
 			allowMultipleInstances: true,
 
 			newTaskResultDataFn: func() interface{} { return &naverWatchNewPerformancesResultData{} },
+		}, {
+			taskCommandID: TcidNaverWatchNewBlogPosts,
+
+			allowMultipleInstances: true,
+
+			newTaskResultDataFn: func() interface{} { return &naverWatchNewBlogPostsResultData{} },
 		}},
 
 		newTaskFn: func(instanceID TaskInstanceID, taskRunData *taskRunData, config *g.AppConfig) (taskHandler, error) {
@@ -105,11 +278,31 @@ func init() {
 									if err := fillTaskCommandDataFromMap(taskCommandData, c.Data); err != nil {
 										return "", nil, errors.New(fmt.Sprintf("작업 커맨드 데이터가 유효하지 않습니다.(error:%s)", err))
 									}
-									if err := taskCommandData.validate(); err != nil {
+
+									return task.runWatchNewPerformances(taskCommandData, taskResultData, messageTypeHTML)
+								}
+							}
+							break
+						}
+					}
+
+				case TcidNaverWatchNewBlogPosts:
+					for _, t := range task.config.Tasks {
+						if task.ID() == TaskID(t.ID) {
+							// 네이버 검색 API를 사용하므로 작업 데이터에 client_id, client_secret이 입력되어 있어야 한다.
+							taskData := &naverTaskData{}
+							if err := fillTaskDataFromMap(taskData, t.Data); err != nil {
+								return "", nil, errors.New(fmt.Sprintf("작업 데이터가 유효하지 않습니다.(error:%s)", err))
+							}
+
+							for _, c := range t.Commands {
+								if task.CommandID() == TaskCommandID(c.ID) {
+									taskCommandData := &naverWatchNewBlogPostsTaskCommandData{}
+									if err := fillTaskCommandDataFromMap(taskCommandData, c.Data); err != nil {
 										return "", nil, errors.New(fmt.Sprintf("작업 커맨드 데이터가 유효하지 않습니다.(error:%s)", err))
 									}
 
-									return task.runWatchNewPerformances(taskCommandData, taskResultData, messageTypeHTML)
+									return task.runWatchNewBlogPosts(taskData, taskCommandData, taskResultData, messageTypeHTML)
 								}
 							}
 							break
@@ -131,122 +324,279 @@ type naverTask struct {
 	config *g.AppConfig
 }
 
-// noinspection GoUnhandledErrorResult,GoErrorStringFormat
-func (t *naverTask) runWatchNewPerformances(taskCommandData *naverWatchNewPerformancesTaskCommandData, taskResultData interface{}, messageTypeHTML bool) (message string, changedTaskResultData interface{}, err error) {
-	originTaskResultData, ok := taskResultData.(*naverWatchNewPerformancesResultData)
-	if ok == false {
-		log.Panic("TaskResultData의 타입 변환이 실패하였습니다.")
+// fetchPerformances 공연정보가 없는 페이지가 나올 때까지 모든 페이지의 공연정보를 읽어들인다.
+// ConcurrentPages 갯수만큼의 페이지를 묶어서 동시에 읽어들이며, 작업결과데이터가 항상 같은 순서로 저장되도록 페이지 순서대로 합친다.
+// 작업이 취소된 경우 읽어들이는 중인 페이지의 요청도 모두 취소되며 context.Canceled를 반환한다.
+func (t *naverTask) fetchPerformances(taskCommandData *naverWatchNewPerformancesTaskCommandData, titleKeywordMatcher, placeKeywordMatcher *utils.KeywordMatcher) ([]*naverPerformance, error) {
+	type pageResult struct {
+		performances []*naverPerformance
+		count        int
+		err          error
 	}
 
-	actualityTaskResultData := &naverWatchNewPerformancesResultData{}
-	titleIncludedKeywords := utils.SplitExceptEmptyItems(taskCommandData.Filters.Title.IncludedKeywords, ",")
-	titleExcludedKeywords := utils.SplitExceptEmptyItems(taskCommandData.Filters.Title.ExcludedKeywords, ",")
-	placeIncludedKeywords := utils.SplitExceptEmptyItems(taskCommandData.Filters.Place.IncludedKeywords, ",")
-	placeExcludedKeywords := utils.SplitExceptEmptyItems(taskCommandData.Filters.Place.ExcludedKeywords, ",")
+	ctx := t.context()
+	concurrentPages := taskCommandData.ConcurrentPages
 
-	// 전라도 지역 공연정보를 읽어온다.
-	searchPerformancePageIndex := 1
-	for {
-		var searchResultData = &naverWatchNewPerformancesSearchResultData{}
-		err = unmarshalFromResponseJSONData("GET", fmt.Sprintf("https://m.search.naver.com/p/csearch/content/nqapirender.nhn?key=kbList&pkid=269&where=nexearch&u7=%d&u8=all&u3=&u1=%s&u2=all&u4=ingplan&u6=N&u5=date", searchPerformancePageIndex, url.QueryEscape(taskCommandData.Query)), nil, nil, searchResultData)
-		if err != nil {
-			return "", nil, err
+	var performances []*naverPerformance
+	for pageIndex := 1; ; pageIndex += concurrentPages {
+		if pageIndex > 1 {
+			select {
+			case <-time.After(naverPerformancePageFetchDelay):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
 		}
 
-		doc, err := goquery.NewDocumentFromReader(strings.NewReader(searchResultData.Html))
-		if err != nil {
-			return "", nil, fmt.Errorf("불러온 페이지의 데이터 파싱이 실패하였습니다.(error:%s)", err)
+		results := make([]pageResult, concurrentPages)
+
+		var wg sync.WaitGroup
+		for i := 0; i < concurrentPages; i++ {
+			wg.Add(1)
+			go func(r *pageResult, pageIndex int) {
+				defer wg.Done()
+
+				r.performances, r.count, r.err = t.fetchPerformancesPage(taskCommandData, pageIndex, titleKeywordMatcher, placeKeywordMatcher)
+			}(&results[i], pageIndex+i)
 		}
+		wg.Wait()
 
-		// 읽어온 페이지에서 공연정보를 추출한다.
-		ps := doc.Find("ul > li")
-		ps.EachWithBreak(func(i int, s *goquery.Selection) bool {
-			// 제목
-			pis := s.Find("div.item > div.title_box > strong.name")
-			if pis.Length() != 1 {
-				err = errors.New("공연 제목 추출이 실패하였습니다. CSS셀렉터를 확인하세요.")
-				return false
-			}
-			title := strings.TrimSpace(pis.Text())
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 
-			// 장소
-			pis = s.Find("div.item > div.title_box > span.sub_text")
-			if pis.Length() != 1 {
-				err = errors.New("공연 장소 추출이 실패하였습니다. CSS셀렉터를 확인하세요.")
-				return false
+		for _, r := range results {
+			if r.err != nil {
+				return nil, r.err
 			}
-			place := strings.TrimSpace(pis.Text())
 
-			// 썸네일 이미지
-			pis = s.Find("div.item > div.thumb > img")
-			if pis.Length() != 1 {
-				err = errors.New("공연 썸네일 이미지 추출이 실패하였습니다. CSS셀렉터를 확인하세요.")
-				return false
-			}
-			thumbnailSrc, exists := pis.Attr("src")
-			if exists == false {
-				err = errors.New("공연 썸네일 이미지 추출이 실패하였습니다. CSS셀렉터를 확인하세요.")
-				return false
+			// 불러온 데이터가 없는 경우, 모든 공연정보를 불러온 것으로 인식한다.
+			if r.count == 0 {
+				return performances, nil
 			}
-			thumbnail := fmt.Sprintf(`<img src="%s">`, thumbnailSrc)
 
-			if filter(title, titleIncludedKeywords, titleExcludedKeywords) == false || filter(place, placeIncludedKeywords, placeExcludedKeywords) == false {
-				return true
-			}
+			performances = append(performances, r.performances...)
+		}
+	}
+}
 
-			actualityTaskResultData.Performances = append(actualityTaskResultData.Performances, &naverPerformance{
-				Title:     title,
-				Place:     place,
-				Thumbnail: thumbnail,
-			})
+// fetchPerformancesPage 한 페이지의 공연정보를 읽어들여 조회 조건에 맞는 공연정보와, 필터링하기 전 페이지에 포함된 공연정보의 갯수를 반환한다.
+func (t *naverTask) fetchPerformancesPage(taskCommandData *naverWatchNewPerformancesTaskCommandData, pageIndex int, titleKeywordMatcher, placeKeywordMatcher *utils.KeywordMatcher) (performances []*naverPerformance, count int, err error) {
+	var searchResultData = &naverWatchNewPerformancesSearchResultData{}
+	searchURL := buildPerformanceSearchURL(taskCommandData.Query, taskCommandData.Genre, pageIndex)
+	searchScraper := t.newScraper(fetcherFunc(func(req *http.Request) (*http.Response, error) {
+		return t.doWithRetry(req, isEmptyPerformanceSearchResult)
+	}))
+	err = searchScraper.FetchJSON(t.context(), "GET", searchURL, nil, nil, searchResultData)
+	if err != nil {
+		return nil, 0, err
+	}
 
-			return true
-		})
-		if err != nil {
-			return "", nil, err
+	doc, err := t.scraper().ParseHTML(t.context(), strings.NewReader(searchResultData.Html), searchURL, "")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 읽어온 페이지에서 공연정보를 추출한다.
+	ps, err := parsePerformancesFromHTML(doc)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, p := range ps {
+		if titleKeywordMatcher.Match(p.Title) == false || placeKeywordMatcher.Match(p.Place) == false {
+			continue
+		}
+		if taskCommandData.matchDateRange(p) == false {
+			continue
 		}
 
-		searchPerformancePageIndex += 1
+		performances = append(performances, p)
+	}
 
-		// 불러온 데이터가 없는 경우, 모든 공연정보를 불러온 것으로 인식한다.
-		if ps.Length() == 0 {
-			break
+	return performances, len(ps), nil
+}
+
+// parsePerformancesFromHTML 검색 결과 페이지에서 조회 조건으로 필터링하지 않은 모든 공연정보를 추출한다.
+// 외부 서버에서 읽어온 HTML은 어떤 형태로든 깨져 있을 수 있으므로, 공연정보를 추출할 수 없는 경우 panic이 발생하지 않고 에러를 반환하여야 한다.
+// noinspection GoErrorStringFormat
+func parsePerformancesFromHTML(doc *goquery.Document) (performances []*naverPerformance, err error) {
+	ps := doc.Find("ul > li")
+	ps.EachWithBreak(func(i int, s *goquery.Selection) bool {
+		// 제목
+		pis := s.Find("div.item > div.title_box > strong.name")
+		if pis.Length() != 1 {
+			err = errors.New("공연 제목 추출이 실패하였습니다. CSS셀렉터를 확인하세요.")
+			return false
 		}
+		title := strings.TrimSpace(pis.Text())
 
-		time.Sleep(100 * time.Millisecond)
+		// 장소
+		pis = s.Find("div.item > div.title_box > span.sub_text")
+		if pis.Length() != 1 {
+			err = errors.New("공연 장소 추출이 실패하였습니다. CSS셀렉터를 확인하세요.")
+			return false
+		}
+		place := strings.TrimSpace(pis.Text())
+
+		// 썸네일 이미지
+		pis = s.Find("div.item > div.thumb > img")
+		if pis.Length() != 1 {
+			err = errors.New("공연 썸네일 이미지 추출이 실패하였습니다. CSS셀렉터를 확인하세요.")
+			return false
+		}
+		thumbnailSrc, exists := pis.Attr("src")
+		if exists == false {
+			err = errors.New("공연 썸네일 이미지 추출이 실패하였습니다. CSS셀렉터를 확인하세요.")
+			return false
+		}
+		thumbnail := fmt.Sprintf(`<img src="%s">`, thumbnailSrc)
+
+		// 공연 기간(공연 기간이 표시되지 않는 공연정보도 있으므로 추출이 실패하더라도 오류로 처리하지 않는다)
+		startDate, endDate := parseNaverPerformanceDateRange(s.Find("div.item > div.other_info").Text())
+
+		performances = append(performances, &naverPerformance{
+			Title:        title,
+			Place:        place,
+			Thumbnail:    thumbnail,
+			StartDate:    startDate,
+			EndDate:      endDate,
+			RegisteredAt: time.Now(),
+		})
+
+		return true
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// 신규 공연정보를 확인한다.
-	m := ""
-	lineSpacing := "\n\n"
-	err = eachSourceElementIsInTargetElementOrNot(actualityTaskResultData.Performances, originTaskResultData.Performances, func(selem, telem interface{}) (bool, error) {
-		actualityPerformance, ok1 := selem.(*naverPerformance)
-		originPerformance, ok2 := telem.(*naverPerformance)
+	return performances, nil
+}
+
+// isEmptyPerformanceSearchResult 공연정보 검색 결과의 html 항목이 비어있는지 확인한다.
+// 마지막 페이지의 다음 페이지도 html 항목이 비어있는 결과로 응답하므로, 재시도한 후에도 비어있는 페이지는 마지막 페이지로 인식된다.
+// 요청이 실패한 경우는 기본 재시도 조건에 따라 재시도하며, JSON 형식이 아닌 응답은 재시도하지 않고 그대로 FetchJSON()에서 오류로 처리되도록 한다.
+func isEmptyPerformanceSearchResult(resp *http.Response, err error) bool {
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return false
+	}
+
+	var searchResultData naverWatchNewPerformancesSearchResultData
+	if err := json.NewDecoder(resp.Body).Decode(&searchResultData); err != nil {
+		return false
+	}
+
+	return strings.TrimSpace(searchResultData.Html) == ""
+}
+
+// noinspection GoUnhandledErrorResult,GoErrorStringFormat
+func (t *naverTask) runWatchNewPerformances(taskCommandData *naverWatchNewPerformancesTaskCommandData, taskResultData interface{}, messageTypeHTML bool) (message string, changedTaskResultData interface{}, err error) {
+	originTaskResultData, ok := taskResultData.(*naverWatchNewPerformancesResultData)
+	if ok == false {
+		log.Panic("TaskResultData의 타입 변환이 실패하였습니다.")
+	}
+
+	actualityTaskResultData := &naverWatchNewPerformancesResultData{}
+
+	titleKeywordMatcher, err := utils.NewKeywordMatcherWithValidation(utils.SplitExceptEmptyItems(taskCommandData.Filters.Title.IncludedKeywords, ","), utils.SplitExceptEmptyItems(taskCommandData.Filters.Title.ExcludedKeywords, ","))
+	if err != nil {
+		return "", nil, err
+	}
+	placeKeywordMatcher, err := utils.NewKeywordMatcherWithValidation(utils.SplitExceptEmptyItems(taskCommandData.Filters.Place.IncludedKeywords, ","), utils.SplitExceptEmptyItems(taskCommandData.Filters.Place.ExcludedKeywords, ","))
+	if err != nil {
+		return "", nil, err
+	}
+
+	// 전라도 지역 공연정보를 읽어온다.
+	actualityTaskResultData.Performances, err = t.fetchPerformances(taskCommandData, titleKeywordMatcher, placeKeywordMatcher)
+	if err != nil {
+		return "", nil, err
+	}
+
+	equalFn := func(selem, telem interface{}) (bool, error) {
+		performance1, ok1 := selem.(*naverPerformance)
+		performance2, ok2 := telem.(*naverPerformance)
 		if ok1 == false || ok2 == false {
 			return false, errors.New("selem/telem의 타입 변환이 실패하였습니다.")
 		} else {
-			if actualityPerformance.Title == originPerformance.Title && actualityPerformance.Place == originPerformance.Place {
+			if performance1.Key() == performance2.Key() {
 				return true, nil
 			}
 		}
 		return false, nil
-	}, nil, func(selem interface{}) {
+	}
+
+	// 신규 공연정보를 확인한다.
+	m := ""
+	newPerformanceCount := 0
+	registeredAtMissing := false
+	lineSpacing := "\n\n"
+	err = eachSourceElementIsInTargetElementOrNot(actualityTaskResultData.Performances, originTaskResultData.Performances, equalFn, func(selem, telem interface{}) {
+		// 이전에 확인된 공연정보는 최초로 등록된 시간을 유지한다.
 		actualityPerformance := selem.(*naverPerformance)
+		originPerformance := telem.(*naverPerformance)
+
+		// 등록된 시간이 저장되지 않은 이전 버전의 작업결과데이터인 경우, 현재 시간을 등록된 시간으로 저장한다.
+		if originPerformance.RegisteredAt.IsZero() == true {
+			registeredAtMissing = true
+			return
+		}
+
+		actualityPerformance.RegisteredAt = originPerformance.RegisteredAt
+	}, func(selem interface{}) {
+		actualityPerformance := selem.(*naverPerformance)
+
+		newPerformanceCount++
 
 		if m != "" {
 			m += lineSpacing
 		}
-		m += actualityPerformance.String(messageTypeHTML, " 🆕")
+		m += actualityPerformance.String(messageTypeHTML, performanceEventNew.Mark())
 	})
 	if err != nil {
 		return "", nil, err
 	}
 
-	if m != "" {
-		message = "새로운 공연정보가 등록되었습니다.\n\n" + m
+	// 삭제된 공연정보를 확인한다.
+	removedPerformanceExists := false
+	removedMessage := ""
+	err = eachSourceElementIsInTargetElementOrNot(originTaskResultData.Performances, actualityTaskResultData.Performances, equalFn, nil, func(selem interface{}) {
+		originPerformance := selem.(*naverPerformance)
+
+		removedPerformanceExists = true
+
+		// 스케쥴러에 의해 실행된 경우, 오래전에 등록된 공연정보의 삭제는 알리지 않는다.
+		// 등록된 시간이 저장되지 않은 공연정보는 방금 등록된 것으로 간주한다.
+		if t.runBy == TaskRunByScheduler && taskCommandData.MaxAgeDays > 0 && originPerformance.RegisteredAt.IsZero() == false {
+			if time.Since(originPerformance.RegisteredAt) > time.Duration(taskCommandData.MaxAgeDays)*24*time.Hour {
+				return
+			}
+		}
+
+		if removedMessage != "" {
+			removedMessage += lineSpacing
+		}
+		removedMessage += originPerformance.String(messageTypeHTML, performanceEventRemoved.Mark())
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	if m != "" || removedMessage != "" {
+		if m != "" {
+			message = fmt.Sprintf("새로운 공연정보가 등록되었습니다.%s\n\n%s", mark.WithCount(newPerformanceCount), m)
+		}
+		if removedMessage != "" {
+			if message != "" {
+				message += "\n\n"
+			}
+			message += "공연 정보가 삭제되었습니다.\n\n" + removedMessage
+		}
+		changedTaskResultData = actualityTaskResultData
+	} else if removedPerformanceExists == true || registeredAtMissing == true {
+		// 알리지 않은 삭제된 공연정보와 새로 저장된 등록 시간도 작업결과데이터에는 반영한다.
 		changedTaskResultData = actualityTaskResultData
 	} else {
-		if t.runBy == TaskRunByUser {
+		if t.runBy.IsOnDemand() == true {
 			if len(actualityTaskResultData.Performances) == 0 {
 				message = "등록된 공연정보가 존재하지 않습니다."
 			} else {
@@ -0,0 +1,203 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"github.com/darkkaiser/notify-server/service/task/mark"
+	"github.com/darkkaiser/notify-server/utils"
+	log "github.com/sirupsen/logrus"
+	"html"
+	"html/template"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// 네이버 블로그 검색 URL
+	naverBlogSearchUrl = "https://openapi.naver.com/v1/search/blog.json"
+)
+
+type naverBlogSearchResultData struct {
+	Total   int `json:"total"`
+	Start   int `json:"start"`
+	Display int `json:"display"`
+	Items   []struct {
+		Title       string `json:"title"`
+		Link        string `json:"link"`
+		Description string `json:"description"`
+		BloggerName string `json:"bloggername"`
+		BloggerLink string `json:"bloggerlink"`
+		PostDate    string `json:"postdate"`
+	} `json:"items"`
+}
+
+// naverTaskData 네이버 검색 API를 사용하는 작업 커맨드에서 필요한 작업 데이터
+type naverTaskData struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+func (d *naverTaskData) ApplyDefaults() {
+}
+
+func (d *naverTaskData) Validate() error {
+	if d.ClientID == "" {
+		return errors.New("client_id가 입력되지 않았습니다")
+	}
+	if d.ClientSecret == "" {
+		return errors.New("client_secret이 입력되지 않았습니다")
+	}
+	return nil
+}
+
+type naverWatchNewBlogPostsTaskCommandData struct {
+	Query   string `json:"query"`
+	Filters struct {
+		IncludedKeywords string `json:"included_keywords"`
+		ExcludedKeywords string `json:"excluded_keywords"`
+		MaxResults       int    `json:"max_results"`
+	} `json:"filters"`
+}
+
+func (d *naverWatchNewBlogPostsTaskCommandData) ApplyDefaults() {
+	if d.Filters.MaxResults == 0 {
+		d.Filters.MaxResults = 30
+	}
+}
+
+func (d *naverWatchNewBlogPostsTaskCommandData) Validate() error {
+	if d.Query == "" {
+		return errors.New("query가 입력되지 않았습니다")
+	}
+	if d.Filters.MaxResults < 1 || d.Filters.MaxResults > 100 {
+		return errors.New("max_results에 1~100 범위를 벗어난 값이 입력되었습니다")
+	}
+	return nil
+}
+
+type naverBlogPost struct {
+	Title       string `json:"title"`
+	Link        string `json:"link"`
+	BloggerName string `json:"blogger_name"`
+	PostDate    string `json:"post_date"`
+}
+
+func (p *naverBlogPost) String(messageTypeHTML bool, mark string) string {
+	if messageTypeHTML == true {
+		return fmt.Sprintf("☞ <a href=\"%s\"><b>%s</b></a>%s\n      • 작성자 : %s\n      • 작성일 : %s", p.Link, template.HTMLEscapeString(p.Title), mark, template.HTMLEscapeString(p.BloggerName), p.PostDate)
+	}
+	return strings.TrimSpace(fmt.Sprintf("☞ %s%s\n      • 작성자 : %s\n      • 작성일 : %s\n%s", p.Title, mark, p.BloggerName, p.PostDate, p.Link))
+}
+
+type naverWatchNewBlogPostsResultData struct {
+	Posts []*naverBlogPost `json:"posts"`
+}
+
+// naverSearchTextReplacer 네이버 검색 API의 결과에 포함된 검색어 강조 태그를 제거한다.
+var naverSearchTextReplacer = strings.NewReplacer("<b>", "", "</b>", "")
+
+func naverSearchText(s string) string {
+	return utils.Trim(html.UnescapeString(naverSearchTextReplacer.Replace(s)))
+}
+
+func (t *naverTask) runWatchNewBlogPosts(taskData *naverTaskData, taskCommandData *naverWatchNewBlogPostsTaskCommandData, taskResultData interface{}, messageTypeHTML bool) (message string, changedTaskResultData interface{}, err error) {
+	originTaskResultData, ok := taskResultData.(*naverWatchNewBlogPostsResultData)
+	if ok == false {
+		log.Panic("TaskResultData의 타입 변환이 실패하였습니다.")
+	}
+
+	//
+	// 최근에 작성된 블로그 글을 검색한다.
+	//
+	header := map[string]string{
+		"X-Naver-Client-Id":     taskData.ClientID,
+		"X-Naver-Client-Secret": taskData.ClientSecret,
+	}
+	searchResultData := &naverBlogSearchResultData{}
+	err = t.scraper().FetchJSON(t.context(), "GET", fmt.Sprintf("%s?query=%s&display=%d&start=1&sort=date", naverBlogSearchUrl, url.QueryEscape(taskCommandData.Query), taskCommandData.Filters.MaxResults), nil, header, searchResultData)
+	if err != nil {
+		return "", nil, err
+	}
+
+	//
+	// 검색된 블로그 글을 설정된 조건에 맞게 필터링한다.
+	//
+	actualityTaskResultData := &naverWatchNewBlogPostsResultData{}
+	keywordMatcher, err := utils.NewKeywordMatcherWithValidation(utils.SplitExceptEmptyItems(taskCommandData.Filters.IncludedKeywords, ","), utils.SplitExceptEmptyItems(taskCommandData.Filters.ExcludedKeywords, ","))
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, item := range searchResultData.Items {
+		title := naverSearchText(item.Title)
+		if keywordMatcher.Match(title) == false {
+			continue
+		}
+
+		// 작성일은 'yyyyMMdd' 형식으로 전달된다.
+		postDate := item.PostDate
+		if d, err := time.Parse("20060102", item.PostDate); err == nil {
+			postDate = d.Format("2006-01-02")
+		}
+
+		actualityTaskResultData.Posts = append(actualityTaskResultData.Posts, &naverBlogPost{
+			Title:       title,
+			Link:        item.Link,
+			BloggerName: naverSearchText(item.BloggerName),
+			PostDate:    postDate,
+		})
+	}
+
+	//
+	// 새로 작성된 블로그 글을 확인한다.
+	//
+	m := ""
+	lineSpacing := "\n\n"
+	err = eachSourceElementIsInTargetElementOrNot(actualityTaskResultData.Posts, originTaskResultData.Posts, func(selem, telem interface{}) (bool, error) {
+		actualityPost, ok1 := selem.(*naverBlogPost)
+		originPost, ok2 := telem.(*naverBlogPost)
+		if ok1 == false || ok2 == false {
+			return false, errors.New("selem/telem의 타입 변환이 실패하였습니다")
+		} else {
+			if actualityPost.Link == originPost.Link {
+				return true, nil
+			}
+		}
+		return false, nil
+	}, nil, func(selem interface{}) {
+		actualityPost := selem.(*naverBlogPost)
+
+		if m != "" {
+			m += lineSpacing
+		}
+		m += actualityPost.String(messageTypeHTML, mark.New)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	filtersDescription := fmt.Sprintf("조회 조건은 아래와 같습니다:\n• 검색 키워드 : %s\n• 제목 포함 키워드 : %s\n• 제목 제외 키워드 : %s", taskCommandData.Query, taskCommandData.Filters.IncludedKeywords, taskCommandData.Filters.ExcludedKeywords)
+
+	if m != "" {
+		message = fmt.Sprintf("새로운 블로그 글이 작성되었습니다.\n\n%s\n\n%s", filtersDescription, m)
+		changedTaskResultData = actualityTaskResultData
+	} else {
+		if t.runBy.IsOnDemand() == true {
+			if len(actualityTaskResultData.Posts) == 0 {
+				message = fmt.Sprintf("조회 조건에 해당되는 블로그 글이 존재하지 않습니다.\n\n%s", filtersDescription)
+			} else {
+				for _, actualityPost := range actualityTaskResultData.Posts {
+					if m != "" {
+						m += lineSpacing
+					}
+					m += actualityPost.String(messageTypeHTML, "")
+				}
+
+				message = fmt.Sprintf("새로 작성된 블로그 글이 없습니다.\n\n%s\n\n최근에 작성된 블로그 글은 아래와 같습니다:\n\n%s", filtersDescription, m)
+			}
+		}
+	}
+
+	return message, changedTaskResultData, nil
+}
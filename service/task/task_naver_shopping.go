@@ -1,28 +1,41 @@
 package task
 
 import (
+	"bytes"
+	"encoding/csv"
 	"errors"
 	"fmt"
 	"github.com/darkkaiser/notify-server/g"
+	"github.com/darkkaiser/notify-server/service/task/mark"
 	"github.com/darkkaiser/notify-server/utils"
 	log "github.com/sirupsen/logrus"
+	"html/template"
 	"math"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
-	naverShoppingWatchPriceTaskCommandIDPrefix string = "WatchPrice_"
+	naverShoppingWatchPriceTaskCommandIDPrefix       string = "WatchPrice_"
+	naverShoppingWatchPriceMergedTaskCommandIDPrefix string = "WatchPriceMerged_"
 
 	// TaskID
 	TidNaverShopping TaskID = "NS" // 네이버쇼핑(https://shopping.naver.com/)
 
 	// TaskCommandID
-	TcidNaverShoppingWatchPriceAny = TaskCommandID(naverShoppingWatchPriceTaskCommandIDPrefix + taskCommandIDAnyString) // 네이버쇼핑 가격 확인
+	TcidNaverShoppingWatchPriceAny       = TaskCommandID(naverShoppingWatchPriceTaskCommandIDPrefix + taskCommandIDAnyString)       // 네이버쇼핑 가격 확인
+	TcidNaverShoppingWatchPriceMergedAny = TaskCommandID(naverShoppingWatchPriceMergedTaskCommandIDPrefix + taskCommandIDAnyString) // 네이버쇼핑 여러 검색 조건의 가격 확인
 
 	// 네이버쇼핑 검색 URL
 	naverShoppingSearchUrl = "https://openapi.naver.com/v1/search/shop.json"
+
+	// 검색된 상품의 가격이 이 값보다 낮은 경우, 데이터 오류로 판단한다.
+	naverShoppingSuspiciousLowPrice = 100
+
+	// 가격이 유효하지 않은 상품의 비율이 이 값(%)을 초과하는 경우, 검색 결과 전체를 신뢰할 수 없는 것으로 판단한다.
+	naverShoppingMaxInvalidPricePercent = 50
 )
 
 type naverShoppingSearchResultData struct {
@@ -44,7 +57,10 @@ type naverShoppingTaskData struct {
 	ClientSecret string `json:"client_secret"`
 }
 
-func (d *naverShoppingTaskData) validate() error {
+func (d *naverShoppingTaskData) ApplyDefaults() {
+}
+
+func (d *naverShoppingTaskData) Validate() error {
 	if d.ClientID == "" {
 		return errors.New("client_id가 입력되지 않았습니다")
 	}
@@ -60,36 +76,239 @@ type naverShoppingWatchPriceTaskCommandData struct {
 		IncludedKeywords string `json:"included_keywords"`
 		ExcludedKeywords string `json:"excluded_keywords"`
 		PriceLessThan    int    `json:"price_less_than"`
+		PriceGreaterThan int    `json:"price_greater_than"`
+		PriceDropPercent int    `json:"price_drop_percent"`
+		// 판매처(mallName)가 목록에 포함된 상품만 조회한다. 비어있는 경우 모든 판매처의 상품을 조회한다.
+		MallWhitelist []string `json:"mall_whitelist"`
+		// 판매처(mallName)가 목록에 포함된 상품은 조회하지 않는다. mall_whitelist에 포함된 판매처는 제외되지 않는다.
+		MallBlacklist []string `json:"mall_blacklist"`
 	} `json:"filters"`
+	MessageTemplate string `json:"message_template"`
+}
+
+func (d *naverShoppingWatchPriceTaskCommandData) ApplyDefaults() {
 }
 
-func (d *naverShoppingWatchPriceTaskCommandData) validate() error {
+func (d *naverShoppingWatchPriceTaskCommandData) Validate() error {
 	if d.Query == "" {
 		return errors.New("query가 입력되지 않았습니다")
 	}
 	if d.Filters.PriceLessThan <= 0 {
 		return errors.New("price_less_than에 0 이하의 값이 입력되었습니다")
 	}
+	if d.Filters.PriceGreaterThan < 0 {
+		return errors.New("price_greater_than에 음수가 입력되었습니다")
+	}
+	if d.Filters.PriceGreaterThan >= d.Filters.PriceLessThan {
+		return errors.New("price_greater_than은 price_less_than보다 작아야 합니다")
+	}
+	if d.Filters.PriceDropPercent < 0 || d.Filters.PriceDropPercent >= 100 {
+		return errors.New("price_drop_percent에 0~99 범위를 벗어난 값이 입력되었습니다")
+	}
+	for _, mall := range d.Filters.MallWhitelist {
+		if strings.TrimSpace(mall) == "" {
+			return errors.New("mall_whitelist에 빈 문자열이 입력되었습니다")
+		}
+	}
+	for _, mall := range d.Filters.MallBlacklist {
+		if strings.TrimSpace(mall) == "" {
+			return errors.New("mall_blacklist에 빈 문자열이 입력되었습니다")
+		}
+	}
+	if d.MessageTemplate != "" {
+		if _, err := utils.NewNotificationTemplate(string(TidNaverShopping), d.MessageTemplate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// naverShoppingWatchPriceMergedTaskCommandData 여러 검색 조건으로 검색된 상품을 하나의 작업결과데이터로 합쳐서 확인하는 작업 커맨드 데이터
+type naverShoppingWatchPriceMergedTaskCommandData struct {
+	// 순서대로 실행할 검색 조건 목록, 각 검색 조건은 'WatchPrice_' 작업 커맨드 데이터와 같은 형식이다.
+	Queries          []*naverShoppingWatchPriceTaskCommandData `json:"queries"`
+	PriceDropPercent int                                       `json:"price_drop_percent"`
+	MessageTemplate  string                                    `json:"message_template"`
+}
+
+func (d *naverShoppingWatchPriceMergedTaskCommandData) ApplyDefaults() {
+	for _, query := range d.Queries {
+		if query != nil {
+			query.ApplyDefaults()
+		}
+	}
+}
+
+func (d *naverShoppingWatchPriceMergedTaskCommandData) Validate() error {
+	if len(d.Queries) == 0 {
+		return errors.New("queries가 입력되지 않았습니다")
+	}
+	for i, query := range d.Queries {
+		if query == nil {
+			return fmt.Errorf("queries[%d]가 입력되지 않았습니다", i)
+		}
+		if err := query.Validate(); err != nil {
+			return fmt.Errorf("queries[%d]의 %s", i, err)
+		}
+		// 가격 하락률은 합쳐진 상품 목록에 대해 적용되므로 검색 조건별로 설정할 수 없다.
+		if query.Filters.PriceDropPercent != 0 {
+			return fmt.Errorf("queries[%d]에 price_drop_percent가 입력되었습니다. price_drop_percent는 queries와 같은 수준에 입력하세요", i)
+		}
+	}
+	if d.PriceDropPercent < 0 || d.PriceDropPercent >= 100 {
+		return errors.New("price_drop_percent에 0~99 범위를 벗어난 값이 입력되었습니다")
+	}
+	if d.MessageTemplate != "" {
+		if _, err := utils.NewNotificationTemplate(string(TidNaverShopping), d.MessageTemplate); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// isPriceEligible 상품의 가격이 설정된 가격 범위에 해당되는지 확인한다.
+func (d *naverShoppingWatchPriceTaskCommandData) isPriceEligible(price int) bool {
+	return price > 0 && price > d.Filters.PriceGreaterThan && price < d.Filters.PriceLessThan
+}
+
+// isMallEligible 상품의 판매처가 설정된 판매처 조건에 해당되는지 확인한다.
+// 판매처 이름은 대소문자를 구분하지 않고 비교하며, mall_whitelist에 포함된 판매처는 mall_blacklist에 포함되어 있더라도 조회된다.
+func (d *naverShoppingWatchPriceTaskCommandData) isMallEligible(mallName string) bool {
+	containsMall := func(malls []string) bool {
+		for _, mall := range malls {
+			if strings.EqualFold(strings.TrimSpace(mall), strings.TrimSpace(mallName)) == true {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(d.Filters.MallWhitelist) > 0 {
+		return containsMall(d.Filters.MallWhitelist)
+	}
+
+	return containsMall(d.Filters.MallBlacklist) == false
+}
+
+// filtersDescription 알림메시지에 포함되는 조회 조건
+func (d *naverShoppingWatchPriceTaskCommandData) filtersDescription() string {
+	filtersDescription := fmt.Sprintf("• 검색 키워드 : %s\n• 상풍명 포함 키워드 : %s\n• 상품명 제외 키워드 : %s\n• %s 미만의 상품", d.Query, d.Filters.IncludedKeywords, d.Filters.ExcludedKeywords, utils.FormatKRW(d.Filters.PriceLessThan))
+	if d.Filters.PriceGreaterThan > 0 {
+		filtersDescription += fmt.Sprintf("\n• %s 초과의 상품", utils.FormatKRW(d.Filters.PriceGreaterThan))
+	}
+	if d.Filters.PriceDropPercent > 0 {
+		filtersDescription += fmt.Sprintf("\n• 이전 가격 대비 %d%% 이상 하락한 상품", d.Filters.PriceDropPercent)
+	}
+	if len(d.Filters.MallWhitelist) > 0 {
+		filtersDescription += fmt.Sprintf("\n• 판매처 : %s", strings.Join(d.Filters.MallWhitelist, ", "))
+	}
+	if len(d.Filters.MallBlacklist) > 0 {
+		filtersDescription += fmt.Sprintf("\n• 제외 판매처 : %s", strings.Join(d.Filters.MallBlacklist, ", "))
+	}
+
+	return filtersDescription
+}
+
 type naverShoppingProduct struct {
 	Title       string `json:"title"`
 	Link        string `json:"link"`
 	LowPrice    int    `json:"lprice"`
+	MallName    string `json:"mallName"`
 	ProductID   string `json:"productId"`
 	ProductType string `json:"productType"`
 }
 
+// naverShoppingProductTitle 네이버쇼핑 검색 API의 상품명에 포함된 검색어 강조 태그와 HTML 엔티티를 제거한다.
+// 태그가 HTML 엔티티로 인코딩되어 전달되는 경우('&lt;b&gt;')도 있으므로 HTML 엔티티를 먼저 변환한 후에 태그를 제거한다.
+func naverShoppingProductTitle(title string) string {
+	return utils.Trim(utils.StripHTML(utils.HTMLEntityDecode(title)))
+}
+
 func (p *naverShoppingProduct) String(messageTypeHTML bool, mark string) string {
+	title := utils.TruncateWithEllipsis(p.Title, itemTitleMaxLength)
 	if messageTypeHTML == true {
-		return fmt.Sprintf("☞ <a href=\"%s\"><b>%s</b></a> %s원%s", p.Link, p.Title, utils.FormatCommas(p.LowPrice), mark)
+		return fmt.Sprintf("☞ <a href=\"%s\"><b>%s</b></a> %s%s", p.Link, template.HTMLEscapeString(title), utils.FormatKRW(p.LowPrice), mark)
 	}
-	return strings.TrimSpace(fmt.Sprintf("☞ %s %s원%s\n%s", p.Title, utils.FormatCommas(p.LowPrice), mark, p.Link))
+	return strings.TrimSpace(fmt.Sprintf("☞ %s %s%s\n%s", title, utils.FormatKRW(p.LowPrice), mark, p.Link))
+}
+
+// naverShoppingProductTemplateData 메시지 템플릿(message_template)에서 사용할 수 있는 상품 정보
+// 상품 정보 외에 {{.Mark}}(신규/가격변경 표시), {{.HTML}}(HTML 메시지 여부)를 사용할 수 있다.
+type naverShoppingProductTemplateData struct {
+	*naverShoppingProduct
+
+	Mark string
+	HTML bool
 }
 
 type naverShoppingWatchPriceResultData struct {
-	Products []*naverShoppingProduct `json:"products"`
+	Products  []*naverShoppingProduct `json:"products"`
+	UpdatedAt time.Time               `json:"updated_at"`
+}
+
+// Merge 두 작업결과데이터의 상품 목록을 합친 새로운 작업결과데이터를 반환한다.
+// 같은 상품(ProductID가 같은 상품, ProductID가 없는 경우 링크가 같은 상품)이 양쪽에 모두 있는 경우 가격이 더 낮은 상품을 남긴다.
+// 상품의 순서는 d의 상품 목록 뒤에 other에만 있는 상품을 덧붙인 순서이며, d와 other는 변경되지 않는다.
+func (d *naverShoppingWatchPriceResultData) Merge(other *naverShoppingWatchPriceResultData) *naverShoppingWatchPriceResultData {
+	key := func(p *naverShoppingProduct) string {
+		if p.ProductID != "" {
+			return p.ProductID
+		}
+		return p.Link
+	}
+
+	merged := &naverShoppingWatchPriceResultData{UpdatedAt: d.UpdatedAt}
+	indexes := make(map[string]int)
+	for _, result := range []*naverShoppingWatchPriceResultData{d, other} {
+		if result == nil {
+			continue
+		}
+
+		for _, p := range result.Products {
+			if i, exists := indexes[key(p)]; exists == true {
+				if p.LowPrice < merged.Products[i].LowPrice {
+					merged.Products[i] = p
+				}
+				continue
+			}
+
+			indexes[key(p)] = len(merged.Products)
+			merged.Products = append(merged.Products, p)
+		}
+
+		if result.UpdatedAt.After(merged.UpdatedAt) == true {
+			merged.UpdatedAt = result.UpdatedAt
+		}
+	}
+
+	return merged
+}
+
+// ToCSV 작업결과데이터의 상품 목록을 CSV 형식으로 변환한다.
+func (d *naverShoppingWatchPriceResultData) ToCSV() ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"product_id", "title", "link", "low_price", "mall_name", "updated_at"}); err != nil {
+		return nil, err
+	}
+
+	var updatedAt string
+	if d.UpdatedAt.IsZero() == false {
+		updatedAt = d.UpdatedAt.Format(time.RFC3339)
+	}
+	for _, p := range d.Products {
+		if err := w.Write([]string{p.ProductID, p.Title, p.Link, strconv.Itoa(p.LowPrice), p.MallName, updatedAt}); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
 }
 
 func init() {
@@ -99,6 +318,12 @@ func init() {
 
 			allowMultipleInstances: true,
 
+			newTaskResultDataFn: func() interface{} { return &naverShoppingWatchPriceResultData{} },
+		}, {
+			taskCommandID: TcidNaverShoppingWatchPriceMergedAny,
+
+			allowMultipleInstances: true,
+
 			newTaskResultDataFn: func() interface{} { return &naverShoppingWatchPriceResultData{} },
 		}},
 
@@ -107,16 +332,15 @@ func init() {
 				return nil, errors.New("등록되지 않은 작업입니다.😱")
 			}
 
-			taskData := &naverShoppingTaskData{}
+			var data map[string]interface{}
 			for _, t := range config.Tasks {
 				if taskRunData.taskID == TaskID(t.ID) {
-					if err := fillTaskDataFromMap(taskData, t.Data); err != nil {
-						return nil, errors.New(fmt.Sprintf("작업 데이터가 유효하지 않습니다.(error:%s)", err))
-					}
+					data = t.Data
 					break
 				}
 			}
-			if err := taskData.validate(); err != nil {
+			taskData := &naverShoppingTaskData{}
+			if err := fillTaskDataFromMap(taskData, data); err != nil {
 				return nil, errors.New(fmt.Sprintf("작업 데이터가 유효하지 않습니다.(error:%s)", err))
 			}
 
@@ -131,6 +355,9 @@ func init() {
 					canceled: false,
 
 					runBy: taskRunData.taskRunBy,
+
+					// 최초 실행 등에서 많은 상품이 한 번에 조회되는 경우 텔레그램의 최대 글자수를 초과할 수 있다.
+					splitLongMessages: true,
 				},
 
 				config: config,
@@ -150,11 +377,27 @@ func init() {
 									if err := fillTaskCommandDataFromMap(taskCommandData, c.Data); err != nil {
 										return "", nil, errors.New(fmt.Sprintf("작업 커맨드 데이터가 유효하지 않습니다.(error:%s)", err))
 									}
-									if err := taskCommandData.validate(); err != nil {
+
+									return task.runWatchPrice(taskCommandData, taskResultData, messageTypeHTML)
+								}
+							}
+							break
+						}
+					}
+				}
+
+				// 'WatchPriceMerged_'로 시작되는 명령인지 확인한다.
+				if strings.HasPrefix(string(task.CommandID()), naverShoppingWatchPriceMergedTaskCommandIDPrefix) == true {
+					for _, t := range task.config.Tasks {
+						if task.ID() == TaskID(t.ID) {
+							for _, c := range t.Commands {
+								if task.CommandID() == TaskCommandID(c.ID) {
+									taskCommandData := &naverShoppingWatchPriceMergedTaskCommandData{}
+									if err := fillTaskCommandDataFromMap(taskCommandData, c.Data); err != nil {
 										return "", nil, errors.New(fmt.Sprintf("작업 커맨드 데이터가 유효하지 않습니다.(error:%s)", err))
 									}
 
-									return task.runWatchPrice(taskCommandData, taskResultData, messageTypeHTML)
+									return task.runWatchPriceMerged(taskCommandData, taskResultData, messageTypeHTML)
 								}
 							}
 							break
@@ -186,6 +429,43 @@ func (t *naverShoppingTask) runWatchPrice(taskCommandData *naverShoppingWatchPri
 		log.Panic("TaskResultData의 타입 변환이 실패하였습니다.")
 	}
 
+	actualityTaskResultData, err := t.searchProducts(taskCommandData)
+	if err != nil {
+		return "", nil, err
+	}
+
+	filtersDescription := fmt.Sprintf("조회 조건은 아래와 같습니다:\n%s", taskCommandData.filtersDescription())
+
+	return t.diffProducts(originTaskResultData, actualityTaskResultData, taskCommandData.Filters.PriceDropPercent, taskCommandData.MessageTemplate, filtersDescription, messageTypeHTML)
+}
+
+// runWatchPriceMerged 여러 검색 조건으로 순서대로 상품을 검색하고, 검색된 상품 목록을 하나로 합친 후에 이전 작업결과데이터와 비교한다.
+func (t *naverShoppingTask) runWatchPriceMerged(taskCommandData *naverShoppingWatchPriceMergedTaskCommandData, taskResultData interface{}, messageTypeHTML bool) (message string, changedTaskResultData interface{}, err error) {
+	originTaskResultData, ok := taskResultData.(*naverShoppingWatchPriceResultData)
+	if ok == false {
+		log.Panic("TaskResultData의 타입 변환이 실패하였습니다.")
+	}
+
+	actualityTaskResultData := &naverShoppingWatchPriceResultData{}
+	filtersDescription := "조회 조건은 아래와 같습니다:"
+	for i, query := range taskCommandData.Queries {
+		queryTaskResultData, err := t.searchProducts(query)
+		if err != nil {
+			return "", nil, err
+		}
+		actualityTaskResultData = actualityTaskResultData.Merge(queryTaskResultData)
+
+		filtersDescription += fmt.Sprintf("\n\n[검색 조건 %d]\n%s", i+1, query.filtersDescription())
+	}
+	if taskCommandData.PriceDropPercent > 0 {
+		filtersDescription += fmt.Sprintf("\n\n• 이전 가격 대비 %d%% 이상 하락한 상품", taskCommandData.PriceDropPercent)
+	}
+
+	return t.diffProducts(originTaskResultData, actualityTaskResultData, taskCommandData.PriceDropPercent, taskCommandData.MessageTemplate, filtersDescription, messageTypeHTML)
+}
+
+// searchProducts 네이버쇼핑에서 상품을 검색하고, 검색된 상품 목록을 설정된 조건에 맞게 필터링한다.
+func (t *naverShoppingTask) searchProducts(taskCommandData *naverShoppingWatchPriceTaskCommandData) (*naverShoppingWatchPriceResultData, error) {
 	//
 	// 상품에 대한 정보를 검색한다.
 	//
@@ -202,9 +482,9 @@ func (t *naverShoppingTask) runWatchPrice(taskCommandData *naverShoppingWatchPri
 	)
 	for searchResultItemStartNo < searchResultItemTotalCount {
 		var _searchResultData_ = &naverShoppingSearchResultData{}
-		err = unmarshalFromResponseJSONData("GET", fmt.Sprintf("%s?query=%s&display=100&start=%d&sort=sim", naverShoppingSearchUrl, url.QueryEscape(taskCommandData.Query), searchResultItemStartNo), header, nil, _searchResultData_)
+		err := t.scraper().FetchJSON(t.context(), "GET", fmt.Sprintf("%s?query=%s&display=100&start=%d&sort=sim", naverShoppingSearchUrl, url.QueryEscape(taskCommandData.Query), searchResultItemStartNo), nil, header, _searchResultData_)
 		if err != nil {
-			return "", nil, err
+			return nil, err
 		}
 
 		if searchResultItemTotalCount == math.MaxInt {
@@ -229,21 +509,36 @@ func (t *naverShoppingTask) runWatchPrice(taskCommandData *naverShoppingWatchPri
 	// 검색된 상품 목록을 설정된 조건에 맞게 필터링한다.
 	//
 	actualityTaskResultData := &naverShoppingWatchPriceResultData{}
-	includedKeywords := utils.SplitExceptEmptyItems(taskCommandData.Filters.IncludedKeywords, ",")
-	excludedKeywords := utils.SplitExceptEmptyItems(taskCommandData.Filters.ExcludedKeywords, ",")
+	keywordMatcher, err := utils.NewKeywordMatcherWithValidation(utils.SplitExceptEmptyItems(taskCommandData.Filters.IncludedKeywords, ","), utils.SplitExceptEmptyItems(taskCommandData.Filters.ExcludedKeywords, ","))
+	if err != nil {
+		return nil, err
+	}
 
 	var lowPrice int
+	var invalidPriceCount = 0
 	for _, item := range searchResultData.Items {
-		if filter(item.Title, includedKeywords, excludedKeywords) == false {
+		item.Title = naverShoppingProductTitle(item.Title)
+
+		// 비정상적으로 낮은 가격은 데이터 오류로 판단하여 작업결과데이터에 포함하지 않는다.
+		lowPrice, err = strconv.Atoi(item.LowPrice)
+		if err != nil || lowPrice < naverShoppingSuspiciousLowPrice {
+			invalidPriceCount++
+
+			t.Log().Warnf("네이버쇼핑에서 비정상적인 상품 가격이 조회되었습니다.(Title:%s, Link:%s, LowPrice:%s)", item.Title, item.Link, item.LowPrice)
+
 			goto NEXTITEM
 		}
 
-		lowPrice, _ = strconv.Atoi(item.LowPrice)
-		if lowPrice > 0 && lowPrice < taskCommandData.Filters.PriceLessThan {
+		if keywordMatcher.Match(item.Title) == false {
+			goto NEXTITEM
+		}
+
+		if taskCommandData.isPriceEligible(lowPrice) == true && taskCommandData.isMallEligible(item.MallName) == true {
 			actualityTaskResultData.Products = append(actualityTaskResultData.Products, &naverShoppingProduct{
 				Title:       item.Title,
 				Link:        item.Link,
 				LowPrice:    lowPrice,
+				MallName:    item.MallName,
 				ProductID:   item.ProductID,
 				ProductType: item.ProductType,
 			})
@@ -252,14 +547,48 @@ func (t *naverShoppingTask) runWatchPrice(taskCommandData *naverShoppingWatchPri
 	NEXTITEM:
 	}
 
+	// 가격이 유효하지 않은 상품이 많은 경우, 작업결과데이터가 잘못 갱신되지 않도록 오류를 반환한다.
+	if len(searchResultData.Items) > 0 && invalidPriceCount*100 > len(searchResultData.Items)*naverShoppingMaxInvalidPricePercent {
+		return nil, fmt.Errorf("검색된 상품 %d개 중 %d개 상품의 가격이 유효하지 않습니다. 네이버쇼핑 검색 결과를 신뢰할 수 없습니다", len(searchResultData.Items), invalidPriceCount)
+	}
+
+	return actualityTaskResultData, nil
+}
+
+// diffProducts 이전 작업결과데이터와 비교하여 새로 조회되었거나 가격이 변경된 상품을 알리는 메시지를 생성한다.
+// 가격 하락률 조건을 만족하지 않아 알리지 않은 상품은 사용자가 알림을 받은 이전 가격을 기준으로 다음 작업에서 다시 비교할 수 있도록 이전 가격을 그대로 저장한다.
+func (t *naverShoppingTask) diffProducts(originTaskResultData, actualityTaskResultData *naverShoppingWatchPriceResultData, priceDropPercent int, messageTemplateText, filtersDescription string, messageTypeHTML bool) (message string, changedTaskResultData interface{}, err error) {
 	//
 	// 필터링 된 상품 정보를 확인한다.
 	//
+	productString := func(p *naverShoppingProduct, mark string) string {
+		return p.String(messageTypeHTML, mark)
+	}
+	if messageTemplateText != "" {
+		messageTemplate, err := utils.NewNotificationTemplate(string(t.CommandID()), messageTemplateText)
+		if err != nil {
+			return "", nil, err
+		}
+
+		productString = func(p *naverShoppingProduct, mark string) string {
+			s, err := messageTemplate.Render(&naverShoppingProductTemplateData{naverShoppingProduct: p, Mark: mark, HTML: messageTypeHTML})
+			if err != nil {
+				t.Log().Warnf("%s 기본 형식으로 메시지를 생성합니다.", err)
+				return p.String(messageTypeHTML, mark)
+			}
+			return s
+		}
+	}
+
 	m := ""
 	lineSpacing := "\n\n"
 	if messageTypeHTML == true {
 		lineSpacing = "\n"
 	}
+
+	// 가격이 변경되었지만 알리지 않은 상품과 알림을 받은 이전 가격
+	suppressedProducts := make(map[*naverShoppingProduct]int)
+
 	err = eachSourceElementIsInTargetElementOrNot(actualityTaskResultData.Products, originTaskResultData.Products, func(selem, telem interface{}) (bool, error) {
 		actualityProduct, ok1 := selem.(*naverShoppingProduct)
 		originProduct, ok2 := telem.(*naverShoppingProduct)
@@ -276,10 +605,31 @@ func (t *naverShoppingTask) runWatchPrice(taskCommandData *naverShoppingWatchPri
 		originProduct := telem.(*naverShoppingProduct)
 
 		if actualityProduct.LowPrice != originProduct.LowPrice {
+			// 가격 하락률이 설정된 경우, 이전 가격 대비 설정된 비율 이상 하락한 상품만 알린다.
+			if priceDropPercent > 0 {
+				if originProduct.LowPrice <= 0 || actualityProduct.LowPrice > originProduct.LowPrice {
+					suppressedProducts[actualityProduct] = originProduct.LowPrice
+					return
+				}
+
+				dropPercent := (originProduct.LowPrice - actualityProduct.LowPrice) * 100 / originProduct.LowPrice
+				if dropPercent < priceDropPercent {
+					suppressedProducts[actualityProduct] = originProduct.LowPrice
+					return
+				}
+
+				if m != "" {
+					m += lineSpacing
+				}
+				m += productString(originProduct, fmt.Sprintf(" ⇒ %s (%d%%↓) 🔁", utils.FormatKRW(actualityProduct.LowPrice), dropPercent))
+
+				return
+			}
+
 			if m != "" {
 				m += lineSpacing
 			}
-			m += originProduct.String(messageTypeHTML, fmt.Sprintf(" ⇒ %s원 🔁", utils.FormatCommas(actualityProduct.LowPrice)))
+			m += productString(originProduct, fmt.Sprintf(" ⇒ %s 🔁", utils.FormatKRW(actualityProduct.LowPrice)))
 		}
 	}, func(selem interface{}) {
 		actualityProduct := selem.(*naverShoppingProduct)
@@ -287,19 +637,22 @@ func (t *naverShoppingTask) runWatchPrice(taskCommandData *naverShoppingWatchPri
 		if m != "" {
 			m += lineSpacing
 		}
-		m += actualityProduct.String(messageTypeHTML, " 🆕")
+		m += productString(actualityProduct, mark.New)
 	})
 	if err != nil {
 		return "", nil, err
 	}
 
-	filtersDescription := fmt.Sprintf("조회 조건은 아래와 같습니다:\n• 검색 키워드 : %s\n• 상풍명 포함 키워드 : %s\n• 상품명 제외 키워드 : %s\n• %s원 미만의 상품", taskCommandData.Query, taskCommandData.Filters.IncludedKeywords, taskCommandData.Filters.ExcludedKeywords, utils.FormatCommas(taskCommandData.Filters.PriceLessThan))
-
 	if m != "" {
 		message = fmt.Sprintf("조회 조건에 해당되는 상품의 정보가 변경되었습니다.\n\n%s\n\n%s", filtersDescription, m)
+
+		for p, lowPrice := range suppressedProducts {
+			p.LowPrice = lowPrice
+		}
+		actualityTaskResultData.UpdatedAt = time.Now()
 		changedTaskResultData = actualityTaskResultData
 	} else {
-		if t.runBy == TaskRunByUser {
+		if t.runBy.IsOnDemand() == true {
 			if len(actualityTaskResultData.Products) == 0 {
 				message = fmt.Sprintf("조회 조건에 해당되는 상품이 존재하지 않습니다.\n\n%s", filtersDescription)
 			} else {
@@ -307,7 +660,7 @@ func (t *naverShoppingTask) runWatchPrice(taskCommandData *naverShoppingWatchPri
 					if m != "" {
 						m += lineSpacing
 					}
-					m += actualityProduct.String(messageTypeHTML, "")
+					m += productString(actualityProduct, "")
 				}
 
 				message = fmt.Sprintf("조회 조건에 해당되는 상품의 변경된 정보가 없습니다.\n\n%s\n\n조회 조건에 해당되는 상품은 아래와 같습니다:\n\n%s", filtersDescription, m)
@@ -0,0 +1,115 @@
+package task
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNaverShoppingWatchPriceResultData_Merge(t *testing.T) {
+	now := time.Now()
+
+	d1 := &naverShoppingWatchPriceResultData{
+		Products: []*naverShoppingProduct{
+			{ProductID: "1", Title: "상품1", LowPrice: 10000},
+			{ProductID: "2", Title: "상품2", LowPrice: 20000},
+		},
+		UpdatedAt: now.Add(-time.Hour),
+	}
+	d2 := &naverShoppingWatchPriceResultData{
+		Products: []*naverShoppingProduct{
+			{ProductID: "2", Title: "상품2", LowPrice: 15000},
+			{ProductID: "1", Title: "상품1", LowPrice: 12000},
+			{ProductID: "3", Title: "상품3", LowPrice: 30000},
+		},
+		UpdatedAt: now,
+	}
+
+	merged := d1.Merge(d2)
+
+	// 같은 상품은 가격이 더 낮은 상품을 남기고, d1의 순서 뒤에 d2에만 있는 상품이 추가된다.
+	assert.Len(t, merged.Products, 3)
+	assert.Equal(t, "1", merged.Products[0].ProductID)
+	assert.Equal(t, 10000, merged.Products[0].LowPrice)
+	assert.Equal(t, "2", merged.Products[1].ProductID)
+	assert.Equal(t, 15000, merged.Products[1].LowPrice)
+	assert.Equal(t, "3", merged.Products[2].ProductID)
+	assert.Equal(t, now, merged.UpdatedAt)
+
+	// 원본 작업결과데이터는 변경되지 않는다.
+	assert.Len(t, d1.Products, 2)
+	assert.Equal(t, 20000, d1.Products[1].LowPrice)
+
+	assert.Len(t, d1.Merge(nil).Products, 2)
+}
+
+func TestNaverShoppingTask_DiffProducts_KeepsBaselineOfSuppressedProducts(t *testing.T) {
+	nst := &naverShoppingTask{task: task{id: "NS", commandID: "WatchPrice", runBy: TaskRunByScheduler}}
+
+	origin := &naverShoppingWatchPriceResultData{
+		Products: []*naverShoppingProduct{
+			{Link: "1", Title: "상품1", LowPrice: 10000},
+			{Link: "2", Title: "상품2", LowPrice: 10000},
+			{Link: "3", Title: "상품3", LowPrice: 10000},
+		},
+	}
+	actuality := &naverShoppingWatchPriceResultData{
+		Products: []*naverShoppingProduct{
+			{Link: "1", Title: "상품1", LowPrice: 8000},
+			{Link: "2", Title: "상품2", LowPrice: 9500},
+			{Link: "3", Title: "상품3", LowPrice: 12000},
+		},
+	}
+
+	// 하락률이 10% 이상인 상품만 알린다.
+	message, changedTaskResultData, err := nst.diffProducts(origin, actuality, 10, "", "", false)
+	assert.NoError(t, err)
+	assert.Contains(t, message, "상품1")
+	assert.NotContains(t, message, "상품2")
+	assert.NotContains(t, message, "상품3")
+
+	// 알리지 않은 상품은 알림을 받은 이전 가격이 그대로 저장된다.
+	changed := changedTaskResultData.(*naverShoppingWatchPriceResultData)
+	assert.Equal(t, 8000, changed.Products[0].LowPrice)
+	assert.Equal(t, 10000, changed.Products[1].LowPrice)
+	assert.Equal(t, 10000, changed.Products[2].LowPrice)
+
+	// 이전 가격을 기준으로 비교하므로 조금씩 하락하여 누적 하락률이 10% 이상이 되면 알린다.
+	next := &naverShoppingWatchPriceResultData{
+		Products: []*naverShoppingProduct{
+			{Link: "1", Title: "상품1", LowPrice: 8000},
+			{Link: "2", Title: "상품2", LowPrice: 9000},
+			{Link: "3", Title: "상품3", LowPrice: 12000},
+		},
+	}
+	message, _, err = nst.diffProducts(changed, next, 10, "", "", false)
+	assert.NoError(t, err)
+	assert.Contains(t, message, "상품2")
+	assert.NotContains(t, message, "상품3")
+}
+
+func TestNaverShoppingWatchPriceTaskCommandData_IsMallEligible(t *testing.T) {
+	cases := []struct {
+		name      string
+		whitelist []string
+		blacklist []string
+		mallName  string
+		expected  bool
+	}{
+		{name: "조건 없음", mallName: "쿠팡", expected: true},
+		{name: "whitelist 포함", whitelist: []string{"쿠팡", "11번가"}, mallName: "11번가", expected: true},
+		{name: "whitelist 대소문자/공백 무시", whitelist: []string{" SSG.COM "}, mallName: "ssg.com", expected: true},
+		{name: "whitelist 미포함", whitelist: []string{"쿠팡"}, mallName: "11번가", expected: false},
+		{name: "whitelist가 blacklist보다 우선", whitelist: []string{"쿠팡"}, blacklist: []string{"쿠팡"}, mallName: "쿠팡", expected: true},
+		{name: "blacklist 포함", blacklist: []string{"G마켓"}, mallName: "g마켓", expected: false},
+		{name: "blacklist 미포함", blacklist: []string{"G마켓"}, mallName: "쿠팡", expected: true},
+	}
+
+	for _, c := range cases {
+		d := &naverShoppingWatchPriceTaskCommandData{}
+		d.Filters.MallWhitelist = c.whitelist
+		d.Filters.MallBlacklist = c.blacklist
+
+		assert.Equal(t, c.expected, d.isMallEligible(c.mallName), c.name)
+	}
+}
@@ -0,0 +1,299 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/darkkaiser/notify-server/utils"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/unicode/norm"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNaverPerformanceKey(t *testing.T) {
+	// 앞뒤 공백은 무시된다.
+	p1 := &naverPerformance{Title: "뮤지컬 ", Place: " 예술의전당"}
+	p2 := &naverPerformance{Title: "뮤지컬", Place: "예술의전당"}
+	assert.Equal(t, p2.Key(), p1.Key())
+
+	// NFD로 분해된 한글은 NFC로 조합된 한글과 같은 키를 가진다.
+	nfd := &naverPerformance{Title: norm.NFD.String("뮤지컬"), Place: norm.NFD.String("예술의전당")}
+	assert.NotEqual(t, p2.Title, nfd.Title)
+	assert.Equal(t, p2.Key(), nfd.Key())
+
+	// 제목 또는 장소가 다르면 다른 키를 가진다.
+	assert.NotEqual(t, p2.Key(), (&naverPerformance{Title: "뮤지컬", Place: "세종문화회관"}).Key())
+}
+
+func TestNaverPerformanceKey_IgnoresDateRange(t *testing.T) {
+	p1 := &naverPerformance{Title: "뮤지컬", Place: "예술의전당", StartDate: time.Date(2024, 1, 5, 0, 0, 0, 0, naverPerformanceLocation)}
+	p2 := &naverPerformance{Title: "뮤지컬", Place: "예술의전당", StartDate: time.Date(2024, 3, 1, 0, 0, 0, 0, naverPerformanceLocation)}
+	assert.Equal(t, p1.Key(), p2.Key())
+}
+
+func TestParseNaverPerformanceDateRange(t *testing.T) {
+	startDate, endDate := parseNaverPerformanceDateRange("2024.01.05.~2024.02.28.")
+	assert.Equal(t, time.Date(2024, 1, 5, 0, 0, 0, 0, naverPerformanceLocation), startDate)
+	assert.Equal(t, time.Date(2024, 2, 28, 0, 0, 0, 0, naverPerformanceLocation), endDate)
+
+	// 날짜가 하나만 있는 경우 시작일과 종료일이 같다.
+	startDate, endDate = parseNaverPerformanceDateRange("24.03.01.")
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, naverPerformanceLocation), startDate)
+	assert.Equal(t, startDate, endDate)
+
+	startDate, endDate = parseNaverPerformanceDateRange("오픈런")
+	assert.True(t, startDate.IsZero())
+	assert.True(t, endDate.IsZero())
+
+	p := &naverPerformance{Title: "뮤지컬", Place: "예술의전당"}
+	assert.Equal(t, "", p.DateRange())
+	p.StartDate, p.EndDate = parseNaverPerformanceDateRange("2024.01.05.~2024.02.28.")
+	assert.Equal(t, "2024.01.05 ~ 2024.02.28", p.DateRange())
+	assert.Equal(t, "☞ 뮤지컬\n      • 장소 : 예술의전당\n      • 기간 : 2024.01.05 ~ 2024.02.28", p.String(false, ""))
+}
+
+func TestNaverWatchNewPerformancesTaskCommandData_MatchDateRange(t *testing.T) {
+	d := &naverWatchNewPerformancesTaskCommandData{Query: "뮤지컬"}
+	d.Filters.StartAfter = "2024-02-01T00:00:00+09:00"
+	d.Filters.EndBefore = "2024-06-30T00:00:00+09:00"
+	d.ApplyDefaults()
+	assert.NoError(t, d.Validate())
+
+	newPerformance := func(s string) *naverPerformance {
+		p := &naverPerformance{}
+		p.StartDate, p.EndDate = parseNaverPerformanceDateRange(s)
+		return p
+	}
+	assert.True(t, d.matchDateRange(newPerformance("2024.03.01.~2024.04.30.")))
+	assert.False(t, d.matchDateRange(newPerformance("2024.01.05.~2024.04.30.")))
+	assert.False(t, d.matchDateRange(newPerformance("2024.03.01.~2024.12.31.")))
+
+	// 공연 기간을 확인할 수 없는 공연정보는 제외하지 않는다.
+	assert.True(t, d.matchDateRange(newPerformance("")))
+
+	d.Filters.EndBefore = "2024-06-30"
+	assert.Error(t, d.Validate())
+}
+
+const naverPerformancesTestHTML = `<ul>
+<li><div class="item"><div class="thumb"><img src="https://example.com/1.jpg"></div><div class="title_box"><strong class="name"> 뮤지컬 </strong><span class="sub_text">예술의전당</span></div><div class="other_info">2024.01.05. ~ 2024.03.01.</div></div></li>
+<li><div class="item"><div class="thumb"><img src="https://example.com/2.jpg"></div><div class="title_box"><strong class="name">연극</strong><span class="sub_text">대학로</span></div></div></li>
+</ul>`
+
+// naverPerformancesTestErrorHTMLs 공연정보 추출이 실패하여야 하는 HTML
+var naverPerformancesTestErrorHTMLs = map[string]string{
+	"제목 없음":         `<ul><li><div class="item"><div class="thumb"><img src="1.jpg"></div><div class="title_box"><span class="sub_text">예술의전당</span></div></div></li></ul>`,
+	"장소 없음":         `<ul><li><div class="item"><div class="thumb"><img src="1.jpg"></div><div class="title_box"><strong class="name">뮤지컬</strong></div></div></li></ul>`,
+	"썸네일 이미지 없음":    `<ul><li><div class="item"><div class="title_box"><strong class="name">뮤지컬</strong><span class="sub_text">예술의전당</span></div></div></li></ul>`,
+	"썸네일 이미지 경로 없음": `<ul><li><div class="item"><div class="thumb"><img></div><div class="title_box"><strong class="name">뮤지컬</strong><span class="sub_text">예술의전당</span></div></div></li></ul>`,
+}
+
+func TestParsePerformancesFromHTML(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(naverPerformancesTestHTML))
+	assert.NoError(t, err)
+
+	performances, err := parsePerformancesFromHTML(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(performances))
+	assert.Equal(t, "뮤지컬", performances[0].Title)
+	assert.Equal(t, "예술의전당", performances[0].Place)
+	assert.Equal(t, `<img src="https://example.com/1.jpg">`, performances[0].Thumbnail)
+	assert.Equal(t, time.Date(2024, 1, 5, 0, 0, 0, 0, naverPerformanceLocation), performances[0].StartDate)
+	assert.True(t, performances[1].StartDate.IsZero())
+
+	for name, html := range naverPerformancesTestErrorHTMLs {
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+		assert.NoError(t, err)
+
+		performances, err := parsePerformancesFromHTML(doc)
+		assert.Error(t, err, name)
+		assert.Nil(t, performances, name)
+	}
+}
+
+// FuzzParsePerformancesFromHTML 외부 서버에서 읽어온 HTML이 어떤 형태로 깨져 있더라도 panic이 발생하지 않아야 한다.
+// go test -fuzz=FuzzParsePerformancesFromHTML ./service/task 명령으로 실행한다.
+func FuzzParsePerformancesFromHTML(f *testing.F) {
+	f.Add(naverPerformancesTestHTML)
+	f.Add("")
+	f.Add("<html></html>")
+	for _, html := range naverPerformancesTestErrorHTMLs {
+		f.Add(html)
+	}
+
+	f.Fuzz(func(t *testing.T, html string) {
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+		if err != nil {
+			return
+		}
+
+		performances, err := parsePerformancesFromHTML(doc)
+		if err != nil {
+			assert.Nil(t, performances)
+		}
+	})
+}
+
+// setNaverPerformancesTestPages pages의 HTML을 순서대로 1페이지부터 응답하고, 마지막 페이지 다음에는 빈 페이지를 응답하도록 mock에 등록한다.
+func setNaverPerformancesTestPages(mock *MockHTTPFetcher, taskCommandData *naverWatchNewPerformancesTaskCommandData, pages ...string) {
+	for i, html := range append(pages, "") {
+		body, _ := json.Marshal(&naverWatchNewPerformancesSearchResultData{Html: html})
+		mock.SetResponse(buildPerformanceSearchURL(taskCommandData.Query, taskCommandData.Genre, i+1), http.StatusOK, string(body))
+	}
+}
+
+// useMockFetcher 테스트가 끝날 때까지 작업이 보내는 요청을 mock이 처리하도록 한다.
+func useMockFetcher(t *testing.T, mock *MockHTTPFetcher) {
+	origin := fetcher
+	fetcher = mock
+	t.Cleanup(func() { fetcher = origin })
+}
+
+// useMockRetryFetcher useMockFetcher()와 같지만, 요청이 실패하거나 재시도 조건을 만족하면 maxRetries번까지 재시도한다.
+func useMockRetryFetcher(t *testing.T, mock *MockHTTPFetcher, maxRetries int) {
+	origin := fetcher
+	fetcher = NewRetryFetcher(mock, RetryConfig{MaxRetries: maxRetries, RetryDelay: time.Millisecond, MaxRetryDelay: time.Millisecond})
+	t.Cleanup(func() { fetcher = origin })
+}
+
+func TestNaverTask_RunWatchNewPerformances_MissingRegisteredAt(t *testing.T) {
+	mock := NewMockHTTPFetcher()
+	useMockFetcher(t, mock)
+
+	taskCommandData := &naverWatchNewPerformancesTaskCommandData{Query: "전라도", MaxAgeDays: 30}
+	taskCommandData.ApplyDefaults()
+	setNaverPerformancesTestPages(mock, taskCommandData, `<ul>
+<li><div class="item"><div class="thumb"><img src="https://example.com/1.jpg"></div><div class="title_box"><strong class="name">뮤지컬</strong><span class="sub_text">예술의전당</span></div></div></li>
+</ul>`)
+
+	// 등록된 시간이 저장되지 않은 이전 버전의 작업결과데이터
+	originTaskResultData := &naverWatchNewPerformancesResultData{
+		Performances: []*naverPerformance{
+			{Title: "뮤지컬", Place: "예술의전당"},
+			{Title: "연극", Place: "대학로"},
+		},
+	}
+
+	nt := &naverTask{task: task{runBy: TaskRunByScheduler}}
+	message, changedTaskResultData, err := nt.runWatchNewPerformances(taskCommandData, originTaskResultData, false)
+	assert.NoError(t, err)
+
+	// 등록된 시간이 없는 공연정보는 오래전에 등록된 것으로 간주하지 않고 삭제를 알린다.
+	assert.Contains(t, message, "연극")
+
+	// 유지되는 공연정보는 현재 시간이 등록된 시간으로 저장된다.
+	assert.NotNil(t, changedTaskResultData)
+	performances := changedTaskResultData.(*naverWatchNewPerformancesResultData).Performances
+	assert.Equal(t, 1, len(performances))
+	assert.WithinDuration(t, time.Now(), performances[0].RegisteredAt, time.Minute)
+
+	// 변경된 공연정보가 없더라도 새로 저장된 등록 시간은 작업결과데이터에 반영된다.
+	setNaverPerformancesTestPages(mock, taskCommandData, `<ul>
+<li><div class="item"><div class="thumb"><img src="https://example.com/1.jpg"></div><div class="title_box"><strong class="name">뮤지컬</strong><span class="sub_text">예술의전당</span></div></div></li>
+</ul>`)
+	originTaskResultData = &naverWatchNewPerformancesResultData{
+		Performances: []*naverPerformance{{Title: "뮤지컬", Place: "예술의전당"}},
+	}
+	message, changedTaskResultData, err = nt.runWatchNewPerformances(taskCommandData, originTaskResultData, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "", message)
+	assert.NotNil(t, changedTaskResultData)
+}
+
+func TestNaverTask_FetchPerformances_Cancellation(t *testing.T) {
+	mock := NewMockHTTPFetcher()
+	useMockFetcher(t, mock)
+
+	taskCommandData := &naverWatchNewPerformancesTaskCommandData{Query: "전라도"}
+	taskCommandData.ApplyDefaults()
+	setNaverPerformancesTestPages(mock, taskCommandData, naverPerformancesTestHTML)
+
+	// 첫 페이지의 응답이 지연되는 동안 작업이 취소된다.
+	mock.SetDelay(buildPerformanceSearchURL(taskCommandData.Query, taskCommandData.Genre, 1), 10*time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	nt := &naverTask{task: task{runCtx: ctx}}
+
+	start := time.Now()
+	performances, err := nt.fetchPerformances(taskCommandData, utils.NewKeywordMatcher(nil, nil), utils.NewKeywordMatcher(nil, nil))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, performances)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// 취소된 이후에는 다음 페이지를 요청하지 않는다.
+	assert.Equal(t, 0, mock.GetRequestCount(buildPerformanceSearchURL(taskCommandData.Query, taskCommandData.Genre, 2)))
+}
+
+func TestNaverTask_FetchPerformances_RetryOnEmptyHTML(t *testing.T) {
+	mock := NewMockHTTPFetcher()
+	useMockRetryFetcher(t, mock, 1)
+
+	taskCommandData := &naverWatchNewPerformancesTaskCommandData{Query: "전라도"}
+	taskCommandData.ApplyDefaults()
+
+	// 첫 페이지는 일시적으로 html 항목이 비어있는 결과로 응답한 후에 정상적으로 응답한다.
+	firstPageURL := buildPerformanceSearchURL(taskCommandData.Query, taskCommandData.Genre, 1)
+	mock.SetResponse(firstPageURL, http.StatusOK, `{"html":""}`)
+	setNaverPerformancesTestPages(mock, taskCommandData, naverPerformancesTestHTML)
+
+	nt := &naverTask{}
+
+	performances, err := nt.fetchPerformances(taskCommandData, utils.NewKeywordMatcher(nil, nil), utils.NewKeywordMatcher(nil, nil))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, performances)
+	assert.Equal(t, 2, mock.GetRequestCount(firstPageURL))
+
+	// 재시도한 후에도 html 항목이 비어있는 페이지는 마지막 페이지로 인식한다.
+	assert.Equal(t, 2, mock.GetRequestCount(buildPerformanceSearchURL(taskCommandData.Query, taskCommandData.Genre, 2)))
+}
+
+func TestNaverTask_FetchPerformances_PageOrder(t *testing.T) {
+	performanceHTML := func(titles ...string) string {
+		var sb strings.Builder
+		sb.WriteString("<ul>")
+		for _, title := range titles {
+			sb.WriteString(`<li><div class="item"><div class="thumb"><img src="https://example.com/1.jpg"></div><div class="title_box"><strong class="name">` + title + `</strong><span class="sub_text">예술의전당</span></div></div></li>`)
+		}
+		sb.WriteString("</ul>")
+		return sb.String()
+	}
+
+	for _, concurrentPages := range []int{1, 2, 3} {
+		mock := NewMockHTTPFetcher()
+		useMockRetryFetcher(t, mock, 1)
+
+		taskCommandData := &naverWatchNewPerformancesTaskCommandData{Query: "전라도", ConcurrentPages: concurrentPages}
+		taskCommandData.ApplyDefaults()
+
+		// 3번째 페이지가 비어있으므로 4번째 페이지의 공연정보는 포함되지 않아야 한다.
+		setNaverPerformancesTestPages(mock, taskCommandData, performanceHTML("공연1"), performanceHTML("공연2", "공연3"), "", performanceHTML("공연4"))
+
+		nt := &naverTask{}
+
+		performances, err := nt.fetchPerformances(taskCommandData, utils.NewKeywordMatcher(nil, nil), utils.NewKeywordMatcher(nil, nil))
+		assert.NoError(t, err, "concurrent_pages=%d", concurrentPages)
+
+		var titles []string
+		for _, p := range performances {
+			titles = append(titles, p.Title)
+		}
+		assert.Equal(t, []string{"공연1", "공연2", "공연3"}, titles, "concurrent_pages=%d", concurrentPages)
+
+		// 비어있는 페이지와 함께 읽어들이는 페이지가 아니라면, 비어있는 페이지 이후의 페이지는 요청하지 않는다.
+		if concurrentPages != 2 {
+			assert.Equal(t, 0, mock.GetRequestCount(buildPerformanceSearchURL(taskCommandData.Query, taskCommandData.Genre, 4)), "concurrent_pages=%d", concurrentPages)
+		}
+		if concurrentPages == 1 {
+			pageURL := func(pageIndex int) string {
+				return buildPerformanceSearchURL(taskCommandData.Query, taskCommandData.Genre, pageIndex)
+			}
+			// 비어있는 페이지는 한 번 더 요청하여 확인한다.
+			assert.Equal(t, []string{pageURL(1), pageURL(2), pageURL(3), pageURL(3)}, mock.GetRequestedURLsOrdered())
+		}
+	}
+}
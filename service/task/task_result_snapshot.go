@@ -0,0 +1,106 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// 현재 작업결과데이터의 버전
+	// 작업결과데이터의 구조가 호환되지 않게 변경되는 경우 1씩 증가시키고 snapshotMigrations에 변환 함수를 추가한다.
+	currentSnapshotVersion = 1
+
+	// 작업결과데이터에 버전이 저장되는 항목의 이름
+	snapshotVersionKey = "snapshot_version"
+)
+
+// snapshotMigrations 각 버전의 작업결과데이터를 다음 버전으로 변환하는 함수
+// snapshotMigrations[n]은 버전 n의 작업결과데이터를 버전 n+1로 변환한다.
+var snapshotMigrations = []func(old map[string]interface{}) (map[string]interface{}, error){
+	// 버전 0 : 버전 정보가 저장되기 이전의 작업결과데이터이며, 추가된 항목은 읽어들일 때 기본값으로 채워지므로 그대로 사용한다.
+	func(old map[string]interface{}) (map[string]interface{}, error) {
+		return old, nil
+	},
+}
+
+// marshalSnapshot 작업결과데이터에 현재 버전을 추가하여 JSON으로 변환한다.
+func marshalSnapshot(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON 객체가 아닌 작업결과데이터는 버전을 추가하지 않는다.
+	var m map[string]json.RawMessage
+	if err = json.Unmarshal(data, &m); err != nil || m == nil {
+		return data, nil
+	}
+
+	m[snapshotVersionKey] = json.RawMessage(fmt.Sprintf("%d", currentSnapshotVersion))
+
+	return json.Marshal(m)
+}
+
+// unmarshalSnapshot 저장된 작업결과데이터를 v에 읽어들인다.
+// 이전 버전의 작업결과데이터는 현재 버전으로 변환한 후에 읽어들이며, 변환이 실패한 경우 경고 로그를 남기고 v를 변경하지 않는다.
+// 현재 버전보다 이후 버전의 작업결과데이터는 항목의 의미를 알 수 없으므로 v를 변경하지 않고 에러를 반환한다.
+func unmarshalSnapshot(data []byte, v interface{}) error {
+	var header struct {
+		SnapshotVersion int `json:"snapshot_version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		// JSON 객체가 아닌 작업결과데이터는 그대로 읽어들인다.
+		return json.Unmarshal(data, v)
+	}
+
+	if header.SnapshotVersion > currentSnapshotVersion {
+		return fmt.Errorf("현재 버전(%d)보다 이후 버전(%d)의 작업결과데이터는 읽어들일 수 없습니다", currentSnapshotVersion, header.SnapshotVersion)
+	}
+
+	if header.SnapshotVersion < currentSnapshotVersion {
+		var old map[string]interface{}
+
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.UseNumber()
+		if err := d.Decode(&old); err != nil {
+			return err
+		}
+
+		migrated, err := migrateSnapshot(old, header.SnapshotVersion)
+		if err != nil {
+			log.Warnf("이전 버전(%d)의 작업결과데이터를 현재 버전(%d)으로 변환하지 못하여, 작업결과데이터를 사용하지 않습니다.(error:%s)", header.SnapshotVersion, currentSnapshotVersion, err)
+			return nil
+		}
+
+		if data, err = json.Marshal(migrated); err != nil {
+			return err
+		}
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// migrateSnapshot 버전 version의 작업결과데이터를 현재 버전으로 변환한다.
+func migrateSnapshot(old map[string]interface{}, version int) (snapshot map[string]interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			snapshot, err = nil, fmt.Errorf("작업결과데이터 변환중에 panic이 발생하였습니다.(panic:%v)", r)
+		}
+	}()
+
+	if version < 0 || version >= len(snapshotMigrations) {
+		return nil, fmt.Errorf("지원하지 않는 작업결과데이터 버전(%d)입니다", version)
+	}
+
+	snapshot = old
+	for ; version < currentSnapshotVersion; version++ {
+		if snapshot, err = snapshotMigrations[version](snapshot); err != nil {
+			return nil, err
+		}
+	}
+	snapshot[snapshotVersionKey] = currentSnapshotVersion
+
+	return snapshot, nil
+}
@@ -0,0 +1,64 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type snapshotTestData struct {
+	Name  string   `json:"name"`
+	Items []string `json:"items"`
+}
+
+func TestMarshalSnapshot_RoundTrip(t *testing.T) {
+	data, err := marshalSnapshot(&snapshotTestData{Name: "name", Items: []string{"a", "b"}})
+	assert.NoError(t, err)
+
+	var m map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &m))
+	assert.Equal(t, float64(currentSnapshotVersion), m[snapshotVersionKey])
+
+	v := &snapshotTestData{}
+	assert.NoError(t, unmarshalSnapshot(data, v))
+	assert.Equal(t, &snapshotTestData{Name: "name", Items: []string{"a", "b"}}, v)
+}
+
+func TestMarshalSnapshot_NotObject(t *testing.T) {
+	data, err := marshalSnapshot([]string{"a", "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, `["a","b"]`, string(data))
+
+	var v []string
+	assert.NoError(t, unmarshalSnapshot(data, &v))
+	assert.Equal(t, []string{"a", "b"}, v)
+}
+
+func TestUnmarshalSnapshot_MigratesUnversionedData(t *testing.T) {
+	v := &snapshotTestData{}
+	assert.NoError(t, unmarshalSnapshot([]byte(`{"name":"name","items":["a"]}`), v))
+	assert.Equal(t, &snapshotTestData{Name: "name", Items: []string{"a"}}, v)
+}
+
+func TestUnmarshalSnapshot_MigrationFailed(t *testing.T) {
+	origin := snapshotMigrations
+	defer func() { snapshotMigrations = origin }()
+
+	snapshotMigrations = []func(old map[string]interface{}) (map[string]interface{}, error){
+		func(old map[string]interface{}) (map[string]interface{}, error) {
+			return nil, fmt.Errorf("migration failed")
+		},
+	}
+
+	v := &snapshotTestData{Name: "origin"}
+	assert.NoError(t, unmarshalSnapshot([]byte(`{"name":"name"}`), v))
+	assert.Equal(t, "origin", v.Name)
+}
+
+func TestUnmarshalSnapshot_RejectsNewerVersion(t *testing.T) {
+	v := &snapshotTestData{Name: "origin"}
+	err := unmarshalSnapshot([]byte(fmt.Sprintf(`{"name":"name","snapshot_version":%d}`, currentSnapshotVersion+1)), v)
+	assert.Error(t, err)
+	assert.Equal(t, "origin", v.Name)
+}
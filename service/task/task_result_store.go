@@ -0,0 +1,193 @@
+package task
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/darkkaiser/notify-server/g"
+	"github.com/darkkaiser/notify-server/utils"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	TaskResultStoreTypeFile   = "file"
+	TaskResultStoreTypeSQLite = "sqlite"
+
+	// 작업결과데이터 파일의 압축 여부를 결정하는 기본 크기
+	defaultTaskResultCompressionThresholdBytes = 10 * 1024
+)
+
+// TaskResultStore 작업결과데이터를 저장하고 읽어들인다.
+type TaskResultStore interface {
+	// Load 저장된 작업결과데이터를 v에 읽어들인다. 저장된 데이터가 없는 경우 v를 변경하지 않고 nil을 반환한다.
+	Load(taskID TaskID, taskCommandID TaskCommandID, v interface{}) error
+	Save(taskID TaskID, taskCommandID TaskCommandID, v interface{}) error
+	// Delete 저장된 작업결과데이터를 삭제한다. 저장된 데이터가 없는 경우 nil을 반환한다.
+	Delete(taskID TaskID, taskCommandID TaskCommandID) error
+
+	Close() error
+}
+
+// TaskResultDataExporter 작업결과데이터를 내려받을 수 있는 형식으로 변환한다.
+// 작업결과데이터의 내보내기를 지원하는 작업의 작업결과데이터가 구현한다.
+type TaskResultDataExporter interface {
+	ToCSV() ([]byte, error)
+}
+
+// TaskExecutionHistory 작업 실행 이력
+type TaskExecutionHistory struct {
+	ID            int64
+	TaskID        TaskID
+	CommandID     TaskCommandID
+	InstanceID    TaskInstanceID
+	RunBy         TaskRunBy
+	StartedAt     time.Time
+	FinishedAt    time.Time
+	DurationMs    int64
+	Success       bool
+	MessageLength int
+	Error         string
+}
+
+// TaskExecutionHistoryStore 작업 실행 이력을 저장하고 조회한다.
+// 작업 실행 이력의 저장을 지원하는 TaskResultStore가 구현한다.
+type TaskExecutionHistoryStore interface {
+	SaveExecutionHistory(history *TaskExecutionHistory) error
+
+	// ExecutionHistories 최근 실행된 순서대로 최대 limit개의 작업 실행 이력을 반환한다.
+	ExecutionHistories(taskID TaskID, limit int) ([]*TaskExecutionHistory, error)
+}
+
+func newTaskResultStore(config *g.AppConfig) (TaskResultStore, error) {
+	switch config.Storage.Type {
+	case "", TaskResultStoreTypeFile:
+		s := &fileTaskResultStore{
+			compressionEnabled:        config.Storage.Compression.Enabled,
+			compressionThresholdBytes: config.Storage.Compression.ThresholdBytes,
+		}
+		if s.compressionThresholdBytes == 0 {
+			s.compressionThresholdBytes = defaultTaskResultCompressionThresholdBytes
+		}
+		return s, nil
+
+	case TaskResultStoreTypeSQLite:
+		return newSQLiteTaskResultStore(config.Storage.SQLite.Path)
+
+	default:
+		return nil, fmt.Errorf("지원하지 않는 작업결과데이터 저장소 타입(%s)입니다", config.Storage.Type)
+	}
+}
+
+// fileTaskResultStore 작업결과데이터를 작업별 JSON 파일로 저장한다.
+// 압축이 활성화된 경우 크기가 compressionThresholdBytes 이상인 작업결과데이터는 gzip으로 압축하여 '.json.gz' 파일로 저장한다.
+type fileTaskResultStore struct {
+	compressionEnabled        bool
+	compressionThresholdBytes int
+}
+
+func (s *fileTaskResultStore) fileName(taskID TaskID, taskCommandID TaskCommandID) string {
+	filename := fmt.Sprintf("%s-task-%s-%s.json", g.AppName, utils.ToSnakeCase(string(taskID)), utils.ToSnakeCase(string(taskCommandID)))
+	return strings.ReplaceAll(filename, "_", "-")
+}
+
+func (s *fileTaskResultStore) compressedFileName(taskID TaskID, taskCommandID TaskCommandID) string {
+	return s.fileName(taskID, taskCommandID) + ".gz"
+}
+
+func (s *fileTaskResultStore) Load(taskID TaskID, taskCommandID TaskCommandID, v interface{}) error {
+	// 압축 설정이 변경되었을 수 있으므로 압축된 파일과 압축되지 않은 파일을 모두 확인한다.
+	data, err := os.ReadFile(s.compressedFileName(taskID, taskCommandID))
+	if err != nil && errors.Is(err, os.ErrNotExist) == true {
+		data, err = os.ReadFile(s.fileName(taskID, taskCommandID))
+	}
+	if err != nil {
+		// 아직 데이터 파일이 생성되기 전이라면 nil을 반환한다.
+		var pathError *os.PathError
+		if errors.As(err, &pathError) == true {
+			return nil
+		}
+
+		return err
+	}
+
+	// gzip 헤더로 시작되는 데이터는 압축을 해제한다.
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		if data, err = io.ReadAll(r); err != nil {
+			return err
+		}
+	}
+
+	return unmarshalSnapshot(data, v)
+}
+
+func (s *fileTaskResultStore) Save(taskID TaskID, taskCommandID TaskCommandID, v interface{}) error {
+	data, err := marshalSnapshot(v)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err = json.Indent(&buf, data, "", "\t"); err != nil {
+		return err
+	}
+	data = buf.Bytes()
+
+	fileName := s.fileName(taskID, taskCommandID)
+	compressedFileName := s.compressedFileName(taskID, taskCommandID)
+
+	if s.compressionEnabled == false || len(data) < s.compressionThresholdBytes {
+		if err = os.WriteFile(fileName, data, os.FileMode(0644)); err != nil {
+			return err
+		}
+
+		// 이전에 압축하여 저장된 파일이 다시 읽혀지지 않도록 삭제한다.
+		return removeFileIfExists(compressedFileName)
+	}
+
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	if _, err = w.Write(data); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(compressedFileName, compressed.Bytes(), os.FileMode(0644)); err != nil {
+		return err
+	}
+
+	return removeFileIfExists(fileName)
+}
+
+func (s *fileTaskResultStore) Delete(taskID TaskID, taskCommandID TaskCommandID) error {
+	if err := removeFileIfExists(s.compressedFileName(taskID, taskCommandID)); err != nil {
+		return err
+	}
+
+	return removeFileIfExists(s.fileName(taskID, taskCommandID))
+}
+
+func removeFileIfExists(name string) error {
+	err := os.Remove(name)
+	if err != nil && errors.Is(err, os.ErrNotExist) == true {
+		return nil
+	}
+
+	return err
+}
+
+func (s *fileTaskResultStore) Close() error {
+	return nil
+}
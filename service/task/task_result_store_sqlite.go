@@ -0,0 +1,140 @@
+package task
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/darkkaiser/notify-server/g"
+	_ "modernc.org/sqlite"
+	"time"
+)
+
+// sqliteTaskResultStore 작업결과데이터를 SQLite 데이터베이스의 snapshots 테이블에 저장한다.
+// 작업결과데이터 외에 작업 실행 이력(task_execution_history 테이블)도 함께 저장한다.
+// SQLite 드라이버는 cgo를 사용하지 않는 modernc.org/sqlite를 사용하므로 CGO_ENABLED=0으로 빌드할 수 있다.
+type sqliteTaskResultStore struct {
+	db *sql.DB
+}
+
+func newSQLiteTaskResultStore(path string) (*sqliteTaskResultStore, error) {
+	if path == "" {
+		path = fmt.Sprintf("%s.db", g.AppName)
+	}
+
+	// 여러 작업이 동시에 읽고 쓸 수 있도록 WAL 모드를 사용한다.
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)", path))
+	if err != nil {
+		return nil, fmt.Errorf("작업결과데이터 데이터베이스(%s)를 열 수 없습니다.(error:%s)", path, err)
+	}
+
+	if _, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS snapshots (
+			task_id    TEXT NOT NULL,
+			command_id TEXT NOT NULL,
+			updated_at DATETIME NOT NULL,
+			payload    TEXT NOT NULL,
+			PRIMARY KEY (task_id, command_id)
+		)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("작업결과데이터 데이터베이스(%s)의 테이블 생성이 실패하였습니다.(error:%s)", path, err)
+	}
+
+	if _, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS task_execution_history (
+			id             INTEGER PRIMARY KEY AUTOINCREMENT,
+			task_id        TEXT NOT NULL,
+			command_id     TEXT NOT NULL,
+			instance_id    TEXT NOT NULL,
+			run_by         INTEGER NOT NULL,
+			started_at     DATETIME NOT NULL,
+			finished_at    DATETIME NOT NULL,
+			duration_ms    INTEGER NOT NULL,
+			success        BOOLEAN NOT NULL,
+			message_length INTEGER NOT NULL,
+			error          TEXT NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_task_execution_history_task_id ON task_execution_history (task_id, id)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("작업결과데이터 데이터베이스(%s)의 테이블 생성이 실패하였습니다.(error:%s)", path, err)
+	}
+
+	return &sqliteTaskResultStore{db: db}, nil
+}
+
+func (s *sqliteTaskResultStore) Load(taskID TaskID, taskCommandID TaskCommandID, v interface{}) error {
+	var payload string
+	err := s.db.QueryRow("SELECT payload FROM snapshots WHERE task_id = ? AND command_id = ?", string(taskID), string(taskCommandID)).Scan(&payload)
+	if err != nil {
+		// 아직 저장된 데이터가 없다면 nil을 반환한다.
+		if errors.Is(err, sql.ErrNoRows) == true {
+			return nil
+		}
+
+		return err
+	}
+
+	return unmarshalSnapshot([]byte(payload), v)
+}
+
+func (s *sqliteTaskResultStore) Save(taskID TaskID, taskCommandID TaskCommandID, v interface{}) error {
+	data, err := marshalSnapshot(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO snapshots (task_id, command_id, updated_at, payload) VALUES (?, ?, ?, ?)
+		ON CONFLICT (task_id, command_id) DO UPDATE SET updated_at = excluded.updated_at, payload = excluded.payload`,
+		string(taskID), string(taskCommandID), time.Now(), string(data))
+
+	return err
+}
+
+func (s *sqliteTaskResultStore) Delete(taskID TaskID, taskCommandID TaskCommandID) error {
+	_, err := s.db.Exec("DELETE FROM snapshots WHERE task_id = ? AND command_id = ?", string(taskID), string(taskCommandID))
+
+	return err
+}
+
+func (s *sqliteTaskResultStore) SaveExecutionHistory(history *TaskExecutionHistory) error {
+	result, err := s.db.Exec(`
+		INSERT INTO task_execution_history (task_id, command_id, instance_id, run_by, started_at, finished_at, duration_ms, success, message_length, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		string(history.TaskID), string(history.CommandID), string(history.InstanceID), int(history.RunBy), history.StartedAt, history.FinishedAt, history.DurationMs, history.Success, history.MessageLength, history.Error)
+	if err != nil {
+		return err
+	}
+
+	history.ID, err = result.LastInsertId()
+
+	return err
+}
+
+// noinspection GoUnhandledErrorResult
+func (s *sqliteTaskResultStore) ExecutionHistories(taskID TaskID, limit int) ([]*TaskExecutionHistory, error) {
+	rows, err := s.db.Query(`
+		SELECT id, task_id, command_id, instance_id, run_by, started_at, finished_at, duration_ms, success, message_length, error
+		FROM task_execution_history WHERE task_id = ? ORDER BY id DESC LIMIT ?`, string(taskID), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	histories := make([]*TaskExecutionHistory, 0, limit)
+	for rows.Next() {
+		var history TaskExecutionHistory
+		var runBy int
+		if err = rows.Scan(&history.ID, &history.TaskID, &history.CommandID, &history.InstanceID, &runBy, &history.StartedAt, &history.FinishedAt, &history.DurationMs, &history.Success, &history.MessageLength, &history.Error); err != nil {
+			return nil, err
+		}
+		history.RunBy = TaskRunBy(runBy)
+
+		histories = append(histories, &history)
+	}
+
+	return histories, rows.Err()
+}
+
+func (s *sqliteTaskResultStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,106 @@
+package task
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestSQLiteTaskResultStore(t *testing.T) *sqliteTaskResultStore {
+	store, err := newSQLiteTaskResultStore(filepath.Join(t.TempDir(), "task.db"))
+	assert.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	return store
+}
+
+func TestSQLiteTaskResultStore_SaveLoadDelete(t *testing.T) {
+	store := newTestSQLiteTaskResultStore(t)
+
+	// 저장된 데이터가 없는 경우에는 오류 없이 v를 변경하지 않는다.
+	v := &snapshotTestData{}
+	assert.NoError(t, store.Load("NAVER", "WatchNewPerformances", v))
+	assert.Equal(t, &snapshotTestData{}, v)
+
+	assert.NoError(t, store.Save("NAVER", "WatchNewPerformances", &snapshotTestData{Name: "name", Items: []string{"a", "b"}}))
+	assert.NoError(t, store.Save("NAVER", "WatchNewPerformances", &snapshotTestData{Name: "name2", Items: []string{"c"}}))
+	assert.NoError(t, store.Save("KURLY", "WatchProductPrice", &snapshotTestData{Name: "kurly"}))
+
+	// 같은 작업의 데이터는 마지막에 저장된 데이터로 덮어쓴다.
+	v = &snapshotTestData{}
+	assert.NoError(t, store.Load("NAVER", "WatchNewPerformances", v))
+	assert.Equal(t, &snapshotTestData{Name: "name2", Items: []string{"c"}}, v)
+
+	// 삭제된 데이터는 저장된 데이터가 없는 것과 같고, 다른 작업의 데이터는 삭제되지 않는다.
+	assert.NoError(t, store.Delete("NAVER", "WatchNewPerformances"))
+	assert.NoError(t, store.Delete("NAVER", "WatchNewPerformances"))
+	v = &snapshotTestData{}
+	assert.NoError(t, store.Load("NAVER", "WatchNewPerformances", v))
+	assert.Equal(t, &snapshotTestData{}, v)
+
+	v = &snapshotTestData{}
+	assert.NoError(t, store.Load("KURLY", "WatchProductPrice", v))
+	assert.Equal(t, "kurly", v.Name)
+}
+
+func TestSQLiteTaskResultStore_SaveExecutionHistory(t *testing.T) {
+	store := newTestSQLiteTaskResultStore(t)
+
+	startedAt := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+	history := &TaskExecutionHistory{
+		TaskID:        "NAVER",
+		CommandID:     "WatchNewPerformances",
+		InstanceID:    "instance-1",
+		RunBy:         TaskRunByScheduler,
+		StartedAt:     startedAt,
+		FinishedAt:    startedAt.Add(1500 * time.Millisecond),
+		DurationMs:    1500,
+		Success:       false,
+		MessageLength: 10,
+		Error:         "error",
+	}
+	assert.NoError(t, store.SaveExecutionHistory(history))
+	assert.NotEqual(t, int64(0), history.ID)
+
+	histories, err := store.ExecutionHistories("NAVER", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(histories))
+	assert.Equal(t, history.ID, histories[0].ID)
+	assert.Equal(t, TaskCommandID("WatchNewPerformances"), histories[0].CommandID)
+	assert.Equal(t, TaskInstanceID("instance-1"), histories[0].InstanceID)
+	assert.Equal(t, TaskRunByScheduler, histories[0].RunBy)
+	assert.True(t, startedAt.Equal(histories[0].StartedAt))
+	assert.True(t, history.FinishedAt.Equal(histories[0].FinishedAt))
+	assert.Equal(t, int64(1500), histories[0].DurationMs)
+	assert.False(t, histories[0].Success)
+	assert.Equal(t, 10, histories[0].MessageLength)
+	assert.Equal(t, "error", histories[0].Error)
+}
+
+func TestSQLiteTaskResultStore_ExecutionHistories_OrderAndLimit(t *testing.T) {
+	store := newTestSQLiteTaskResultStore(t)
+
+	now := time.Now()
+	for i := 1; i <= 5; i++ {
+		assert.NoError(t, store.SaveExecutionHistory(&TaskExecutionHistory{TaskID: "NAVER", CommandID: "WatchNewPerformances", InstanceID: TaskInstanceID(fmt.Sprintf("instance-%d", i)), StartedAt: now, FinishedAt: now, Success: true}))
+	}
+	assert.NoError(t, store.SaveExecutionHistory(&TaskExecutionHistory{TaskID: "KURLY", CommandID: "WatchProductPrice", InstanceID: "instance-6", StartedAt: now, FinishedAt: now, Success: true}))
+
+	// 최근 실행된 순서대로 최대 limit개를 반환하고, 다른 작업의 실행 이력은 반환하지 않는다.
+	histories, err := store.ExecutionHistories("NAVER", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(histories))
+	assert.Equal(t, TaskInstanceID("instance-5"), histories[0].InstanceID)
+	assert.Equal(t, TaskInstanceID("instance-4"), histories[1].InstanceID)
+	assert.Equal(t, TaskInstanceID("instance-3"), histories[2].InstanceID)
+
+	histories, err = store.ExecutionHistories("NAVER", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(histories))
+
+	histories, err = store.ExecutionHistories("UNKNOWN", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(histories))
+}
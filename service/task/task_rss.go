@@ -0,0 +1,273 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"github.com/darkkaiser/notify-server/g"
+	"github.com/darkkaiser/notify-server/service/task/mark"
+	"github.com/darkkaiser/notify-server/utils"
+	"github.com/mmcdole/gofeed"
+	log "github.com/sirupsen/logrus"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	rssWatchFeedTaskCommandIDPrefix string = "WatchFeed_"
+
+	// TaskID
+	TidRSS TaskID = "RSS" // RSS/Atom 피드
+
+	// TaskCommandID
+	TcidRSSWatchFeedAny = TaskCommandID(rssWatchFeedTaskCommandIDPrefix + taskCommandIDAnyString) // RSS/Atom 피드 신규 글 확인
+)
+
+type rssWatchFeedTaskCommandData struct {
+	FeedURL string `json:"feed_url"`
+	Filters struct {
+		IncludedKeywords string `json:"included_keywords"`
+		ExcludedKeywords string `json:"excluded_keywords"`
+		MaxItems         int    `json:"max_items"`
+	} `json:"filters"`
+}
+
+func (d *rssWatchFeedTaskCommandData) ApplyDefaults() {
+	if d.Filters.MaxItems == 0 {
+		d.Filters.MaxItems = 30
+	}
+}
+
+func (d *rssWatchFeedTaskCommandData) Validate() error {
+	if d.FeedURL == "" {
+		return errors.New("feed_url이 입력되지 않았습니다")
+	}
+	if u, err := url.Parse(d.FeedURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("feed_url(%s)이 유효하지 않습니다", d.FeedURL)
+	}
+	if d.Filters.MaxItems < 1 || d.Filters.MaxItems > 100 {
+		return errors.New("max_items에 1~100 범위를 벗어난 값이 입력되었습니다")
+	}
+	return nil
+}
+
+type rssFeedItem struct {
+	GUID      string `json:"guid"`
+	Title     string `json:"title"`
+	Link      string `json:"link"`
+	Published string `json:"published"`
+}
+
+func (i *rssFeedItem) String(messageTypeHTML bool, mark string) string {
+	if messageTypeHTML == true {
+		return fmt.Sprintf("☞ <a href=\"%s\"><b>%s</b></a>%s\n      • 작성일 : %s", i.Link, template.HTMLEscapeString(i.Title), mark, i.Published)
+	}
+	return strings.TrimSpace(fmt.Sprintf("☞ %s%s\n      • 작성일 : %s\n%s", i.Title, mark, i.Published, i.Link))
+}
+
+type rssWatchFeedResultData struct {
+	Items []*rssFeedItem `json:"items"`
+}
+
+func init() {
+	supportedTasks[TidRSS] = &supportedTaskConfig{
+		commandConfigs: []*supportedTaskCommandConfig{{
+			taskCommandID: TcidRSSWatchFeedAny,
+
+			allowMultipleInstances: true,
+
+			newTaskResultDataFn: func() interface{} { return &rssWatchFeedResultData{} },
+		}},
+
+		newTaskFn: func(instanceID TaskInstanceID, taskRunData *taskRunData, config *g.AppConfig) (taskHandler, error) {
+			if taskRunData.taskID != TidRSS {
+				return nil, errors.New("등록되지 않은 작업입니다.😱")
+			}
+
+			task := &rssTask{
+				task: task{
+					id:         taskRunData.taskID,
+					commandID:  taskRunData.taskCommandID,
+					instanceID: instanceID,
+
+					notifierID: taskRunData.notifierID,
+
+					canceled: false,
+
+					runBy: taskRunData.taskRunBy,
+				},
+
+				config: config,
+			}
+
+			task.runFn = func(taskResultData interface{}, messageTypeHTML bool) (string, interface{}, error) {
+				// 'WatchFeed_'로 시작되는 명령인지 확인한다.
+				if strings.HasPrefix(string(task.CommandID()), rssWatchFeedTaskCommandIDPrefix) == true {
+					for _, t := range task.config.Tasks {
+						if task.ID() == TaskID(t.ID) {
+							for _, c := range t.Commands {
+								if task.CommandID() == TaskCommandID(c.ID) {
+									taskCommandData := &rssWatchFeedTaskCommandData{}
+									if err := fillTaskCommandDataFromMap(taskCommandData, c.Data); err != nil {
+										return "", nil, errors.New(fmt.Sprintf("작업 커맨드 데이터가 유효하지 않습니다.(error:%s)", err))
+									}
+
+									return task.runWatchFeed(taskCommandData, taskResultData, messageTypeHTML)
+								}
+							}
+							break
+						}
+					}
+				}
+
+				return "", nil, ErrNoImplementationForTaskCommand
+			}
+
+			return task, nil
+		},
+	}
+}
+
+type rssTask struct {
+	task
+
+	config *g.AppConfig
+}
+
+// noinspection GoUnhandledErrorResult
+func (t *rssTask) fetchFeed(feedURL string) (*gofeed.Feed, error) {
+	req, err := http.NewRequest("GET", feedURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("피드(%s) 접근이 실패하였습니다.(error:%s)", feedURL, err)
+	}
+
+	resp, err := t.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("피드(%s) 접근이 실패하였습니다.(error:%s)", feedURL, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("피드(%s) 접근이 실패하였습니다.(%s)", feedURL, resp.Status)
+	}
+	defer resp.Body.Close()
+
+	// RSS 2.0, Atom 형식을 자동으로 판별하여 파싱한다.
+	feed, err := gofeed.NewParser().Parse(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("불러온 피드(%s)의 데이터 파싱이 실패하였습니다.(error:%s)", feedURL, err)
+	}
+
+	return feed, nil
+}
+
+func (t *rssTask) runWatchFeed(taskCommandData *rssWatchFeedTaskCommandData, taskResultData interface{}, messageTypeHTML bool) (message string, changedTaskResultData interface{}, err error) {
+	originTaskResultData, ok := taskResultData.(*rssWatchFeedResultData)
+	if ok == false {
+		log.Panic("TaskResultData의 타입 변환이 실패하였습니다.")
+	}
+
+	feed, err := t.fetchFeed(taskCommandData.FeedURL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	//
+	// 피드의 글을 설정된 조건에 맞게 필터링한다.
+	//
+	actualityTaskResultData := &rssWatchFeedResultData{}
+	keywordMatcher, err := utils.NewKeywordMatcherWithValidation(utils.SplitExceptEmptyItems(taskCommandData.Filters.IncludedKeywords, ","), utils.SplitExceptEmptyItems(taskCommandData.Filters.ExcludedKeywords, ","))
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, item := range feed.Items {
+		if len(actualityTaskResultData.Items) >= taskCommandData.Filters.MaxItems {
+			break
+		}
+
+		title := utils.Trim(item.Title)
+		if keywordMatcher.Match(title) == false {
+			continue
+		}
+
+		// GUID가 없는 피드는 링크로 글을 구분한다.
+		guid := item.GUID
+		if guid == "" {
+			guid = item.Link
+		}
+		if guid == "" {
+			continue
+		}
+
+		var published string
+		if item.PublishedParsed != nil {
+			published = item.PublishedParsed.Local().Format("2006-01-02 15:04")
+		} else if item.UpdatedParsed != nil {
+			published = item.UpdatedParsed.Local().Format("2006-01-02 15:04")
+		} else {
+			published = item.Published
+		}
+
+		actualityTaskResultData.Items = append(actualityTaskResultData.Items, &rssFeedItem{
+			GUID:      guid,
+			Title:     title,
+			Link:      item.Link,
+			Published: published,
+		})
+	}
+
+	//
+	// 새로 등록된 글을 확인한다.
+	//
+	m := ""
+	lineSpacing := "\n\n"
+	err = eachSourceElementIsInTargetElementOrNot(actualityTaskResultData.Items, originTaskResultData.Items, func(selem, telem interface{}) (bool, error) {
+		actualityItem, ok1 := selem.(*rssFeedItem)
+		originItem, ok2 := telem.(*rssFeedItem)
+		if ok1 == false || ok2 == false {
+			return false, errors.New("selem/telem의 타입 변환이 실패하였습니다")
+		} else {
+			if actualityItem.GUID == originItem.GUID {
+				return true, nil
+			}
+		}
+		return false, nil
+	}, nil, func(selem interface{}) {
+		actualityItem := selem.(*rssFeedItem)
+
+		if m != "" {
+			m += lineSpacing
+		}
+		m += actualityItem.String(messageTypeHTML, mark.New)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	feedTitle := utils.Trim(feed.Title)
+	if feedTitle == "" {
+		feedTitle = taskCommandData.FeedURL
+	}
+
+	if m != "" {
+		message = fmt.Sprintf("'%s' 피드에 새로운 글이 등록되었습니다.\n\n%s", feedTitle, m)
+		changedTaskResultData = actualityTaskResultData
+	} else {
+		if t.runBy.IsOnDemand() == true {
+			if len(actualityTaskResultData.Items) == 0 {
+				message = fmt.Sprintf("'%s' 피드에 조회 조건에 해당되는 글이 존재하지 않습니다.", feedTitle)
+			} else {
+				for _, actualityItem := range actualityTaskResultData.Items {
+					if m != "" {
+						m += lineSpacing
+					}
+					m += actualityItem.String(messageTypeHTML, "")
+				}
+
+				message = fmt.Sprintf("'%s' 피드에 새로 등록된 글이 없습니다.\n\n최근에 등록된 글은 아래와 같습니다:\n\n%s", feedTitle, m)
+			}
+		}
+	}
+
+	return message, changedTaskResultData, nil
+}
@@ -0,0 +1,246 @@
+package task
+
+import (
+	"context"
+	"github.com/darkkaiser/notify-server/g"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testTaskID        TaskID        = "TEST"
+	testTaskCommandID TaskCommandID = "Run"
+)
+
+// testTaskNotificationSender 발송 요청된 알림메시지를 기록하는 테스트용 TaskNotificationSender
+type testTaskNotificationSender struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (s *testTaskNotificationSender) NotifyToDefault(message string) bool {
+	return s.NotifyWithTaskContext("", message, nil)
+}
+
+func (s *testTaskNotificationSender) NotifyWithTaskContext(_ string, message string, _ TaskContext) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, message)
+
+	return true
+}
+
+func (s *testTaskNotificationSender) NotifyBatch(notifications []Notification) error {
+	for _, n := range notifications {
+		s.NotifyWithTaskContext(n.NotifierID, n.Message, n.TaskCtx)
+	}
+	return nil
+}
+
+func (s *testTaskNotificationSender) SupportHTMLMessage(_ string) bool {
+	return false
+}
+
+// registerTestTask releaseC가 닫힐 때까지 실행되는 테스트용 작업을 등록한다.
+func registerTestTask(t *testing.T, allowMultipleInstances bool) (releaseC chan struct{}) {
+	releaseC = make(chan struct{})
+
+	supportedTasks[testTaskID] = &supportedTaskConfig{
+		commandConfigs: []*supportedTaskCommandConfig{{
+			taskCommandID: testTaskCommandID,
+
+			allowMultipleInstances: allowMultipleInstances,
+
+			newTaskResultDataFn: func() interface{} { return &struct{}{} },
+		}},
+
+		newTaskFn: func(instanceID TaskInstanceID, taskRunData *taskRunData, _ *g.AppConfig) (taskHandler, error) {
+			return &task{
+				id:         taskRunData.taskID,
+				commandID:  taskRunData.taskCommandID,
+				instanceID: instanceID,
+
+				notifierID: taskRunData.notifierID,
+
+				runBy: taskRunData.taskRunBy,
+
+				runFn: func(_ interface{}, _ bool) (string, interface{}, error) {
+					<-releaseC
+					return "", nil, nil
+				},
+			}, nil
+		},
+	}
+	t.Cleanup(func() { delete(supportedTasks, testTaskID) })
+
+	return releaseC
+}
+
+// startTestTaskService 작업을 동시에 maxConcurrentTasks개까지 실행하는 Task 서비스의 run0 고루틴을 시작한다.
+// 테스트가 끝나면 releaseC를 닫아 실행중인 작업을 끝낸 후에 서비스를 중지한다.
+func startTestTaskService(t *testing.T, maxConcurrentTasks int, releaseC chan struct{}) (*TaskService, *testTaskNotificationSender) {
+	config := &g.AppConfig{}
+	config.TaskService.MaxConcurrentTasks = maxConcurrentTasks
+	config.TaskService.QueueSize = 10
+
+	sender := &testTaskNotificationSender{}
+	s := &TaskService{
+		config: config,
+
+		taskHandlers:    make(map[TaskInstanceID]taskHandler),
+		runningTaskKeys: make(map[string]TaskInstanceID),
+
+		idGenerator: NewSequentialIDGenerator(""),
+
+		taskNotificationSender: sender,
+
+		taskResultStore: &fileTaskResultStore{},
+
+		metricsCollector: NoopMetricsCollector{},
+
+		taskSemaphore: make(chan struct{}, maxConcurrentTasks),
+
+		taskRunC:      make(chan *taskRunData, 10),
+		taskDoneC:     make(chan TaskInstanceID, 10),
+		taskCancelC:   make(chan TaskInstanceID, 10),
+		taskQueryC:    make(chan chan int),
+		configReloadC: make(chan struct{}, 1),
+
+		taskStopWaiter: &sync.WaitGroup{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go s.run0(ctx, wg)
+
+	t.Cleanup(func() {
+		close(releaseC)
+		cancel()
+		wg.Wait()
+	})
+
+	return s, sender
+}
+
+// waitTaskInstanceStatus 작업 인스턴스의 상태가 status가 될 때까지 대기한다.
+func waitTaskInstanceStatus(t *testing.T, s *TaskService, instanceID TaskInstanceID, status TaskInstanceStatus) {
+	assert.Eventually(t, func() bool {
+		return s.TaskInstanceStatus(instanceID) == status
+	}, 5*time.Second, 10*time.Millisecond, "TaskInstanceID:%s", instanceID)
+}
+
+func TestTaskService_DeleteRunningTaskKey(t *testing.T) {
+	s := &TaskService{runningTaskKeys: make(map[string]TaskInstanceID)}
+	h1 := &task{id: "NAVER", commandID: "WatchNewPerformances", instanceID: "1"}
+	h2 := &task{id: "NAVER", commandID: "WatchNewPerformances", instanceID: "2"}
+
+	key := runningTaskKey(h1.id, h1.commandID)
+	assert.Equal(t, "NAVER|WatchNewPerformances", key)
+
+	// 다른 인스턴스의 작업이 완료된 경우에는 삭제되지 않는다.
+	s.runningTaskKeys[key] = h1.instanceID
+	s.deleteRunningTaskKey(h2)
+	assert.Equal(t, h1.instanceID, s.runningTaskKeys[key])
+
+	s.deleteRunningTaskKey(h1)
+	_, exists := s.runningTaskKeys[key]
+	assert.False(t, exists)
+}
+
+func TestTaskService_TaskResultDataDelete(t *testing.T) {
+	s := &TaskService{
+		taskHandlers:    make(map[TaskInstanceID]taskHandler),
+		taskResultStore: &fileTaskResultStore{},
+	}
+
+	// 실행중인 작업이 있는 경우에는 삭제되지 않는다.
+	h := &task{id: TidNaver, commandID: TcidNaverWatchNewPerformances, instanceID: "1"}
+	s.taskHandlers[h.instanceID] = h
+	assert.ErrorIs(t, s.TaskResultDataDelete(TidNaver, TcidNaverWatchNewPerformances), ErrTaskAlreadyRunning)
+
+	// 취소된 작업은 작업결과데이터를 저장하지 않으므로 삭제할 수 있다.
+	h.Cancel()
+	assert.NoError(t, s.TaskResultDataDelete(TidNaver, TcidNaverWatchNewPerformances))
+
+	delete(s.taskHandlers, h.instanceID)
+	assert.NoError(t, s.TaskResultDataDelete(TidNaver, TcidNaverWatchNewPerformances))
+}
+
+func TestTaskService_CancelQueuedTask(t *testing.T) {
+	releaseC := registerTestTask(t, true)
+	s, _ := startTestTaskService(t, 1, releaseC)
+
+	// 첫 번째 작업이 실행되는 동안 두 번째 작업은 작업 대기열에서 대기한다.
+	assert.True(t, s.TaskRun(testTaskID, testTaskCommandID, "", false, TaskRunByUser))
+	assert.True(t, s.TaskRun(testTaskID, testTaskCommandID, "", false, TaskRunByUser))
+	waitTaskInstanceStatus(t, s, "1", TaskInstanceStatusRunning)
+	waitTaskInstanceStatus(t, s, "2", TaskInstanceStatusRunning)
+
+	// 작업 대기열에서 취소된 작업은 완료된 작업으로 조회된다.
+	assert.True(t, s.TaskCancel("2"))
+	assert.Eventually(t, func() bool {
+		s.runningMu.Lock()
+		defer s.runningMu.Unlock()
+
+		_, exists := s.taskHandlers["2"]
+		return exists == false
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, TaskInstanceStatusCompleted, s.TaskInstanceStatus("2"))
+	assert.Equal(t, TaskInstanceStatusRunning, s.TaskInstanceStatus("1"))
+}
+
+func TestTaskService_DropAbandonedQueuedTask(t *testing.T) {
+	releaseC := registerTestTask(t, true)
+	s, sender := startTestTaskService(t, 1, releaseC)
+	s.config.TaskService.QueueSize = 1
+
+	assert.True(t, s.TaskRun(testTaskID, testTaskCommandID, "", false, TaskRunByUser))
+	waitTaskInstanceStatus(t, s, "1", TaskInstanceStatusRunning)
+
+	// 작업 대기열에서 대기하는 동안 ctx가 취소되면 작업 실행 결과를 기다리지 않는다.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := s.TaskRunOnce(ctx, testTaskID, testTaskCommandID, "")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// ctx가 취소된 작업은 작업 대기열을 차지하지 않고, 실행되지 않은 채로 삭제된다.
+	assert.True(t, s.TaskRun(testTaskID, testTaskCommandID, "", false, TaskRunByUser))
+	waitTaskInstanceStatus(t, s, "3", TaskInstanceStatusRunning)
+	assert.Equal(t, TaskInstanceStatusCompleted, s.TaskInstanceStatus("2"))
+	sender.mu.Lock()
+	assert.Equal(t, 0, len(sender.messages))
+	sender.mu.Unlock()
+
+	s.runningMu.Lock()
+	_, exists := s.taskHandlers["2"]
+	s.runningMu.Unlock()
+	assert.False(t, exists)
+}
+
+func TestTaskService_RejectDuplicateTask(t *testing.T) {
+	releaseC := registerTestTask(t, false)
+	s, sender := startTestTaskService(t, 2, releaseC)
+
+	assert.True(t, s.TaskRun(testTaskID, testTaskCommandID, "", false, TaskRunByUser))
+	waitTaskInstanceStatus(t, s, "1", TaskInstanceStatusRunning)
+
+	// 이미 실행중인 작업은 다시 실행할 수 없다.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := s.TaskRunOnce(ctx, testTaskID, testTaskCommandID, "")
+	assert.ErrorIs(t, err, ErrTaskAlreadyRunning)
+	sender.mu.Lock()
+	assert.Contains(t, sender.messages, "요청하신 작업은 이미 진행중입니다.\n이전 작업을 취소하시려면 아래 명령어를 클릭하여 주세요.")
+	sender.mu.Unlock()
+
+	// 취소된 작업이 아직 완료되지 않았더라도 같은 작업을 다시 실행할 수 있다.
+	assert.True(t, s.TaskCancel("1"))
+	waitTaskInstanceStatus(t, s, "1", TaskInstanceStatusCompleted)
+
+	assert.True(t, s.TaskRun(testTaskID, testTaskCommandID, "", false, TaskRunByUser))
+	waitTaskInstanceStatus(t, s, "2", TaskInstanceStatusRunning)
+}
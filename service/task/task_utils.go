@@ -3,9 +3,7 @@ package task
 import (
 	"encoding/json"
 	"errors"
-	"github.com/darkkaiser/notify-server/utils"
 	"reflect"
-	"strings"
 )
 
 type equalFunc func(selem, telem interface{}) (bool, error)
@@ -63,6 +61,16 @@ func eachSourceElementIsInTargetElementOrNot(source, target interface{}, equalFn
 	return nil
 }
 
+// taskDataValidator 작업 데이터 및 작업 커맨드 데이터가 구현하는 인터페이스
+// fillTaskDataFromMap(), fillTaskCommandDataFromMap()에서 데이터를 채운 후 ApplyDefaults(), Validate() 순서로 호출된다.
+type taskDataValidator interface {
+	// ApplyDefaults 입력되지 않은 항목에 기본값을 설정한다.
+	ApplyDefaults()
+
+	// Validate 입력된 항목의 유효성을 검사한다.
+	Validate() error
+}
+
 func fillTaskDataFromMap(d interface{}, m map[string]interface{}) error {
 	return fillTaskCommandDataFromMap(d, m)
 }
@@ -75,35 +83,14 @@ func fillTaskCommandDataFromMap(d interface{}, m map[string]interface{}) error {
 	if err := json.Unmarshal(data, d); err != nil {
 		return err
 	}
-	return nil
-}
 
-func filter(s string, includedKeywords, excludedKeywords []string) bool {
-	for _, k := range includedKeywords {
-		includedOneOfManyKeywords := utils.SplitExceptEmptyItems(k, "|")
-		if len(includedOneOfManyKeywords) == 1 {
-			if strings.Contains(s, k) == false {
-				return false
-			}
-		} else {
-			var contains = false
-			for _, keyword := range includedOneOfManyKeywords {
-				if strings.Contains(s, keyword) == true {
-					contains = true
-					break
-				}
-			}
-			if contains == false {
-				return false
-			}
-		}
-	}
+	if v, ok := d.(taskDataValidator); ok == true {
+		v.ApplyDefaults()
 
-	for _, k := range excludedKeywords {
-		if strings.Contains(s, k) == true {
-			return false
+		if err := v.Validate(); err != nil {
+			return err
 		}
 	}
 
-	return true
+	return nil
 }
@@ -1,48 +1,55 @@
 package task
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"github.com/PuerkitoBio/goquery"
-	"io"
+	"github.com/darkkaiser/notify-server/apperrors"
 	"net/http"
 )
 
-// noinspection GoUnhandledErrorResult
-func newHTMLDocument(url string) (*goquery.Document, error) {
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("페이지(%s) 접근이 실패하였습니다.(error:%s)", url, err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("페이지(%s) 접근이 실패하였습니다.(%s)", url, resp.Status)
-	}
-	defer resp.Body.Close()
+func (t *task) newHTMLDocument(url string) (*goquery.Document, error) {
+	return t.newHTMLDocumentWithHeader(url, nil)
+}
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("불러온 페이지(%s)의 데이터 파싱이 실패하였습니다.(error:%s)", url, err)
-	}
+func (t *task) newHTMLDocumentWithHeader(url string, header map[string]string) (*goquery.Document, error) {
+	return t.scraper().FetchHTMLDocument(t.context(), url, header)
+}
+
+// scraper 작업에 설정된 HTTP 헤더, 쿠키 및 프록시 서버가 적용된 요청으로 페이지를 읽어들이는 Scraper를 반환한다.
+func (t *task) scraper() Scraper {
+	return t.newScraper(fetcherFunc(t.do))
+}
 
-	return doc, nil
+// newScraper f로 요청을 보내는 Scraper를 반환한다. f는 작업에 설정된 HTTP 헤더를 요청에 추가하여야 한다.
+func (t *task) newScraper(f Fetcher) Scraper {
+	return &scraper{fetcher: f, taskHeader: t.headers}
+}
+
+// context 작업 실행 컨텍스트를 반환한다. 작업이 실행중이 아닌 경우 context.Background()를 반환한다.
+func (t *task) context() context.Context {
+	if t.runCtx != nil {
+		return t.runCtx
+	}
+	return context.Background()
 }
 
-func newHTMLDocumentSelection(url string, selector string) (*goquery.Selection, error) {
-	doc, err := newHTMLDocument(url)
+func (t *task) newHTMLDocumentSelection(url string, selector string) (*goquery.Selection, error) {
+	doc, err := t.newHTMLDocument(url)
 	if err != nil {
 		return nil, err
 	}
 
 	sel := doc.Find(selector)
 	if sel.Length() <= 0 {
-		return nil, fmt.Errorf("불러온 페이지(%s)의 문서구조가 변경되었습니다. CSS셀렉터를 확인하세요", url)
+		return nil, apperrors.New(ErrCodePageStructureChanged, fmt.Sprintf("불러온 페이지(%s)의 문서구조가 변경되었습니다. CSS셀렉터를 확인하세요.", url), fmt.Errorf("CSS셀렉터(%s)와 일치하는 요소가 없습니다", selector))
 	}
 
 	return sel, nil
 }
 
-func webScrape(url string, selector string, f func(int, *goquery.Selection) bool) error {
-	sel, err := newHTMLDocumentSelection(url, selector)
+func (t *task) webScrape(url string, selector string, f func(int, *goquery.Selection) bool) error {
+	sel, err := t.newHTMLDocumentSelection(url, selector)
 	if err != nil {
 		return err
 	}
@@ -52,33 +59,31 @@ func webScrape(url string, selector string, f func(int, *goquery.Selection) bool
 	return nil
 }
 
-// noinspection GoUnhandledErrorResult
-func unmarshalFromResponseJSONData(method, url string, header map[string]string, body io.Reader, v interface{}) error {
-	req, err := http.NewRequest(method, url, body)
-	if err != nil {
-		return fmt.Errorf("페이지(%s) 접근이 실패하였습니다.(error:%s)", url, err)
-	}
-	for key, value := range header {
-		req.Header.Set(key, value)
-	}
+// do 작업에 설정된 HTTP 헤더를 추가하여 요청을 보낸다.
+// 작업에서 직접 설정한 헤더는 환경설정 파일에 설정된 헤더로 덮어쓰지 않는다.
+func (t *task) do(req *http.Request) (*http.Response, error) {
+	return fetcher.Do(t.prepareRequest(req))
+}
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("페이지(%s) 접근이 실패하였습니다.(error:%s)", url, err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("페이지(%s) 접근이 실패하였습니다.(%s)", url, resp.Status)
-	}
-	defer resp.Body.Close()
+// doWithRetry do()와 같지만, 기본 재시도 조건을 만족하지 않더라도 shouldRetry를 만족하면 재시도한다.
+// 재시도 횟수와 대기 시간은 환경설정 파일에 설정된 재시도 설정을 따른다.
+func (t *task) doWithRetry(req *http.Request, shouldRetry RetryPredicate) (*http.Response, error) {
+	return DoWithRetry(fetcher, t.prepareRequest(req), shouldRetry)
+}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("불러온 페이지(%s) 데이터를 읽을 수 없습니다.(error:%s)", url, err)
+// prepareRequest 요청에 작업에 설정된 HTTP 헤더, 실행 컨텍스트 및 프록시 서버를 적용한다.
+func (t *task) prepareRequest(req *http.Request) *http.Request {
+	for key, value := range t.headers {
+		if req.Header.Get(key) == "" {
+			req.Header.Set(key, value)
+		}
 	}
-
-	if err = json.Unmarshal(bodyBytes, v); err != nil {
-		return fmt.Errorf("불러온 페이지(%s) 데이터의 JSON 변환이 실패하였습니다.(error:%s)", url, err)
+	if t.runCtx != nil {
+		req = req.WithContext(t.runCtx)
+	}
+	if t.proxyURL != nil {
+		req = req.WithContext(withProxyURL(req.Context(), t.proxyURL))
 	}
 
-	return nil
+	return req
 }
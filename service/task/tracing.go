@@ -0,0 +1,102 @@
+package task
+
+import (
+	"context"
+	"github.com/darkkaiser/notify-server/g"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"strings"
+)
+
+const tracerName = "github.com/darkkaiser/notify-server/service/task"
+
+// tracer 작업 실행과 HTTP 요청의 스팬을 생성한다.
+// TracerProvider가 등록되지 않은 경우 아무 일도 하지 않는 no-op 트레이서로 동작한다.
+var tracer = otel.Tracer(tracerName)
+
+// newTracerProvider OTLP(HTTP) 익스포터로 스팬을 전송하는 TracerProvider를 생성하고 전역으로 등록한다.
+// endpoint가 입력되지 않은 경우 nil을 반환하며, 이때 모든 스팬은 no-op으로 처리된다.
+func newTracerProvider(endpoint string) (*sdktrace.TracerProvider, error) {
+	if endpoint == "" {
+		return nil, nil
+	}
+
+	// 'http://'로 시작되는 엔드포인트는 TLS를 사용하지 않는다.
+	options := []otlptracehttp.Option{}
+	if strings.HasPrefix(endpoint, "http://") == true {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	endpoint = strings.TrimPrefix(strings.TrimPrefix(endpoint, "http://"), "https://")
+	options = append(options, otlptracehttp.WithEndpoint(endpoint))
+
+	exporter, err := otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(g.AppName), semconv.ServiceVersionKey.String(g.AppVersion))),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp, nil
+}
+
+// tracingFetcher 요청마다 'http.client' 스팬을 생성한다.
+type tracingFetcher struct {
+	inner Fetcher
+}
+
+func NewTracingFetcher(inner Fetcher) RetryFetcher {
+	return &tracingFetcher{inner: inner}
+}
+
+func (f *tracingFetcher) Do(req *http.Request) (*http.Response, error) {
+	return f.do(req, f.inner.Do)
+}
+
+func (f *tracingFetcher) DoWithRetry(req *http.Request, shouldRetry RetryPredicate) (*http.Response, error) {
+	return f.do(req, func(req *http.Request) (*http.Response, error) {
+		return DoWithRetry(f.inner, req, shouldRetry)
+	})
+}
+
+func (f *tracingFetcher) do(req *http.Request, do func(req *http.Request) (*http.Response, error)) (*http.Response, error) {
+	ctx, span := tracer.Start(req.Context(), "http.client", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.HTTPURLKey.String(req.URL.String()),
+		semconv.HTTPMethodKey.String(req.Method),
+	))
+	defer span.End()
+
+	resp, err := do(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	return resp, nil
+}
+
+// taskRunSpanAttributes 'task.run' 스팬에 기록되는 작업 정보
+func taskRunSpanAttributes(t *task) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("task.id", string(t.ID())),
+		attribute.String("task.command_id", string(t.CommandID())),
+		attribute.String("task.instance_id", string(t.InstanceID())),
+		attribute.String("task.run_by", t.RunBy().String()),
+	}
+}
@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/robfig/cron/v3"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 작업 스케쥴러와 동일하게 초 단위 필드를 포함한 실행 주기를 해석한다.
+var cronParser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+const (
+	// 실행 시간이 겹치는지 확인하는 기간
+	cronConflictCheckPeriod = 24 * time.Hour
+
+	// 실행 시간의 차이가 이 시간 이내이면 겹치는 것으로 판단한다.
+	cronConflictThreshold = time.Second
+)
+
+// ValidateCronExpression 실행 주기의 유효성을 확인한다.
+func ValidateCronExpression(expr string) error {
+	if _, err := cronParser.Parse(expr); err != nil {
+		return fmt.Errorf("실행 주기(%s)가 유효하지 않습니다.(error:%s)", expr, err)
+	}
+	return nil
+}
+
+// ValidateCronExpressionWithTZ 'TZ=Asia/Seoul 0 0 9 * * *'와 같이 타임존이 지정된 실행 주기의 유효성을 확인한다.
+// 타임존이 지정되지 않은 경우 ValidateCronExpression()과 동일하게 동작한다.
+func ValidateCronExpressionWithTZ(expr string) error {
+	expr = strings.TrimSpace(expr)
+
+	if strings.HasPrefix(expr, "TZ=") == true || strings.HasPrefix(expr, "CRON_TZ=") == true {
+		i := strings.IndexAny(expr, " \t")
+		if i == -1 {
+			return fmt.Errorf("실행 주기(%s)에 타임존만 입력되었습니다", expr)
+		}
+
+		tz := expr[strings.Index(expr, "=")+1 : i]
+		if _, err := time.LoadLocation(tz); err != nil {
+			return fmt.Errorf("실행 주기(%s)의 타임존(%s)이 유효하지 않습니다.(error:%s)", expr, tz, err)
+		}
+
+		return ValidateCronExpression(strings.TrimSpace(expr[i:]))
+	}
+
+	return ValidateCronExpression(expr)
+}
+
+// NextCronFireTimes from 이후에 실행 주기에 따라 실행될 n개의 실행 시간을 반환한다.
+// 실행 주기에 타임존이 지정된 경우 반환되는 시간도 해당 타임존의 시간이다.
+func NextCronFireTimes(expr string, from time.Time, n int) ([]time.Time, error) {
+	schedule, err := cronParser.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("실행 주기(%s)가 유효하지 않습니다.(error:%s)", expr, err)
+	}
+
+	// Next()는 from의 타임존으로 변환된 시간을 반환하므로 실행 주기에 지정된 타임존으로 다시 변환한다.
+	loc := from.Location()
+	if spec, ok := schedule.(*cron.SpecSchedule); ok == true && spec.Location != nil {
+		loc = spec.Location
+	}
+
+	fireTimes := make([]time.Time, 0, n)
+	for t := schedule.Next(from); t.IsZero() == false && len(fireTimes) < n; t = schedule.Next(t) {
+		fireTimes = append(fireTimes, t.In(loc))
+	}
+
+	return fireTimes, nil
+}
+
+// CronConflictPair 실행 시간이 겹치는 두 실행 주기
+type CronConflictPair struct {
+	First  string
+	Second string
+
+	// 처음으로 실행 시간이 겹치는 시간
+	At time.Time
+}
+
+// ValidateCronConflict 현재 시간부터 24시간 이내에 1초 이내의 간격으로 함께 실행되는 실행 주기 쌍을 반환한다.
+// 유효하지 않은 실행 주기는 무시한다.
+func ValidateCronConflict(exprs []string) []CronConflictPair {
+	return cronConflicts(exprs, time.Now())
+}
+
+func cronConflicts(exprs []string, from time.Time) []CronConflictPair {
+	to := from.Add(cronConflictCheckPeriod)
+
+	activations := make([][]time.Time, len(exprs))
+	for i, expr := range exprs {
+		schedule, err := cronParser.Parse(expr)
+		if err != nil {
+			continue
+		}
+
+		for t := schedule.Next(from); t.IsZero() == false && t.After(to) == false; t = schedule.Next(t) {
+			activations[i] = append(activations[i], t)
+		}
+	}
+
+	var conflicts []CronConflictPair
+	for i := 0; i < len(exprs); i++ {
+		for j := i + 1; j < len(exprs); j++ {
+			if at, ok := firstCronConflict(activations[i], activations[j]); ok == true {
+				conflicts = append(conflicts, CronConflictPair{First: exprs[i], Second: exprs[j], At: at})
+			}
+		}
+	}
+
+	sort.SliceStable(conflicts, func(i, j int) bool { return conflicts[i].At.Before(conflicts[j].At) })
+
+	return conflicts
+}
+
+// firstCronConflict 정렬된 두 실행 시간 목록에서 처음으로 실행 시간이 겹치는 시간을 구한다.
+func firstCronConflict(a, b []time.Time) (time.Time, bool) {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		d := a[i].Sub(b[j])
+		if d < 0 {
+			d = -d
+		}
+		if d <= cronConflictThreshold {
+			if a[i].Before(b[j]) == true {
+				return a[i], true
+			}
+			return b[j], true
+		}
+
+		if a[i].Before(b[j]) == true {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestValidateCronExpressionWithTZ(t *testing.T) {
+	cases := []struct {
+		expr  string
+		valid bool
+	}{
+		{expr: "0 0 9 * * *", valid: true},
+		{expr: "@every 1h", valid: true},
+		{expr: "TZ=Asia/Seoul 0 0 9 * * *", valid: true},
+		{expr: "CRON_TZ=UTC 0 30 * * * *", valid: true},
+		{expr: "0 9 * * *", valid: false},
+		{expr: "TZ=Asia/Nowhere 0 0 9 * * *", valid: false},
+		{expr: "TZ=Asia/Seoul", valid: false},
+		{expr: "TZ=Asia/Seoul 0 0 25 * * *", valid: false},
+	}
+
+	for _, c := range cases {
+		err := ValidateCronExpressionWithTZ(c.expr)
+		if c.valid == true {
+			assert.NoError(t, err, c.expr)
+		} else {
+			assert.Error(t, err, c.expr)
+		}
+	}
+}
+
+func TestCronConflicts(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local)
+
+	conflicts := cronConflicts([]string{"0 0 9 * * *", "1 0 9 * * *", "0 30 9 * * *", "invalid"}, from)
+	assert.Len(t, conflicts, 1)
+	assert.Equal(t, "0 0 9 * * *", conflicts[0].First)
+	assert.Equal(t, "1 0 9 * * *", conflicts[0].Second)
+	assert.Equal(t, time.Date(2023, 1, 1, 9, 0, 0, 0, time.Local), conflicts[0].At)
+
+	assert.Empty(t, cronConflicts([]string{"0 0 9 * * *", "0 0 21 * * *"}, from))
+}
+
+func TestNextCronFireTimes(t *testing.T) {
+	from := time.Date(2025, 1, 15, 8, 0, 0, 0, time.Local)
+
+	fireTimes, err := NextCronFireTimes("0 0 9 * * *", from, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2025, 1, 15, 9, 0, 0, 0, time.Local),
+		time.Date(2025, 1, 16, 9, 0, 0, 0, time.Local),
+		time.Date(2025, 1, 17, 9, 0, 0, 0, time.Local),
+	}, fireTimes)
+
+	// 타임존이 지정된 경우 해당 타임존의 시간을 반환한다.
+	fireTimes, err = NextCronFireTimes("TZ=Asia/Seoul 0 0 9 * * *", time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC), 1)
+	assert.NoError(t, err)
+	assert.Len(t, fireTimes, 1)
+	assert.Equal(t, "2025-01-16T09:00:00+09:00", fireTimes[0].Format(time.RFC3339))
+
+	_, err = NextCronFireTimes("invalid", from, 1)
+	assert.Error(t, err)
+}
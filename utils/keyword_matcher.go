@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// 정규표현식으로 처리되는 키워드의 접두어
+const KeywordRegexPrefix = "regex:"
+
+// keywordRule 하나의 키워드를 나타낸다.
+// 키워드가 'regex:' 접두어로 시작하는 경우 정규표현식으로, 그렇지 않은 경우 문자열 포함 여부로 비교한다.
+type keywordRule struct {
+	literal string
+	re      *regexp.Regexp
+}
+
+func (r *keywordRule) match(s string) bool {
+	if r.re != nil {
+		return r.re.MatchString(s)
+	}
+	return strings.Contains(s, r.literal)
+}
+
+// keywordPhrase '+'로 구분된 키워드의 묶음을 나타내며, 모든 키워드가 일치하여야 한다.
+type keywordPhrase []*keywordRule
+
+func (p keywordPhrase) match(s string) bool {
+	for _, rule := range p {
+		if rule.match(s) == false {
+			return false
+		}
+	}
+	return true
+}
+
+// KeywordMatcher 포함 키워드와 제외 키워드를 이용하여 문자열이 조건에 맞는지 확인한다.
+//
+// 포함 키워드는 모두 일치하여야 하며, 하나의 포함 키워드 안에서 '|'로 구분된 키워드는 그 중 하나만 일치하면 된다.
+// 제외 키워드는 하나라도 일치하면 조건에 맞지 않는 것으로 판단한다.
+// '+'로 구분된 키워드(예: 'Samsung+Ultra')는 모든 키워드가 일치하여야 일치하는 것으로 판단한다.
+// '+' 문자 자체를 키워드에 포함하려면 '\+'로 입력한다. 키워드의 끝에 있는 '+'(예: 'S24+')는 구분자가 아닌 문자로 처리한다.
+type KeywordMatcher struct {
+	included [][]keywordPhrase
+	excluded []keywordPhrase
+}
+
+// NewKeywordMatcher 키워드 목록으로 KeywordMatcher를 생성한다.
+// 유효하지 않은 정규표현식은 접두어를 제외한 나머지 문자열을 일반 키워드로 처리한다.
+func NewKeywordMatcher(includedKeywords, excludedKeywords []string) *KeywordMatcher {
+	m, _ := newKeywordMatcher(includedKeywords, excludedKeywords, false)
+	return m
+}
+
+// NewKeywordMatcherWithValidation 키워드 목록으로 KeywordMatcher를 생성한다.
+// 유효하지 않은 정규표현식이 포함된 경우 에러를 반환한다.
+func NewKeywordMatcherWithValidation(includedKeywords, excludedKeywords []string) (*KeywordMatcher, error) {
+	return newKeywordMatcher(includedKeywords, excludedKeywords, true)
+}
+
+func newKeywordMatcher(includedKeywords, excludedKeywords []string, validate bool) (*KeywordMatcher, error) {
+	m := &KeywordMatcher{}
+
+	for _, k := range includedKeywords {
+		var phrases []keywordPhrase
+
+		// 정규표현식은 '|' 문자를 포함할 수 있으므로 분리하지 않는다.
+		var keywords []string
+		if strings.HasPrefix(k, KeywordRegexPrefix) == true {
+			keywords = []string{k}
+		} else {
+			keywords = SplitExceptEmptyItems(k, "|")
+		}
+
+		for _, keyword := range keywords {
+			phrase, err := newKeywordPhrase(keyword)
+			if err != nil && validate == true {
+				return nil, err
+			}
+			if len(phrase) > 0 {
+				phrases = append(phrases, phrase)
+			}
+		}
+
+		if len(phrases) > 0 {
+			m.included = append(m.included, phrases)
+		}
+	}
+
+	for _, k := range excludedKeywords {
+		phrase, err := newKeywordPhrase(k)
+		if err != nil && validate == true {
+			return nil, err
+		}
+		if len(phrase) > 0 {
+			m.excluded = append(m.excluded, phrase)
+		}
+	}
+
+	return m, nil
+}
+
+func newKeywordPhrase(keyword string) (keywordPhrase, error) {
+	// 정규표현식은 '+' 문자를 포함할 수 있으므로 분리하지 않는다.
+	if strings.HasPrefix(keyword, KeywordRegexPrefix) == true {
+		rule, err := newKeywordRule(keyword)
+		return keywordPhrase{rule}, err
+	}
+
+	var phrase keywordPhrase
+	for _, k := range splitKeywordPhrase(keyword) {
+		rule, err := newKeywordRule(k)
+		if err != nil {
+			return nil, err
+		}
+		phrase = append(phrase, rule)
+	}
+
+	return phrase, nil
+}
+
+// splitKeywordPhrase 키워드를 '+'로 구분하여 반환한다.
+// '\+'는 구분자가 아닌 '+' 문자로 처리하며, 구분된 키워드가 비어 있는 경우(예: 'S24+', 'S24++Ultra')에는 앞의 키워드에 '+' 문자를 붙인다.
+func splitKeywordPhrase(keyword string) []string {
+	var keywords []string
+
+	var sb strings.Builder
+	appendKeyword := func() {
+		k := strings.TrimSpace(sb.String())
+		sb.Reset()
+
+		if k != "" {
+			keywords = append(keywords, k)
+		} else if len(keywords) > 0 {
+			keywords[len(keywords)-1] += "+"
+		}
+	}
+
+	for i := 0; i < len(keyword); i++ {
+		switch {
+		case keyword[i] == '\\' && i+1 < len(keyword) && keyword[i+1] == '+':
+			sb.WriteByte('+')
+			i++
+		case keyword[i] == '+':
+			appendKeyword()
+		default:
+			sb.WriteByte(keyword[i])
+		}
+	}
+	appendKeyword()
+
+	return keywords
+}
+
+func newKeywordRule(keyword string) (*keywordRule, error) {
+	if strings.HasPrefix(keyword, KeywordRegexPrefix) == false {
+		return &keywordRule{literal: keyword}, nil
+	}
+
+	pattern := keyword[len(KeywordRegexPrefix):]
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return &keywordRule{literal: pattern}, fmt.Errorf("정규표현식(%s)이 유효하지 않습니다.(error:%s)", pattern, err)
+	}
+
+	return &keywordRule{re: re}, nil
+}
+
+// Match 문자열이 키워드 조건에 맞는지 확인한다.
+func (m *KeywordMatcher) Match(s string) bool {
+	for _, phrases := range m.included {
+		var contains = false
+		for _, phrase := range phrases {
+			if phrase.match(s) == true {
+				contains = true
+				break
+			}
+		}
+		if contains == false {
+			return false
+		}
+	}
+
+	for _, phrase := range m.excluded {
+		if phrase.match(s) == true {
+			return false
+		}
+	}
+
+	return true
+}
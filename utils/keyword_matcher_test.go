@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeywordMatcher_Match(t *testing.T) {
+	cases := []struct {
+		s                string
+		includedKeywords []string
+		excludedKeywords []string
+		expected         bool
+	}{
+		{s: "트루락 키즈업 90포", includedKeywords: nil, excludedKeywords: nil, expected: true},
+		{s: "트루락 키즈업 90포", includedKeywords: []string{"트루락", "키즈업"}, excludedKeywords: nil, expected: true},
+		{s: "트루락 키즈업 90포", includedKeywords: []string{"트루락", "피토메스"}, excludedKeywords: nil, expected: false},
+		{s: "트루락 키즈업 90포", includedKeywords: []string{"트루락", "90포|3개월"}, excludedKeywords: nil, expected: true},
+		{s: "트루락 키즈업 3개월", includedKeywords: []string{"트루락", "90포|3개월"}, excludedKeywords: nil, expected: true},
+		{s: "트루락 키즈업 30포", includedKeywords: []string{"트루락", "90포|3개월"}, excludedKeywords: nil, expected: false},
+		{s: "트루락 키즈업 90포", includedKeywords: []string{"트루락"}, excludedKeywords: []string{"30포", "60포"}, expected: true},
+		{s: "트루락 키즈업 60포", includedKeywords: []string{"트루락"}, excludedKeywords: []string{"30포", "60포"}, expected: false},
+		{s: "2024년 공연", includedKeywords: []string{`regex:^\d{4}년`}, excludedKeywords: nil, expected: true},
+		{s: "공연 2024년", includedKeywords: []string{`regex:^\d{4}년`}, excludedKeywords: nil, expected: false},
+		{s: "갤럭시 S24 Ultra", includedKeywords: []string{`regex:S\d+ (Ultra|Plus)`}, excludedKeywords: nil, expected: true},
+		{s: "갤럭시 S24 Plus", includedKeywords: []string{`regex:S\d+ (Ultra|Plus)`}, excludedKeywords: nil, expected: true},
+		{s: "갤럭시 S24", includedKeywords: []string{`regex:S\d+ (Ultra|Plus)`}, excludedKeywords: nil, expected: false},
+		{s: "갤럭시 S24 Ultra 케이스", includedKeywords: []string{"갤럭시"}, excludedKeywords: []string{`regex:케이스|필름`}, expected: false},
+		{s: "갤럭시 S24 Ultra", includedKeywords: []string{"갤럭시"}, excludedKeywords: []string{`regex:케이스|필름`}, expected: true},
+		{s: "Samsung Galaxy S24 Ultra", includedKeywords: []string{"Samsung+Ultra"}, excludedKeywords: nil, expected: true},
+		{s: "Samsung Galaxy S24 Plus", includedKeywords: []string{"Samsung+Ultra"}, excludedKeywords: nil, expected: false},
+		{s: "Apple iPhone 15 Pro", includedKeywords: []string{"Samsung+Ultra|Apple+Pro"}, excludedKeywords: nil, expected: true},
+		{s: "Apple iPhone 15", includedKeywords: []string{"Samsung+Ultra|Apple+Pro"}, excludedKeywords: nil, expected: false},
+		{s: "Samsung Galaxy S24 Ultra 케이스", includedKeywords: []string{"Samsung"}, excludedKeywords: []string{"Ultra+케이스"}, expected: false},
+		{s: "Samsung Galaxy S24 케이스", includedKeywords: []string{"Samsung"}, excludedKeywords: []string{"Ultra+케이스"}, expected: true},
+		{s: "갤럭시 S24 Ultra", includedKeywords: []string{`regex:S\d+ Ultra`}, excludedKeywords: nil, expected: true},
+		{s: "갤럭시 S24+ 자급제", includedKeywords: []string{"S24+"}, excludedKeywords: nil, expected: true},
+		{s: "갤럭시 S24 Ultra", includedKeywords: []string{"S24+"}, excludedKeywords: nil, expected: false},
+		{s: "갤럭시 S24+ 자급제", includedKeywords: []string{`S24\+`}, excludedKeywords: nil, expected: true},
+		{s: "갤럭시 S24 자급제", includedKeywords: []string{`S24\+`}, excludedKeywords: nil, expected: false},
+		{s: "갤럭시 S24+ 자급제", includedKeywords: []string{`갤럭시+S24\+`}, excludedKeywords: nil, expected: true},
+		{s: "갤럭시 S24+ 자급제", includedKeywords: []string{"S24++자급제"}, excludedKeywords: nil, expected: true},
+		{s: "갤럭시 S24 자급제", includedKeywords: []string{"S24++자급제"}, excludedKeywords: nil, expected: false},
+		{s: "갤럭시 S24+ 케이스", includedKeywords: []string{"갤럭시"}, excludedKeywords: []string{"S24++케이스"}, expected: false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, NewKeywordMatcher(c.includedKeywords, c.excludedKeywords).Match(c.s), c.s)
+	}
+}
+
+func TestNewKeywordMatcherWithValidation(t *testing.T) {
+	assert := assert.New(t)
+
+	m, err := NewKeywordMatcherWithValidation([]string{`regex:^\d+$`}, []string{"제외"})
+	assert.Nil(err)
+	assert.NotNil(m)
+
+	m, err = NewKeywordMatcherWithValidation([]string{`regex:(abc`}, nil)
+	assert.NotNil(err)
+	assert.Nil(m)
+
+	m, err = NewKeywordMatcherWithValidation(nil, []string{`regex:[a-`})
+	assert.NotNil(err)
+	assert.Nil(m)
+
+	// 유효성 검사를 하지 않는 경우, 유효하지 않은 정규표현식은 일반 키워드로 처리된다.
+	assert.True(NewKeywordMatcher([]string{`regex:(abc`}, nil).Match("(abc"))
+	assert.False(NewKeywordMatcher([]string{`regex:(abc`}, nil).Match("abc"))
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// SplitMessage 메시지를 maxLength 글자 이하의 메시지로 나눈다.
+// 항목의 구분(빈 줄), 줄바꿈 순서로 가능한 한 논리적인 경계에서 나누며, 한 줄이 maxLength보다 긴 경우에만 글자 단위로 나눈다.
+func SplitMessage(message string, maxLength int) []string {
+	if maxLength <= 0 {
+		return []string{message}
+	}
+
+	return splitMessage(message, maxLength, []string{"\n\n", "\n"})
+}
+
+func splitMessage(message string, maxLength int, separators []string) []string {
+	if utf8.RuneCountInString(message) <= maxLength {
+		return []string{message}
+	}
+
+	if len(separators) == 0 {
+		var chunks []string
+
+		runes := []rune(message)
+		for len(runes) > maxLength {
+			chunks = append(chunks, string(runes[:maxLength]))
+			runes = runes[maxLength:]
+		}
+		if len(runes) > 0 {
+			chunks = append(chunks, string(runes))
+		}
+
+		return chunks
+	}
+
+	separator := separators[0]
+
+	var chunks []string
+	var chunk string
+	var chunkLength int
+	for _, part := range strings.Split(message, separator) {
+		partLength := utf8.RuneCountInString(part)
+
+		if partLength > maxLength {
+			if chunkLength > 0 {
+				chunks = append(chunks, chunk)
+				chunk, chunkLength = "", 0
+			}
+			chunks = append(chunks, splitMessage(part, maxLength, separators[1:])...)
+			continue
+		}
+
+		if chunkLength == 0 {
+			chunk, chunkLength = part, partLength
+		} else if chunkLength+utf8.RuneCountInString(separator)+partLength <= maxLength {
+			chunk += separator + part
+			chunkLength += utf8.RuneCountInString(separator) + partLength
+		} else {
+			chunks = append(chunks, chunk)
+			chunk, chunkLength = part, partLength
+		}
+	}
+	if chunkLength > 0 {
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
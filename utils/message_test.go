@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestSplitMessage(t *testing.T) {
+	assert.Equal(t, []string{"짧은 메시지"}, SplitMessage("짧은 메시지", 100))
+	assert.Equal(t, []string{"메시지"}, SplitMessage("메시지", 0))
+
+	// 항목의 구분(빈 줄)에서 나눈다.
+	assert.Equal(t, []string{"111\n111\n\n222", "333"}, SplitMessage("111\n111\n\n222\n\n333", 12))
+
+	// 항목이 maxLength보다 긴 경우 줄바꿈에서 나눈다.
+	assert.Equal(t, []string{"aaaa", "bbbb\ncc", "dddd"}, SplitMessage("aaaa\n\nbbbb\ncc\n\ndddd", 7))
+
+	// 한 줄이 maxLength보다 긴 경우 글자 단위로 나눈다.
+	assert.Equal(t, []string{"가나다", "라마바", "사"}, SplitMessage("가나다라마바사", 3))
+
+	chunks := SplitMessage(strings.Repeat("☞ 상품명 10,000원\n\n", 500), 4000)
+	assert.Greater(t, len(chunks), 1)
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, len([]rune(chunk)), 4000)
+	}
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// notificationTemplateFuncs 알림 메시지 템플릿에서 사용할 수 있는 함수 목록
+var notificationTemplateFuncs = template.FuncMap{
+	"formatPrice": FormatCommas,
+	"trim":        Trim,
+	"upper":       strings.ToUpper,
+	"lower":       strings.ToLower,
+}
+
+// NotificationTemplate 사용자가 정의한 형식으로 알림 메시지를 생성한다.
+type NotificationTemplate struct {
+	t *template.Template
+}
+
+// NewNotificationTemplate 템플릿 문자열을 파싱하여 NotificationTemplate을 생성한다.
+func NewNotificationTemplate(name, text string) (*NotificationTemplate, error) {
+	t, err := template.New(name).Funcs(notificationTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("메시지 템플릿이 유효하지 않습니다.(error:%s)", err)
+	}
+
+	return &NotificationTemplate{t: t}, nil
+}
+
+// Render 템플릿에 데이터를 적용하여 알림 메시지를 생성한다.
+func (t *NotificationTemplate) Render(data interface{}) (string, error) {
+	var sb strings.Builder
+	if err := t.t.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("메시지 템플릿 적용이 실패하였습니다.(error:%s)", err)
+	}
+
+	return sb.String(), nil
+}
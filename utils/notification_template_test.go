@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNotificationTemplate_Render(t *testing.T) {
+	assert := assert.New(t)
+
+	data := struct {
+		Title    string
+		LowPrice int
+		Link     string
+	}{Title: "트루락 키즈업 90포", LowPrice: 1234567, Link: "https://example.com/1"}
+
+	tmpl, err := NewNotificationTemplate("test", "💰 {{.Title}} {{.LowPrice | formatPrice}}원\n{{.Link}}")
+	assert.Nil(err)
+	m, err := tmpl.Render(data)
+	assert.Nil(err)
+	assert.Equal("💰 트루락 키즈업 90포 1,234,567원\nhttps://example.com/1", m)
+
+	tmpl, err = NewNotificationTemplate("test", "{{.Title | upper}}")
+	assert.Nil(err)
+	m, err = tmpl.Render(struct{ Title string }{Title: "abc"})
+	assert.Nil(err)
+	assert.Equal("ABC", m)
+
+	// 파싱 오류
+	tmpl, err = NewNotificationTemplate("test", "{{.Title")
+	assert.NotNil(err)
+	assert.Nil(tmpl)
+
+	// 존재하지 않는 필드
+	tmpl, err = NewNotificationTemplate("test", "{{.Unknown}}")
+	assert.Nil(err)
+	_, err = tmpl.Render(data)
+	assert.NotNil(err)
+}
@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// ParseProxyURL 프록시 서버의 URL을 파싱한다.
+// http, https, socks5 스킴만 허용되며, 프록시 인증 정보는 'user:pass@host:port' 형식으로 입력한다.
+// 오류 메시지에 프록시 인증 정보가 노출되지 않도록 비밀번호는 가려서 출력한다.
+func ParseProxyURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("프록시 URL의 형식이 유효하지 않습니다.(error:%s)", err.(*url.Error).Err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5" {
+		return nil, fmt.Errorf("프록시 URL(%s)은 http, https, socks5 스킴만 허용됩니다", u.Redacted())
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("프록시 URL(%s)에 호스트가 입력되지 않았습니다", u.Redacted())
+	}
+
+	return u, nil
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// LoadCACertPool PEM 파일의 CA 인증서를 시스템 인증서 저장소에 추가한 인증서 풀을 반환한다.
+// 시스템 인증서 저장소를 읽을 수 없는 경우에는 PEM 파일의 CA 인증서만 포함된 인증서 풀을 반환한다.
+func LoadCACertPool(caCertFile string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(caCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("CA 인증서 파일(%s)을 읽을 수 없습니다.(error:%s)", caCertFile, err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if pool.AppendCertsFromPEM(data) == false {
+		return nil, fmt.Errorf("CA 인증서 파일(%s)에 유효한 PEM 형식의 인증서가 없습니다", caCertFile)
+	}
+
+	return pool, nil
+}
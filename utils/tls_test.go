@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadCACertPool(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "notify-server test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	validFile := filepath.Join(dir, "ca.pem")
+	assert.NoError(t, os.WriteFile(validFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	assert.NoError(t, os.WriteFile(invalidFile, []byte("not a certificate"), 0644))
+
+	pool, err := LoadCACertPool(validFile)
+	assert.NoError(t, err)
+	assert.NotNil(t, pool)
+
+	_, err = LoadCACertPool(invalidFile)
+	assert.Error(t, err)
+
+	_, err = LoadCACertPool(filepath.Join(dir, "notfound.pem"))
+	assert.Error(t, err)
+}
@@ -1,9 +1,13 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	log "github.com/sirupsen/logrus"
+	"html"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -36,6 +40,33 @@ func Trim(s string) string {
 	return strings.Join(strings.Fields(strings.TrimSpace(s)), " ")
 }
 
+// htmlTagRegexp HTML 태그
+var htmlTagRegexp = regexp.MustCompile(`<[^<>]*>`)
+
+// StripHTML 문자열에 포함된 HTML 태그를 제거한다.
+func StripHTML(s string) string {
+	return htmlTagRegexp.ReplaceAllString(s, "")
+}
+
+// HTMLEntityDecode 문자열에 포함된 HTML 엔티티('&amp;', '&lt;', '&#39;' 등)를 원래의 문자로 변환한다.
+func HTMLEntityDecode(s string) string {
+	return html.UnescapeString(s)
+}
+
+// TruncateWithEllipsis 문자열을 바이트가 아닌 유니코드 문자(rune) 단위로 maxRunes 글자까지 자르고, 잘린 경우 '…'를 덧붙인다.
+// 한글처럼 여러 바이트로 인코딩되는 문자가 중간에 잘리지 않는다.
+func TruncateWithEllipsis(s string, maxRunes int) string {
+	if maxRunes <= 0 {
+		return ""
+	}
+
+	if r := []rune(s); len(r) > maxRunes {
+		return string(r[:maxRunes]) + "…"
+	}
+
+	return s
+}
+
 func TrimMultiLine(s string) string {
 	var ret []string
 	var appendedEmptyLine bool
@@ -76,6 +107,20 @@ func FormatCommas(num int) string {
 	return str
 }
 
+// FormatKRW 금액을 천 단위 구분 기호와 '원' 단위를 붙인 문자열(예: 10,000원)로 반환한다.
+func FormatKRW(price int) string {
+	return FormatCommas(price) + "원"
+}
+
+// ParseKRW 천 단위 구분 기호와 '원' 단위가 포함된 금액 문자열(예: 10,000원)을 숫자로 변환한다.
+func ParseKRW(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, "원")
+	s = strings.ReplaceAll(s, ",", "")
+
+	return strconv.Atoi(strings.TrimSpace(s))
+}
+
 func SplitExceptEmptyItems(s, sep string) []string {
 	tokens := strings.Split(s, sep)
 
@@ -89,3 +134,9 @@ func SplitExceptEmptyItems(s, sep string) []string {
 
 	return t
 }
+
+// HashKey 키 값을 SHA-256으로 해싱하여 16진수 문자열로 반환한다.
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
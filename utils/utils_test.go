@@ -179,6 +179,36 @@ func TestFormatCommas(t *testing.T) {
 	}
 }
 
+func TestFormatKRW(t *testing.T) {
+	assert.Equal(t, "0원", FormatKRW(0))
+	assert.Equal(t, "10,000원", FormatKRW(10000))
+	assert.Equal(t, "1,234,567원", FormatKRW(1234567))
+}
+
+func TestParseKRW(t *testing.T) {
+	cases := []struct {
+		s        string
+		expected int
+		hasError bool
+	}{
+		{s: "10,000원", expected: 10000},
+		{s: " 1,234,567 원 ", expected: 1234567},
+		{s: "500", expected: 500},
+		{s: "", hasError: true},
+		{s: "가격문의", hasError: true},
+	}
+
+	for _, c := range cases {
+		price, err := ParseKRW(c.s)
+		if c.hasError == true {
+			assert.NotNil(t, err, c.s)
+		} else {
+			assert.Nil(t, err, c.s)
+			assert.Equal(t, c.expected, price, c.s)
+		}
+	}
+}
+
 func TestSplitExceptEmptyItems(t *testing.T) {
 	var notAssign []string
 
@@ -200,3 +230,48 @@ func TestSplitExceptEmptyItems(t *testing.T) {
 		assert.Equal(t, c.expected, SplitExceptEmptyItems(c.s, c.sep))
 	}
 }
+
+func TestStripHTMLAndHTMLEntityDecode(t *testing.T) {
+	cases := []struct {
+		s        string
+		expected string
+	}{
+		{s: "Galaxy &lt;b&gt;S25&lt;/b&gt; &amp; Ultra", expected: "Galaxy S25 & Ultra"},
+		{s: "<b>Galaxy</b> S25 &#39;Ultra&#39;", expected: "Galaxy S25 'Ultra'"},
+		{s: "5 &lt; 10", expected: "5 < 10"},
+		{s: "Galaxy S25", expected: "Galaxy S25"},
+		{s: "", expected: ""},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, StripHTML(HTMLEntityDecode(c.s)), c.s)
+	}
+
+	assert.Equal(t, "Galaxy S25", StripHTML("<b>Galaxy</b> <i>S25</i>"))
+	assert.Equal(t, "Galaxy <b>S25</b> & Ultra", HTMLEntityDecode("Galaxy &lt;b&gt;S25&lt;/b&gt; &amp; Ultra"))
+}
+
+func TestTruncateWithEllipsis(t *testing.T) {
+	cases := []struct {
+		s        string
+		maxRunes int
+		expected string
+	}{
+		{s: "뮤지컬 레미제라블", maxRunes: 3, expected: "뮤지컬…"},
+		{s: "뮤지컬", maxRunes: 3, expected: "뮤지컬"},
+		{s: "뮤지컬", maxRunes: 4, expected: "뮤지컬"},
+		{s: "Galaxy S25", maxRunes: 6, expected: "Galaxy…"},
+		{s: "Galaxy", maxRunes: 6, expected: "Galaxy"},
+		{s: "", maxRunes: 5, expected: ""},
+		{s: "뮤지컬", maxRunes: 0, expected: ""},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, TruncateWithEllipsis(c.s, c.maxRunes), c.s)
+	}
+}
+
+func TestHashKey(t *testing.T) {
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", HashKey(""))
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", HashKey("hello"))
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// ValidateWebhookURL 알림메시지를 전송할 Webhook URL이 안전하게 사용할 수 있는 URL인지 확인한다.
+// 내부망으로의 요청(SSRF)을 막기 위하여 사설/루프백 IP 주소와 localhost를 허용하지 않으며, https 스킴만 허용한다.
+func ValidateWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("Webhook URL(%s)의 형식이 유효하지 않습니다.(error:%s)", rawURL, err)
+	}
+
+	if u.Scheme != "https" {
+		return fmt.Errorf("Webhook URL(%s)은 https 스킴만 허용됩니다", rawURL)
+	}
+	if u.User != nil {
+		return fmt.Errorf("Webhook URL(%s)에 사용자 인증 정보가 포함되어 있습니다", rawURL)
+	}
+
+	host := u.Hostname()
+	if host == "" {
+		return fmt.Errorf("Webhook URL(%s)에 호스트가 입력되지 않았습니다", rawURL)
+	}
+	if strings.EqualFold(host, "localhost") == true || strings.HasSuffix(strings.ToLower(host), ".localhost") == true {
+		return fmt.Errorf("Webhook URL(%s)의 호스트(%s)는 허용되지 않습니다", rawURL, host)
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.IsPrivate() == true || ip.IsLoopback() == true || ip.IsLinkLocalUnicast() == true || ip.IsUnspecified() == true {
+			return fmt.Errorf("Webhook URL(%s)의 호스트(%s)는 사설 또는 루프백 IP 주소이므로 허용되지 않습니다", rawURL, host)
+		}
+	}
+
+	return nil
+}